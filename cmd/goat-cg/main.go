@@ -1,9 +1,11 @@
 package main
  
 import (
-	"goat-cg/internal/server"
+	"os"
+
+	"goat-cg/internal/cli"
 )
  
 func main() {
-	server.Run()
+	os.Exit(cli.Run(os.Args[1:]))
 }
\ No newline at end of file
@@ -21,6 +21,8 @@ type Config struct {
 
 	JwtSecretKey string
 	LogLevel string
+
+	InvitationExpiresDays string
 }
 
 var cf Config
@@ -44,6 +46,8 @@ func init() {
 
 	cf.JwtSecretKey = os.Getenv("JWT_SECRET_KEY")
 	cf.LogLevel = os.Getenv("LOG_LEVEL")
+
+	cf.InvitationExpiresDays = os.Getenv("INVITATION_EXPIRES_DAYS")
 }
 
 
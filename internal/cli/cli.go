@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"os"
+	"fmt"
+	"flag"
+	"errors"
+	"strings"
+
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/model"
+	"goat-cg/internal/service"
+	"goat-cg/internal/server"
+)
+
+
+const usage = `usage: goat-cg <command> [options]
+
+commands:
+  serve      start the web server (default)
+  generate   generate code of a project into a directory
+  export     export table definitions of a project (markdown, html, csv, xlsx)
+  import     import table definitions of a project (csv, xlsx)
+  user       manage users (list, create, passwd, delete)
+
+run "goat-cg <command> -h" for the options of the command.
+`
+
+
+// Run run the command of args and return the exit code.
+// the commands use the services directly against the DB of the config. (ENV)
+func Run(args []string) int {
+	if len(args) == 0 {
+		server.Run()
+		return 0
+	}
+
+	var err error
+	switch args[0] {
+	case "serve":
+		server.Run()
+	case "generate":
+		err = runGenerate(args[1:])
+	case "export":
+		err = runExport(args[1:])
+	case "import":
+		err = runImport(args[1:])
+	case "user":
+		err = runUser(args[1:])
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(os.Stdout, usage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n%s", args[0], usage)
+		return 2
+	}
+
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goat-cg %s: %s\n", args[0], errorMessage(err))
+		return 1
+	}
+	return 0
+}
+
+
+// newFlagSet flag set of the command. errors of the flags are returned by Parse.
+func newFlagSet(name, synopsis string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: goat-cg %s\n\noptions:\n", synopsis)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+
+// getProject get the project of "owner/name".
+func getProject(name string) (model.Project, error) {
+	owner, projectName, ok := strings.Cut(name, "/")
+	if !ok || owner == "" || projectName == "" {
+		return model.Project{}, errors.New("--project must be owner/name.")
+	}
+
+	project, err := service.NewProjectService().GetProjectByName(owner, projectName)
+	if err != nil {
+		return project, fmt.Errorf("project %s not found.", name)
+	}
+	return project, nil
+}
+
+
+// getTables get the tables of the names. (all tables not deleted if names is empty)
+func getTables(projectId int, names []string) ([]model.Table, error) {
+	tables, err := service.NewTableService().GetTables(projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		var ret []model.Table
+		for _, t := range tables {
+			if t.DelFlg != 1 {
+				ret = append(ret, t)
+			}
+		}
+		return ret, nil
+	}
+
+	byName := map[string]model.Table{}
+	for _, t := range tables {
+		byName[t.TableName] = t
+	}
+	var ret []model.Table
+	for _, name := range names {
+		t, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("table %s not found.", name)
+		}
+		ret = append(ret, t)
+	}
+	return ret, nil
+}
+
+
+// getViews get the views of the names. (all views if names is empty)
+func getViews(projectId int, names []string) ([]model.View, error) {
+	views, err := service.NewViewService().GetViews(projectId)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return views, nil
+	}
+
+	byName := map[string]model.View{}
+	for _, v := range views {
+		byName[v.ViewName] = v
+	}
+	var ret []model.View
+	for _, name := range names {
+		v, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("view %s not found.", name)
+		}
+		ret = append(ret, v)
+	}
+	return ret, nil
+}
+
+
+// splitList "a, b,c" -> ["a", "b", "c"]
+func splitList(s string) []string {
+	var ret []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+
+// errorMessage message of the errors of the services.
+func errorMessage(err error) string {
+	switch e := err.(type) {
+	case errs.UniqueConstraintError:
+		return fmt.Sprintf("%s must be unique.", e.Column)
+	case errs.InvalidValueError:
+		return fmt.Sprintf("%s: %s", e.Field, e.Message)
+	}
+	return err.Error()
+}
\ No newline at end of file
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"os"
+	"io"
+	"fmt"
+	"errors"
+	"strings"
+	"path/filepath"
+	"archive/zip"
+
+	"goat-cg/internal/dto"
+	"goat-cg/internal/service"
+)
+
+
+//goat-cg generate --project owner/name --dialect postgresql --tables a,b --out dir
+func runGenerate(args []string) error {
+	fs := newFlagSet("generate", "generate --project owner/name [--generator goat] [--tables a,b] --out dir")
+	project := fs.String("project", "", "project as owner/name (required)")
+	generator := fs.String("generator", "goat", strings.Join(service.CODEGEN_GENERATORS, ", "))
+	dialect := fs.String("dialect", "sqlite3", "sqlite3, postgresql or mysql (goat, orm, seed)")
+	tables := fs.String("tables", "", "comma separated table names (default all tables not deleted)")
+	views := fs.String("views", "", "comma separated view names (goat, default all views)")
+	orm := fs.String("orm", "gorm", "gorm, sqlx, ent, sqlc, sqlalchemy, jpa or prisma (orm)")
+	zod := fs.Bool("zod", false, "generate zod schemas (typescript)")
+	grpc := fs.Bool("grpc", false, "generate gRPC services (proto)")
+	rows := fs.Int("rows", 10, "fake rows per table, 0-1000 (seed)")
+	format := fs.String("format", "sql", "sql, csv or go (seed)")
+	out := fs.String("out", "", "output directory, or a .zip file to keep the archive (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *project == "" || *out == "" {
+		fs.Usage()
+		return errors.New("--project and --out are required.")
+	}
+
+	p, err := getProject(*project)
+	if err != nil {
+		return err
+	}
+	ts, err := getTables(p.ProjectId, splitList(*tables))
+	if err != nil {
+		return err
+	}
+	var tableIds []int
+	for _, t := range ts {
+		tableIds = append(tableIds, t.TableId)
+	}
+
+	var viewIds []int
+	if *generator == "goat" {
+		vs, err := getViews(p.ProjectId, splitList(*views))
+		if err != nil {
+			return err
+		}
+		for _, v := range vs {
+			viewIds = append(viewIds, v.ViewId)
+		}
+	}
+
+	fpath, err := service.NewCodegenService().Generate(*generator, dto.Codegen{
+		ProjectName: p.ProjectName,
+		Rdbms: *dialect,
+		TableIds: tableIds,
+		ViewIds: viewIds,
+		Zod: *zod,
+		Grpc: *grpc,
+		Orm: *orm,
+		SeedFormat: *format,
+		SeedRows: *rows,
+		SaveProtoFields: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer os.Remove(fpath)
+
+	if strings.HasSuffix(*out, ".zip") {
+		if err = os.MkdirAll(filepath.Dir(*out), 0755); err != nil {
+			return err
+		}
+		return copyFile(fpath, *out)
+	}
+
+	n, err := extractArchive(fpath, *out)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "%d files generated into %s\n", n, *out)
+	return nil
+}
+
+
+// extractArchive extract the zip of the codegen service into dir and return the number of files.
+// the directory of the archive ("tmp/<name>-<datetime>-<random>/") is removed from the paths.
+func extractArchive(fpath, dir string) (int, error) {
+	r, err := zip.OpenReader(fpath)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	prefix := strings.TrimPrefix(strings.TrimSuffix(fpath, ".zip"), "./") + "/"
+	n := 0
+	for _, f := range r.File {
+		name := strings.TrimPrefix(f.Name, prefix)
+		if name == "" || f.FileInfo().IsDir() {
+			continue
+		}
+		dst := filepath.Join(dir, filepath.FromSlash(name))
+		if !strings.HasPrefix(dst, filepath.Clean(dir) + string(os.PathSeparator)) {
+			return n, fmt.Errorf("invalid path in archive: %s", f.Name)
+		}
+
+		if err = extractFile(f, dst); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+
+func extractFile(f *zip.File, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	src, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	w, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	_, err = io.Copy(w, src)
+	return err
+}
+
+
+func copyFile(src, dst string) error {
+	r, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	w, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	_, err = io.Copy(w, r)
+	return err
+}
\ No newline at end of file
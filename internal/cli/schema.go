@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"os"
+	"fmt"
+	"errors"
+	"path/filepath"
+
+	"goat-cg/internal/dto"
+	"goat-cg/internal/model"
+	"goat-cg/internal/service"
+	"goat-cg/internal/shared/form"
+)
+
+
+//goat-cg export --project owner/name --format xlsx --out schema.xlsx
+func runExport(args []string) error {
+	fs := newFlagSet("export", "export --project owner/name [--format csv] [--tables a,b] [--out file]")
+	project := fs.String("project", "", "project as owner/name (required)")
+	format := fs.String("format", "csv", "markdown, html (zip), csv or xlsx")
+	tables := fs.String("tables", "", "comma separated table names (default all tables not deleted)")
+	out := fs.String("out", "", "output file (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *project == "" {
+		fs.Usage()
+		return errors.New("--project is required.")
+	}
+
+	p, err := getProject(*project)
+	if err != nil {
+		return err
+	}
+	ts, err := getTables(p.ProjectId, splitList(*tables))
+	if err != nil {
+		return err
+	}
+	var tableIds []int
+	for _, t := range ts {
+		tableIds = append(tableIds, t.TableId)
+	}
+
+	ds := service.NewDictionaryService()
+	dictionary, err := ds.GetDictionary(p, tableIds)
+	if err != nil {
+		return err
+	}
+
+	var b []byte
+	switch *format {
+	case "markdown":
+		b = []byte(ds.ToMarkdown(dictionary))
+	case "html":
+		b, err = ds.ToHtmlSite(dictionary)
+	case "csv":
+		b, err = ds.ToCsv(dictionary)
+	case "xlsx":
+		b, err = ds.ToXlsx(dictionary)
+	default:
+		return fmt.Errorf("unknown format %s.", *format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(b)
+		return err
+	}
+	return os.WriteFile(*out, b, 0644)
+}
+
+
+// importTable columns of a table in the file.
+type importTable struct {
+	name string
+	table model.Table
+	columns []dto.CreateColumn
+}
+
+
+//goat-cg import --project owner/name --file schema.xlsx
+func runImport(args []string) error {
+	fs := newFlagSet("import", "import --project owner/name --file schema.(csv|xlsx) [--tables a,b] [--dry-run]")
+	project := fs.String("project", "", "project as owner/name (required)")
+	file := fs.String("file", "", "CSV or XLSX in the layout of export (required)")
+	tables := fs.String("tables", "", "comma separated table names (default all tables in the file)")
+	dryRun := fs.Bool("dry-run", false, "validate the file without saving")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *project == "" || *file == "" {
+		fs.Usage()
+		return errors.New("--project and --file are required.")
+	}
+
+	p, err := getProject(*project)
+	if err != nil {
+		return err
+	}
+
+	names := splitList(*tables)
+	if len(names) == 0 {
+		err = withImportFile(*file, func(f *os.File, size int64) error {
+			names, err = form.ImportTableNames(f, *file, size)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(names) == 0 {
+		return errors.New("no table found in the file.")
+	}
+
+	current, err := service.NewTableService().GetTables(p.ProjectId)
+	if err != nil {
+		return err
+	}
+	byName := map[string]model.Table{}
+	for _, t := range current {
+		byName[t.TableName] = t
+	}
+
+	cs := service.NewColumnService()
+	var its []importTable
+	var messages []string
+	for _, name := range names {
+		it := importTable{name: name, table: byName[name]}
+
+		var columns []model.Column
+		if it.table.TableId != 0 {
+			if columns, err = cs.GetColumns(it.table.TableId); err != nil {
+				return err
+			}
+		}
+
+		var rows []form.ImportColumnRow
+		err = withImportFile(*file, func(f *os.File, size int64) error {
+			records, err := form.ReadImportRecords(f, *file, size, name)
+			if err != nil {
+				return err
+			}
+			rows, err = form.ParseImportRecords(records, name, columns)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %s", name, err.Error())
+		}
+
+		it.columns = form.ToCreateColumns(rows, it.table.TableId, p.UserId)
+		for i, err := range cs.ValidateImportColumns(it.table.TableId, it.columns) {
+			if rows[i].Error == "" && err != nil {
+				rows[i].Error = errorMessage(err)
+			}
+		}
+		for _, r := range rows {
+			if r.Error != "" {
+				messages = append(messages, fmt.Sprintf("%s: line %d: %s: %s", name, r.Line, r.Column.ColumnName, r.Error))
+			}
+		}
+		its = append(its, it)
+	}
+
+	if len(messages) > 0 {
+		for _, m := range messages {
+			fmt.Fprintln(os.Stderr, m)
+		}
+		return fmt.Errorf("%d invalid rows. nothing is imported.", len(messages))
+	}
+
+	for _, it := range its {
+		action := "update"
+		if it.table.TableId == 0 {
+			action = "create"
+		}
+		fmt.Fprintf(os.Stdout, "%s table %s: %d columns\n", action, it.name, len(it.columns))
+		if *dryRun {
+			continue
+		}
+
+		if err = importColumns(p, it); err != nil {
+			return fmt.Errorf("%s: %s", it.name, errorMessage(err))
+		}
+	}
+	return nil
+}
+
+
+// importColumns create the table if it does not exist and import the columns.
+func importColumns(p model.Project, it importTable) error {
+	ts := service.NewTableService()
+	tableId := it.table.TableId
+
+	if tableId == 0 {
+		if err := ts.CreateTable(p.ProjectId, p.UserId, it.name, "", 0); err != nil {
+			return err
+		}
+		tables, err := getTables(p.ProjectId, []string{it.name})
+		if err != nil {
+			return err
+		}
+		tableId = tables[0].TableId
+	}
+
+	return service.NewColumnService().ImportColumns(tableId, it.columns)
+}
+
+
+// withImportFile open the file for each read. (CSV is read from the start)
+func withImportFile(path string, read func(f *os.File, size int64) error) error {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return read(f, info.Size())
+}
\ No newline at end of file
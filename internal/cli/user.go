@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"os"
+	"fmt"
+	"bufio"
+	"errors"
+	"strings"
+	"text/tabwriter"
+
+	"goat-cg/internal/service"
+)
+
+
+const userUsage = `usage: goat-cg user <command> [options]
+
+commands:
+  list     list users
+  create   create a user (--name, --email, --password or --password-stdin)
+  passwd   change the password of a user (--name, --password or --password-stdin)
+  delete   delete a user (--name)
+`
+
+
+//goat-cg user list|create|passwd|delete
+func runUser(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, userUsage)
+		return errors.New("command is required.")
+	}
+
+	switch args[0] {
+	case "list":
+		return runUserList(args[1:])
+	case "create":
+		return runUserCreate(args[1:])
+	case "passwd":
+		return runUserPasswd(args[1:])
+	case "delete":
+		return runUserDelete(args[1:])
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(os.Stdout, userUsage)
+		return nil
+	}
+
+	fmt.Fprint(os.Stderr, userUsage)
+	return fmt.Errorf("unknown command: %s", args[0])
+}
+
+
+//goat-cg user list
+func runUserList(args []string) error {
+	fs := newFlagSet("user list", "user list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	users, err := service.NewUserService().GetUsers()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tUSERNAME\tEMAIL\tCREATED AT")
+	for _, u := range users {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", u.UserId, u.Username, u.Email, u.CreatedAt)
+	}
+	return w.Flush()
+}
+
+
+//goat-cg user create --name alice --email alice@example.com --password-stdin
+func runUserCreate(args []string) error {
+	fs := newFlagSet("user create", "user create --name name --email email (--password pw | --password-stdin)")
+	name := fs.String("name", "", "username (required)")
+	email := fs.String("email", "", "email (required)")
+	password := fs.String("password", "", "password")
+	passwordStdin := fs.Bool("password-stdin", false, "read the password from stdin")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	pw, err := readPassword(*password, *passwordStdin)
+	if err != nil {
+		return err
+	}
+	if *name == "" || *email == "" {
+		fs.Usage()
+		return errors.New("--name and --email are required.")
+	}
+
+	if err = service.NewUserService().Signup(*name, pw, *email); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "user %s created.\n", *name)
+	return nil
+}
+
+
+//goat-cg user passwd --name alice --password-stdin
+func runUserPasswd(args []string) error {
+	fs := newFlagSet("user passwd", "user passwd --name name (--password pw | --password-stdin)")
+	name := fs.String("name", "", "username (required)")
+	password := fs.String("password", "", "new password")
+	passwordStdin := fs.Bool("password-stdin", false, "read the new password from stdin")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" {
+		fs.Usage()
+		return errors.New("--name is required.")
+	}
+
+	pw, err := readPassword(*password, *passwordStdin)
+	if err != nil {
+		return err
+	}
+
+	us := service.NewUserService()
+	user, err := us.GetUserByName(*name)
+	if err != nil {
+		return fmt.Errorf("user %s not found.", *name)
+	}
+	if err = us.UpdatePassword(user.UserId, pw); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "password of %s updated.\n", *name)
+	return nil
+}
+
+
+//goat-cg user delete --name alice
+func runUserDelete(args []string) error {
+	fs := newFlagSet("user delete", "user delete --name name")
+	name := fs.String("name", "", "username (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" {
+		fs.Usage()
+		return errors.New("--name is required.")
+	}
+
+	us := service.NewUserService()
+	user, err := us.GetUserByName(*name)
+	if err != nil {
+		return fmt.Errorf("user %s not found.", *name)
+	}
+	if err = us.DeleteUser(user.UserId); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "user %s deleted.\n", *name)
+	return nil
+}
+
+
+// readPassword password of the flag, or the first line of stdin.
+func readPassword(password string, stdin bool) (string, error) {
+	if stdin {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", errors.New("password not given on stdin.")
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+	if password == "" {
+		return "", errors.New("--password or --password-stdin is required.")
+	}
+	return password, nil
+}
\ No newline at end of file
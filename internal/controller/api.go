@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"errors"
+	"strings"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"goat-cg/internal/core/errs"
+)
+
+
+// Error body of /api/v1: {"error": message, "type": type, "field": field}
+// type: "invalid_value", "unique_constraint", "not_found", "already_registered", "forbidden", "internal"
+// (and "unauthorized" of jwt.JwtAuthApiMiddleware, "forbidden" of middleware.ApiRoleMiddleware)
+
+
+func apiErrorBody(typ, field, message string) gin.H {
+	h := gin.H{"error": message, "type": typ}
+	if field != "" {
+		h["field"] = field
+	}
+	return h
+}
+
+
+// apiError respond the error of the services.
+func apiError(c *gin.Context, err error) {
+	switch e := err.(type) {
+	case errs.InvalidValueError:
+		c.JSON(400, apiErrorBody("invalid_value", e.Field, e.Field + ": " + e.Message))
+	case errs.UniqueConstraintError:
+		c.JSON(409, apiErrorBody("unique_constraint", e.Column, e.Column + " must be unique."))
+	case errs.NotFoundError:
+		c.JSON(404, apiErrorBody("not_found", "", "not found."))
+	case errs.AlreadyRegisteredError:
+		c.JSON(409, apiErrorBody("already_registered", "", "already registered."))
+	case errs.ForbiddenError:
+		c.JSON(403, apiErrorBody("forbidden", "", "permission denied."))
+	default:
+		c.JSON(500, apiErrorBody("internal", "", "error occurred."))
+	}
+	c.Abort()
+}
+
+
+// apiBindError respond the error of binding the request body.
+func apiBindError(c *gin.Context, err error) {
+	var ve validator.ValidationErrors
+	var se *json.SyntaxError
+	var te *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &ve):
+		fe := ve[0]
+		field := jsonFieldName(fe.StructField())
+		message := field + ": " + fe.Tag()
+		if fe.Param() != "" {
+			message += "=" + fe.Param()
+		}
+		c.JSON(400, apiErrorBody("invalid_value", field, message))
+	case errors.As(err, &te):
+		c.JSON(400, apiErrorBody("invalid_value", te.Field, te.Field + ": must be " + te.Type.String()))
+	case errors.As(err, &se):
+		c.JSON(400, apiErrorBody("invalid_value", "", "invalid JSON."))
+	default:
+		c.JSON(400, apiErrorBody("invalid_value", "", "invalid input."))
+	}
+	c.Abort()
+}
+
+
+// apiNotFound respond for ids of the path not found.
+func apiNotFound(c *gin.Context) {
+	apiError(c, errs.NewNotFoundError())
+}
+
+
+// jsonFieldName ColumnName -> column_name
+func jsonFieldName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if 'A' <= r && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
\ No newline at end of file
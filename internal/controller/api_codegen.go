@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"github.com/gin-gonic/gin"
+
+	"goat-cg/internal/shared/form"
+	"goat-cg/internal/shared/roles"
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/service"
+	"goat-cg/internal/model"
+)
+
+
+type CodegenApiController struct {
+	tableService service.TableService
+	viewService service.ViewService
+	codegenService service.CodegenService
+}
+
+
+func NewCodegenApiController() *CodegenApiController {
+	tableService := service.NewTableService()
+	viewService := service.NewViewService()
+	codegenService := service.NewCodegenService()
+	return &CodegenApiController{tableService, viewService, codegenService}
+}
+
+
+//POST /api/v1/:username/:project_name/codegen/:generator
+// respond the zip. table_ids: all tables not deleted if empty. view_ids: goat only.
+func (ctr *CodegenApiController) Generate(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+
+	var form form.PostCodegen
+	if err := c.ShouldBindJSON(&form); err != nil {
+		apiBindError(c, err)
+		return
+	}
+
+	tables, err := ctr.tableService.GetTables(project.ProjectId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	ids := map[int]bool{}
+	for _, t := range tables {
+		ids[t.TableId] = true
+		if len(form.TableIds) == 0 && t.DelFlg != 1 {
+			form.TableIds = append(form.TableIds, t.TableId)
+		}
+	}
+	for _, id := range form.TableIds {
+		if !ids[id] {
+			apiNotFound(c)
+			return
+		}
+	}
+
+	views, err := ctr.viewService.GetViews(project.ProjectId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	ids = map[int]bool{}
+	for _, v := range views {
+		ids[v.ViewId] = true
+	}
+	for _, id := range form.ViewIds {
+		if !ids[id] {
+			apiNotFound(c)
+			return
+		}
+	}
+
+	in := form.ToCodegen(project.ProjectName)
+	in.SaveProtoFields = roles.Has(c.Keys["role"].(string), constant.ROLE_CLS_NOMAL)
+	fpath, err := ctr.codegenService.Generate(c.Param("generator"), in)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	defer os.Remove(fpath)
+
+	c.FileAttachment(fpath, project.ProjectName + "-" + c.Param("generator") + filepath.Ext(fpath))
+}
\ No newline at end of file
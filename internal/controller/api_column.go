@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"goat-cg/internal/core/jwt"
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/shared/form"
+	"goat-cg/internal/service"
+	"goat-cg/internal/model"
+)
+
+
+type ColumnApiController struct {
+	columnService service.ColumnService
+}
+
+
+func NewColumnApiController() *ColumnApiController {
+	columnService := service.NewColumnService()
+	return &ColumnApiController{columnService}
+}
+
+
+//GET /api/v1/:username/:project_name/tables/:table_id/columns
+func (ctr *ColumnApiController) GetColumns(c *gin.Context) {
+	table := c.Keys["table"].(model.Table)
+
+	columns, err := ctr.columnService.GetColumns(table.TableId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, columns)
+}
+
+
+//POST /api/v1/:username/:project_name/tables/:table_id/columns
+func (ctr *ColumnApiController) CreateColumn(c *gin.Context) {
+	userId := jwt.GetUserId(c)
+	table := c.Keys["table"].(model.Table)
+
+	var form form.PostColumn
+	if err := c.ShouldBindJSON(&form); err != nil {
+		apiBindError(c, err)
+		return
+	}
+	form.ColumnId = 0
+
+	if err := ctr.columnService.CreateColumn(form.ToCreateColumn(table.TableId, userId)); err != nil {
+		apiError(c, err)
+		return
+	}
+
+	column, err := ctr.getColumnByName(table.TableId, form.ColumnName)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(201, column)
+}
+
+
+//GET /api/v1/:username/:project_name/tables/:table_id/columns/:column_id
+func (ctr *ColumnApiController) GetColumn(c *gin.Context) {
+	c.JSON(200, c.Keys["column"].(model.Column))
+}
+
+
+//PUT /api/v1/:username/:project_name/tables/:table_id/columns/:column_id
+func (ctr *ColumnApiController) UpdateColumn(c *gin.Context) {
+	userId := jwt.GetUserId(c)
+	table := c.Keys["table"].(model.Table)
+	column := c.Keys["column"].(model.Column)
+
+	var form form.PostColumn
+	if err := c.ShouldBindJSON(&form); err != nil {
+		apiBindError(c, err)
+		return
+	}
+	form.ColumnId = column.ColumnId
+
+	if err := ctr.columnService.UpdateColumn(form.ToCreateColumn(table.TableId, userId)); err != nil {
+		apiError(c, err)
+		return
+	}
+
+	column, err := ctr.columnService.GetColumn(column.ColumnId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, column)
+}
+
+
+//DELETE /api/v1/:username/:project_name/tables/:table_id/columns/:column_id
+func (ctr *ColumnApiController) DeleteColumn(c *gin.Context) {
+	column := c.Keys["column"].(model.Column)
+
+	if err := ctr.columnService.DeleteColumn(column.ColumnId); err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+
+//GET /api/v1/:username/:project_name/tables/:table_id/columns/:column_id/log
+func (ctr *ColumnApiController) GetColumnLog(c *gin.Context) {
+	column := c.Keys["column"].(model.Column)
+
+	columnLog, err := ctr.columnService.GetColumnLog(column.ColumnId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, columnLog)
+}
+
+
+// getColumnByName column created by the request. (services return no id)
+func (ctr *ColumnApiController) getColumnByName(tableId int, columnName string) (model.Column, error) {
+	columns, err := ctr.columnService.GetColumns(tableId)
+	if err != nil {
+		return model.Column{}, err
+	}
+	for _, c := range columns {
+		if c.ColumnName == columnName {
+			return c, nil
+		}
+	}
+	return model.Column{}, errs.NewNotFoundError()
+}
\ No newline at end of file
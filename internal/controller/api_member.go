@@ -0,0 +1,326 @@
+package controller
+
+import (
+	"strconv"
+	"github.com/gin-gonic/gin"
+
+	"goat-cg/internal/core/jwt"
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/shared/form"
+	"goat-cg/internal/service"
+	"goat-cg/internal/model"
+)
+
+
+type MemberApiController struct {
+	memberService service.MemberService
+	projectService service.ProjectService
+}
+
+
+func NewMemberApiController() *MemberApiController {
+	memberService := service.NewMemberService()
+	projectService := service.NewProjectService()
+	return &MemberApiController{memberService, projectService}
+}
+
+
+//GET /api/v1/:username/:project_name/members
+func (ctr *MemberApiController) GetMembers(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+
+	members, err := ctr.memberService.GetMembers(project.ProjectId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, members)
+}
+
+
+//POST /api/v1/:username/:project_name/members
+func (ctr *MemberApiController) Invite(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+
+	var form form.PostMember
+	if err := c.ShouldBindJSON(&form); err != nil {
+		apiBindError(c, err)
+		return
+	}
+	if form.Email == jwt.GetEmail(c) {
+		apiError(c, errs.NewInvalidValueError("email", "cannot invite yourself."))
+		return
+	}
+
+	err := ctr.memberService.Invite(
+		project.ProjectId, jwt.GetUserId(c), c.Keys["role"].(string), form.Email, form.UserRole,
+	)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+
+	members, err := ctr.memberService.GetMembers(project.ProjectId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	for _, m := range members {
+		if m.Email == form.Email {
+			c.JSON(201, m)
+			return
+		}
+	}
+
+	invitations, err := ctr.memberService.GetEmailInvitations(project.ProjectId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	for _, i := range invitations {
+		if i.Email == form.Email {
+			c.JSON(201, i)
+			return
+		}
+	}
+	apiNotFound(c)
+}
+
+
+//GET /api/v1/:username/:project_name/members/:user_id
+func (ctr *MemberApiController) GetMember(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		apiNotFound(c)
+		return
+	}
+
+	member, err := ctr.memberService.GetMember(project.ProjectId, userId)
+	if err != nil {
+		apiNotFound(c)
+		return
+	}
+	c.JSON(200, member)
+}
+
+
+//PUT /api/v1/:username/:project_name/members/:user_id
+func (ctr *MemberApiController) UpdateMemberRole(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		apiNotFound(c)
+		return
+	}
+
+	var form form.PutMemberRole
+	if err = c.ShouldBindJSON(&form); err != nil {
+		apiBindError(c, err)
+		return
+	}
+
+	err = ctr.memberService.UpdateRole(project.ProjectId, c.Keys["role"].(string), userId, form.UserRole)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+
+	member, err := ctr.memberService.GetMember(project.ProjectId, userId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, member)
+}
+
+
+//DELETE /api/v1/:username/:project_name/members/:user_id
+func (ctr *MemberApiController) DeleteMember(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		apiNotFound(c)
+		return
+	}
+
+	if err = ctr.memberService.DeleteMember(project.ProjectId, c.Keys["role"].(string), userId); err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+
+//DELETE /api/v1/:username/:project_name/members/:user_id/invitation
+func (ctr *MemberApiController) RevokeInvitation(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		apiNotFound(c)
+		return
+	}
+
+	err = ctr.memberService.RevokeInvitation(
+		project.ProjectId, jwt.GetUserId(c), c.Keys["role"].(string), userId,
+	)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+
+//GET /api/v1/:username/:project_name/invitations
+// invitations to the emails not signed up yet.
+func (ctr *MemberApiController) GetEmailInvitations(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+
+	invitations, err := ctr.memberService.GetEmailInvitations(project.ProjectId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, invitations)
+}
+
+
+//DELETE /api/v1/:username/:project_name/invitations/:invitation_id
+func (ctr *MemberApiController) RevokeEmailInvitation(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	invitationId, err := strconv.Atoi(c.Param("invitation_id"))
+	if err != nil {
+		apiNotFound(c)
+		return
+	}
+
+	err = ctr.memberService.RevokeEmailInvitation(
+		project.ProjectId, jwt.GetUserId(c), c.Keys["role"].(string), invitationId,
+	)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+
+//GET /api/v1/invitations
+// pending invitations to the user.
+func (ctr *MemberApiController) GetInvitations(c *gin.Context) {
+	invitations, err := ctr.memberService.GetInvitations(jwt.GetUserId(c))
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, invitations)
+}
+
+
+//POST /api/v1/invitations/:project_id/accept
+func (ctr *MemberApiController) AcceptInvitation(c *gin.Context) {
+	ctr.answerInvitation(c, ctr.memberService.AcceptInvitation)
+}
+
+
+//POST /api/v1/invitations/:project_id/decline
+func (ctr *MemberApiController) DeclineInvitation(c *gin.Context) {
+	ctr.answerInvitation(c, ctr.memberService.DeclineInvitation)
+}
+
+
+func (ctr *MemberApiController) answerInvitation(c *gin.Context, answer func(projectId, userId int) error) {
+	projectId, err := strconv.Atoi(c.Param("project_id"))
+	if err != nil {
+		apiNotFound(c)
+		return
+	}
+
+	if err = answer(projectId, jwt.GetUserId(c)); err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+
+//POST /api/v1/:username/:project_name/join
+// request to join the project. (for the users not members of it)
+func (ctr *MemberApiController) RequestJoin(c *gin.Context) {
+	project, err := ctr.projectService.GetProjectByName(c.Param("username"), c.Param("project_name"))
+	if err != nil {
+		apiNotFound(c)
+		return
+	}
+
+	if err = ctr.memberService.RequestJoin(project, jwt.GetUserId(c)); err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(201, gin.H{})
+}
+
+
+//DELETE /api/v1/:username/:project_name/join
+func (ctr *MemberApiController) CancelJoin(c *gin.Context) {
+	project, err := ctr.projectService.GetProjectByName(c.Param("username"), c.Param("project_name"))
+	if err != nil {
+		apiNotFound(c)
+		return
+	}
+
+	if err = ctr.memberService.CancelJoin(project.ProjectId, jwt.GetUserId(c)); err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+
+//POST /api/v1/:username/:project_name/members/:user_id/approve
+func (ctr *MemberApiController) ApproveJoin(c *gin.Context) {
+	var form form.PostMemberRole
+	if err := c.ShouldBindJSON(&form); err != nil {
+		apiBindError(c, err)
+		return
+	}
+
+	ctr.answerJoin(c, func(projectId int, actorRole string, userId int) error {
+		return ctr.memberService.ApproveJoin(projectId, actorRole, userId, form.UserRole)
+	})
+}
+
+
+//POST /api/v1/:username/:project_name/members/:user_id/reject
+func (ctr *MemberApiController) RejectJoin(c *gin.Context) {
+	ctr.answerJoin(c, ctr.memberService.RejectJoin)
+}
+
+
+//POST /api/v1/:username/:project_name/members/:user_id/block
+func (ctr *MemberApiController) BlockMember(c *gin.Context) {
+	ctr.answerJoin(c, ctr.memberService.BlockMember)
+}
+
+
+func (ctr *MemberApiController) answerJoin(c *gin.Context, answer func(projectId int, actorRole string, userId int) error) {
+	project := c.Keys["project"].(model.Project)
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		apiNotFound(c)
+		return
+	}
+
+	if err = answer(project.ProjectId, c.Keys["role"].(string), userId); err != nil {
+		apiError(c, err)
+		return
+	}
+
+	member, err := ctr.memberService.GetMember(project.ProjectId, userId)
+	if err != nil {
+		c.JSON(200, gin.H{})
+		return
+	}
+	c.JSON(200, member)
+}
\ No newline at end of file
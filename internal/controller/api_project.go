@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"goat-cg/internal/core/jwt"
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/shared/form"
+	"goat-cg/internal/service"
+	"goat-cg/internal/model"
+)
+
+
+type ProjectApiController struct {
+	projectService service.ProjectService
+	organizationService service.OrganizationService
+}
+
+
+func NewProjectApiController() *ProjectApiController {
+	projectService := service.NewProjectService()
+	organizationService := service.NewOrganizationService()
+	return &ProjectApiController{projectService, organizationService}
+}
+
+
+//GET /api/v1/projects
+// own projects, projects of membership and projects of the organizations.
+func (ctr *ProjectApiController) GetProjects(c *gin.Context) {
+	userId := jwt.GetUserId(c)
+
+	projects, err := ctr.projectService.GetProjects(userId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	memberProjects, err := ctr.projectService.GetMemberProjects(userId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	orgProjects, err := ctr.organizationService.GetUserProjects(userId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+
+	c.JSON(200, mergeProjects(projects, memberProjects, orgProjects))
+}
+
+
+//POST /api/v1/projects
+// in the organization of org_name for its owners.
+func (ctr *ProjectApiController) CreateProject(c *gin.Context) {
+	userId := jwt.GetUserId(c)
+	username := jwt.GetUsername(c)
+
+	var form form.PostProject
+	if err := c.ShouldBindJSON(&form); err != nil {
+		apiBindError(c, err)
+		return
+	}
+
+	var err error
+	if form.OrgName != "" {
+		var org model.Organization
+		var role string
+		if org, err = ctr.organizationService.GetOrganization(form.OrgName); err == nil {
+			role, err = ctr.organizationService.GetOrgRole(org.OrgId, userId)
+		}
+		if err != nil {
+			apiError(c, err)
+			return
+		}
+		if role != constant.ORG_ROLE_CLS_OWNER {
+			apiError(c, errs.NewForbiddenError())
+			return
+		}
+		username = org.OrgName
+		err = ctr.projectService.CreateOrgProject(org, form.ProjectName, form.ProjectMemo)
+	} else {
+		err = ctr.projectService.CreateProject(userId, username, form.ProjectName, form.ProjectMemo)
+	}
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+
+	project, err := ctr.projectService.GetProjectByName(username, form.ProjectName)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(201, project)
+}
+
+
+//GET /api/v1/:username/:project_name
+func (ctr *ProjectApiController) GetProject(c *gin.Context) {
+	c.JSON(200, c.Keys["project"].(model.Project))
+}
+
+
+//PUT /api/v1/:username/:project_name
+func (ctr *ProjectApiController) UpdateProject(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+
+	var form form.PostProject
+	if err := c.ShouldBindJSON(&form); err != nil {
+		apiBindError(c, err)
+		return
+	}
+
+	err := ctr.projectService.UpdateProject(
+		project.Username, project.ProjectId, form.ProjectName, form.ProjectMemo,
+	)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+
+	project, err = ctr.projectService.GetProject(project.ProjectId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, project)
+}
+
+
+//POST /api/v1/:username/:project_name/transfer
+func (ctr *ProjectApiController) TransferProject(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+
+	var form form.PostProjectTransfer
+	if err := c.ShouldBindJSON(&form); err != nil {
+		apiBindError(c, err)
+		return
+	}
+
+	project, err := ctr.projectService.TransferProject(project.ProjectId, form.UserId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, project)
+}
+
+
+//DELETE /api/v1/:username/:project_name
+func (ctr *ProjectApiController) DeleteProject(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+
+	if err := ctr.projectService.DeleteProject(project.ProjectId); err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
\ No newline at end of file
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"goat-cg/internal/core/jwt"
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/shared/form"
+	"goat-cg/internal/service"
+	"goat-cg/internal/model"
+)
+
+
+type TableApiController struct {
+	tableService service.TableService
+}
+
+
+func NewTableApiController() *TableApiController {
+	tableService := service.NewTableService()
+	return &TableApiController{tableService}
+}
+
+
+//GET /api/v1/:username/:project_name/tables
+func (ctr *TableApiController) GetTables(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+
+	tables, err := ctr.tableService.GetTables(project.ProjectId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, tables)
+}
+
+
+//POST /api/v1/:username/:project_name/tables
+func (ctr *TableApiController) CreateTable(c *gin.Context) {
+	userId := jwt.GetUserId(c)
+	project := c.Keys["project"].(model.Project)
+
+	var form form.PostTable
+	if err := c.ShouldBindJSON(&form); err != nil {
+		apiBindError(c, err)
+		return
+	}
+
+	err := ctr.tableService.CreateTable(
+		project.ProjectId, userId, form.TableName, form.TableNameLogical, form.VersionFlg,
+	)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+
+	table, err := ctr.getTableByName(project.ProjectId, form.TableName)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(201, table)
+}
+
+
+//GET /api/v1/:username/:project_name/tables/:table_id
+func (ctr *TableApiController) GetTable(c *gin.Context) {
+	c.JSON(200, c.Keys["table"].(model.Table))
+}
+
+
+//PUT /api/v1/:username/:project_name/tables/:table_id
+func (ctr *TableApiController) UpdateTable(c *gin.Context) {
+	userId := jwt.GetUserId(c)
+	project := c.Keys["project"].(model.Project)
+	table := c.Keys["table"].(model.Table)
+
+	var form form.PostTable
+	if err := c.ShouldBindJSON(&form); err != nil {
+		apiBindError(c, err)
+		return
+	}
+
+	err := ctr.tableService.UpdateTable(
+		project.ProjectId, table.TableId, userId,
+		form.TableName, form.TableNameLogical, form.VersionFlg, form.DelFlg,
+	)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+
+	table, err = ctr.tableService.GetTable(table.TableId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, table)
+}
+
+
+//DELETE /api/v1/:username/:project_name/tables/:table_id
+func (ctr *TableApiController) DeleteTable(c *gin.Context) {
+	table := c.Keys["table"].(model.Table)
+
+	if err := ctr.tableService.DeleteTable(table.TableId); err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+
+//GET /api/v1/:username/:project_name/tables/:table_id/log
+func (ctr *TableApiController) GetTableLog(c *gin.Context) {
+	table := c.Keys["table"].(model.Table)
+
+	tableLog, err := ctr.tableService.GetTableLog(table.TableId)
+	if err != nil {
+		apiError(c, err)
+		return
+	}
+	c.JSON(200, tableLog)
+}
+
+
+// getTableByName table created by the request. (services return no id)
+func (ctr *TableApiController) getTableByName(projectId int, tableName string) (model.Table, error) {
+	tables, err := ctr.tableService.GetTables(projectId)
+	if err != nil {
+		return model.Table{}, err
+	}
+	for _, t := range tables {
+		if t.TableName == tableName {
+			return t, nil
+		}
+	}
+	return model.Table{}, errs.NewNotFoundError()
+}
\ No newline at end of file
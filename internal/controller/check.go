@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+
+	"goat-cg/internal/core/jwt"
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/shared/form"
+	"goat-cg/internal/service"
+	"goat-cg/internal/model"
+)
+
+
+type CheckController struct {
+	checkService service.CheckService
+	columnService service.ColumnService
+}
+
+
+func NewCheckController() *CheckController {
+	checkService := service.NewCheckService()
+	columnService := service.NewColumnService()
+	return &CheckController{checkService, columnService}
+}
+
+
+//GET /:username/:project_name/tables/:table_id/checks
+func (ctr *CheckController) ChecksPage(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	table := c.Keys["table"].(model.Table)
+
+	checks, _ := ctr.checkService.GetChecks(table.TableId)
+	columns, _ := ctr.columnService.GetColumns(table.TableId)
+
+	c.HTML(200, "checks.html", gin.H{
+		"project": project,
+		"table": table,
+		"checks": checks,
+		"columns": columns,
+	})
+}
+
+
+//GET /:username/:project_name/tables/:table_id/checks/new
+func (ctr *CheckController) CreateCheckPage(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	table := c.Keys["table"].(model.Table)
+
+	columns, _ := ctr.columnService.GetColumns(table.TableId)
+
+	c.HTML(200, "check.html", gin.H{
+		"project": project,
+		"table": table,
+		"columns": columns,
+	})
+}
+
+
+//POST /:username/:project_name/tables/:table_id/checks/new
+func (ctr *CheckController) CreateCheck(c *gin.Context) {
+	userId := jwt.GetUserId(c)
+	project := c.Keys["project"].(model.Project)
+	table := c.Keys["table"].(model.Table)
+
+	columns, _ := ctr.columnService.GetColumns(table.TableId)
+
+	var form form.PostCheck
+	if err := c.ShouldBind(&form); err != nil {
+		c.HTML(400, "check.html", gin.H{
+			"project": project,
+			"table": table,
+			"columns": columns,
+			"check": form,
+			"error": "invalid input.",
+		})
+		return
+	}
+	err := ctr.checkService.CreateCheck(form.ToCreateCheck(table.TableId, userId))
+
+	if err == nil {
+		c.Redirect(303, fmt.Sprintf(
+			"/%s/%s/tables/%s/checks", 
+			c.Param("username"), c.Param("project_name"), c.Param("table_id"),
+		))
+		return
+	}
+
+	ctr.renderCheckError(c, err, gin.H{
+		"project": project,
+		"table": table,
+		"columns": columns,
+		"check": form,
+	})
+}
+
+
+//GET /:username/:project_name/tables/:table_id/checks/:check_id
+func (ctr *CheckController) UpdateCheckPage(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	table := c.Keys["table"].(model.Table)
+	check := c.Keys["check"].(model.Check)
+
+	columns, _ := ctr.columnService.GetColumns(table.TableId)
+
+	c.HTML(200, "check.html", gin.H{
+		"project": project,
+		"table": table,
+		"columns": columns,
+		"check": check,
+	})
+}
+
+
+//POST /:username/:project_name/tables/:table_id/checks/:check_id
+func (ctr *CheckController) UpdateCheck(c *gin.Context) {
+	userId := jwt.GetUserId(c)
+	project := c.Keys["project"].(model.Project)
+	table := c.Keys["table"].(model.Table)
+	check := c.Keys["check"].(model.Check)
+
+	columns, _ := ctr.columnService.GetColumns(table.TableId)
+
+	var form form.PostCheck
+	if err := c.ShouldBind(&form); err != nil {
+		form.CheckId = check.CheckId
+		c.HTML(400, "check.html", gin.H{
+			"project": project,
+			"table": table,
+			"columns": columns,
+			"check": form,
+			"error": "invalid input.",
+		})
+		return
+	}
+	form.CheckId = check.CheckId
+	err := ctr.checkService.UpdateCheck(form.ToCreateCheck(table.TableId, userId))
+
+	if err == nil {
+		c.Redirect(303, fmt.Sprintf(
+			"/%s/%s/tables/%s/checks", 
+			c.Param("username"), c.Param("project_name"), c.Param("table_id"),
+		))
+		return
+	}
+
+	ctr.renderCheckError(c, err, gin.H{
+		"project": project,
+		"table": table,
+		"columns": columns,
+		"check": form,
+	})
+}
+
+
+//DELETE /:username/:project_name/tables/:table_id/checks/:check_id
+func (ctr *CheckController) DeleteCheck(c *gin.Context) {
+	check := c.Keys["check"].(model.Check)
+
+	if ctr.checkService.DeleteCheck(check.CheckId) != nil {
+		c.JSON(500, gin.H{})
+		c.Abort()
+		return
+	}
+
+	c.JSON(200, gin.H{})
+}
+
+
+func (ctr *CheckController) renderCheckError(c *gin.Context, err error, h gin.H) {
+	switch e := err.(type) {
+	case errs.UniqueConstraintError:
+		h["error"] = "CheckName must be unique."
+		c.HTML(409, "check.html", h)
+	case errs.InvalidValueError:
+		h["error"] = fmt.Sprintf("%s: %s", e.Field, e.Message)
+		c.HTML(400, "check.html", h)
+	default:
+		h["error"] = "error occurred."
+		c.HTML(500, "check.html", h)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+
+	"goat-cg/internal/core/jwt"
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/shared/form"
+	"goat-cg/internal/service"
+	"goat-cg/internal/model"
+)
+
+
+type ClassificationController struct {
+	classificationService service.ClassificationService
+}
+
+
+func NewClassificationController() *ClassificationController {
+	classificationService := service.NewClassificationService()
+	return &ClassificationController{classificationService}
+}
+
+
+//GET /:username/:project_name/classifications
+func (ctr *ClassificationController) ClassificationsPage(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+
+	classifications, _ := ctr.classificationService.GetClassifications(project.ProjectId)
+
+	c.HTML(200, "classifications.html", gin.H{
+		"project": project,
+		"classifications": classifications,
+	})
+}
+
+
+//GET /:username/:project_name/classifications/new
+func (ctr *ClassificationController) CreateClassificationPage(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+
+	c.HTML(200, "classification.html", gin.H{
+		"project": project,
+	})
+}
+
+
+//POST /:username/:project_name/classifications/new
+func (ctr *ClassificationController) CreateClassification(c *gin.Context) {
+	userId := jwt.GetUserId(c)
+	project := c.Keys["project"].(model.Project)
+
+	var form form.PostClassification
+	if err := c.ShouldBind(&form); err != nil {
+		c.HTML(400, "classification.html", gin.H{
+			"project": project,
+			"classification": form,
+			"values": form.ToCreateClassification(project.ProjectId, userId).Values,
+			"error": "invalid input.",
+		})
+		return
+	}
+	in := form.ToCreateClassification(project.ProjectId, userId)
+	err := ctr.classificationService.CreateClassification(in)
+
+	if err == nil {
+		c.Redirect(303, fmt.Sprintf(
+			"/%s/%s/classifications", c.Param("username"), c.Param("project_name"),
+		))
+		return
+	}
+
+	ctr.renderClassificationError(c, err, gin.H{
+		"project": project,
+		"classification": form,
+		"values": in.Values,
+	})
+}
+
+
+//GET /:username/:project_name/classifications/:classification_id
+func (ctr *ClassificationController) UpdateClassificationPage(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	classification := c.Keys["classification"].(model.Classification)
+
+	values, _ := ctr.classificationService.GetClassificationValues(classification.ClassificationId)
+
+	c.HTML(200, "classification.html", gin.H{
+		"project": project,
+		"classification": classification,
+		"values": values,
+	})
+}
+
+
+//POST /:username/:project_name/classifications/:classification_id
+func (ctr *ClassificationController) UpdateClassification(c *gin.Context) {
+	userId := jwt.GetUserId(c)
+	project := c.Keys["project"].(model.Project)
+	classification := c.Keys["classification"].(model.Classification)
+
+	var form form.PostClassification
+	if err := c.ShouldBind(&form); err != nil {
+		form.ClassificationId = classification.ClassificationId
+		c.HTML(400, "classification.html", gin.H{
+			"project": project,
+			"classification": form,
+			"values": form.ToCreateClassification(project.ProjectId, userId).Values,
+			"error": "invalid input.",
+		})
+		return
+	}
+	form.ClassificationId = classification.ClassificationId
+	in := form.ToCreateClassification(project.ProjectId, userId)
+	err := ctr.classificationService.UpdateClassification(in)
+
+	if err == nil {
+		c.Redirect(303, fmt.Sprintf(
+			"/%s/%s/classifications", c.Param("username"), c.Param("project_name"),
+		))
+		return
+	}
+
+	ctr.renderClassificationError(c, err, gin.H{
+		"project": project,
+		"classification": form,
+		"values": in.Values,
+	})
+}
+
+
+//DELETE /:username/:project_name/classifications/:classification_id
+func (ctr *ClassificationController) DeleteClassification(c *gin.Context) {
+	classification := c.Keys["classification"].(model.Classification)
+
+	err := ctr.classificationService.DeleteClassification(classification.ClassificationId)
+	if err != nil {
+		if e, ok := err.(errs.InvalidValueError); ok {
+			c.JSON(409, gin.H{"error": e.Message})
+		} else {
+			c.JSON(500, gin.H{})
+		}
+		c.Abort()
+		return
+	}
+
+	c.JSON(200, gin.H{})
+}
+
+
+func (ctr *ClassificationController) renderClassificationError(c *gin.Context, err error, h gin.H) {
+	switch e := err.(type) {
+	case errs.UniqueConstraintError:
+		h["error"] = "ClassificationName must be unique."
+		c.HTML(409, "classification.html", h)
+	case errs.InvalidValueError:
+		h["error"] = fmt.Sprintf("%s: %s", e.Field, e.Message)
+		c.HTML(400, "classification.html", h)
+	default:
+		h["error"] = "error occurred."
+		c.HTML(500, "classification.html", h)
+	}
+}
\ No newline at end of file
@@ -3,6 +3,8 @@ package controller
 import (
 	"github.com/gin-gonic/gin"
 
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/shared/roles"
 	"goat-cg/internal/core/utils"
 	"goat-cg/internal/service"
 	"goat-cg/internal/model"
@@ -11,14 +13,16 @@ import (
 
 type CodegenController struct {
 	tableService service.TableService
+	viewService service.ViewService
 	codegenService service.CodegenService
 }
 
 
 func NewCodegenController() *CodegenController {
 	tableService := service.NewTableService()
+	viewService := service.NewViewService()
 	codegenService := service.NewCodegenService()
-	return &CodegenController{tableService, codegenService}
+	return &CodegenController{tableService, viewService, codegenService}
 }
 
 
@@ -27,33 +31,232 @@ func (cc *CodegenController) CodegenPage(c *gin.Context) {
 	project := c.Keys["project"].(model.Project)
 	
 	tables, _ := cc.tableService.GetTables(project.ProjectId)
+	views, _ := cc.viewService.GetViews(project.ProjectId)
 
 	c.HTML(200, "codegen.html", gin.H{
 		"project": project,
 		"tables": tables,
+		"views": views,
 	})
 }
 
 
 type CodegenPostBody struct {
 	TableIds []string `json:"tableids"`
+	ViewIds []string `json:"viewids"`
 	DbType string `json:"dbtype"`
+	Zod bool `json:"zod"`
+	Grpc bool `json:"grpc"`
+	Orm string `json:"orm"`
+	SeedRows int `json:"seedrows"`
+	SeedFormat string `json:"seedformat"`
+}
+
+
+// projectTableIds parse the table ids and check that all of them belong to the project.
+func (cc *CodegenController) projectTableIds(project model.Project, s []string) ([]int, bool) {
+	ids, err := utils.AtoiSlice(s)
+	if err != nil {
+		return nil, false
+	}
+
+	tables, err := cc.tableService.GetTables(project.ProjectId)
+	if err != nil {
+		return nil, false
+	}
+	owned := map[int]bool{}
+	for _, t := range tables {
+		owned[t.TableId] = true
+	}
+	for _, id := range ids {
+		if !owned[id] {
+			return nil, false
+		}
+	}
+	return ids, true
+}
+
+
+// projectViewIds parse the view ids and check that all of them belong to the project.
+func (cc *CodegenController) projectViewIds(project model.Project, s []string) ([]int, bool) {
+	ids, err := utils.AtoiSlice(s)
+	if err != nil {
+		return nil, false
+	}
+
+	views, err := cc.viewService.GetViews(project.ProjectId)
+	if err != nil {
+		return nil, false
+	}
+	owned := map[int]bool{}
+	for _, v := range views {
+		owned[v.ViewId] = true
+	}
+	for _, id := range ids {
+		if !owned[id] {
+			return nil, false
+		}
+	}
+	return ids, true
 }
 
 
 //POST /:username/:project_name/codegen/goat
 func (cc *CodegenController) CodegenGOAT(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
 	pb := &CodegenPostBody{} 
 	c.BindJSON(&pb)
 
-	tableIds, err := utils.AtoiSlice(pb.TableIds)
+	tableIds, ok := cc.projectTableIds(project, pb.TableIds)
+	if !ok {
+		c.String(400, "error.txt")
+		return
+	}
+
+	viewIds, ok := cc.projectViewIds(project, pb.ViewIds)
+	if !ok {
+		c.String(400, "error.txt")
+		return
+	}
 
-	if err != nil {
-		c.String(200, "error.txt")
+	fpath := cc.codegenService.GenerateGoat(pb.DbType, tableIds, viewIds)
+
+	c.String(200, fpath[1:])
+}
+
+
+//POST /:username/:project_name/codegen/openapi
+func (cc *CodegenController) CodegenOpenApi(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	pb := &CodegenPostBody{} 
+	c.BindJSON(&pb)
+
+	tableIds, ok := cc.projectTableIds(project, pb.TableIds)
+	if !ok {
+		c.String(400, "error.txt")
+		return
+	}
+
+	fpath := cc.codegenService.GenerateOpenApi(project.ProjectName, tableIds)
+
+	c.String(200, fpath[1:])
+}
+
+
+//POST /:username/:project_name/codegen/typescript
+func (cc *CodegenController) CodegenTypeScript(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	pb := &CodegenPostBody{} 
+	c.BindJSON(&pb)
+
+	tableIds, ok := cc.projectTableIds(project, pb.TableIds)
+	if !ok {
+		c.String(400, "error.txt")
+		return
+	}
+
+	fpath := cc.codegenService.GenerateTypeScript(tableIds, pb.Zod)
+
+	c.String(200, fpath[1:])
+}
+
+
+//POST /:username/:project_name/codegen/proto
+func (cc *CodegenController) CodegenProto(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	pb := &CodegenPostBody{} 
+	c.BindJSON(&pb)
+
+	tableIds, ok := cc.projectTableIds(project, pb.TableIds)
+	if !ok {
+		c.String(400, "error.txt")
 		return
 	}
 
-	fpath := cc.codegenService.GenerateGoat(pb.DbType, tableIds)
+	// field numbers are saved on the generation by editors only
+	saveFields := roles.Has(c.Keys["role"].(string), constant.ROLE_CLS_NOMAL)
+	fpath := cc.codegenService.GenerateProto(project.ProjectName, tableIds, pb.Grpc, saveFields)
+
+	c.String(200, fpath[1:])
+}
+
+
+//POST /:username/:project_name/codegen/graphql
+func (cc *CodegenController) CodegenGraphql(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	pb := &CodegenPostBody{} 
+	c.BindJSON(&pb)
+
+	tableIds, ok := cc.projectTableIds(project, pb.TableIds)
+	if !ok {
+		c.String(400, "error.txt")
+		return
+	}
+
+	fpath := cc.codegenService.GenerateGraphql(tableIds)
+
+	c.String(200, fpath[1:])
+}
+
+
+//POST /:username/:project_name/codegen/jsonschema
+func (cc *CodegenController) CodegenJsonSchema(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	pb := &CodegenPostBody{} 
+	c.BindJSON(&pb)
+
+	tableIds, ok := cc.projectTableIds(project, pb.TableIds)
+	if !ok {
+		c.String(400, "error.txt")
+		return
+	}
+
+	fpath := cc.codegenService.GenerateJsonSchema(tableIds)
+
+	c.String(200, fpath[1:])
+}
+
+
+//POST /:username/:project_name/codegen/orm
+func (cc *CodegenController) CodegenOrm(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	pb := &CodegenPostBody{} 
+	c.BindJSON(&pb)
+
+	tableIds, ok := cc.projectTableIds(project, pb.TableIds)
+	if !ok {
+		c.String(400, "error.txt")
+		return
+	}
+
+	// every orm target (gorm .. prisma) shares the table id check above
+	fpath := cc.codegenService.GenerateOrm(pb.DbType, pb.Orm, tableIds)
+	if fpath == "" {
+		c.String(400, "error.txt")
+		return
+	}
+
+	c.String(200, fpath[1:])
+}
+
+
+//POST /:username/:project_name/codegen/seed
+func (cc *CodegenController) CodegenSeed(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	pb := &CodegenPostBody{} 
+	c.BindJSON(&pb)
+
+	tableIds, ok := cc.projectTableIds(project, pb.TableIds)
+	if !ok || pb.SeedRows < 0 || pb.SeedRows > 1000 {
+		c.String(400, "error.txt")
+		return
+	}
+
+	fpath := cc.codegenService.GenerateSeed(pb.DbType, pb.SeedFormat, pb.SeedRows, tableIds)
+	if fpath == "" {
+		c.String(200, "error.txt")
+		return
+	}
 
 	c.String(200, fpath[1:])
 }
\ No newline at end of file
@@ -2,6 +2,9 @@ package controller
 
 import (
 	"fmt"
+	"errors"
+	"net/http"
+	"encoding/json"
 	"github.com/gin-gonic/gin"
 
 	"goat-cg/internal/core/jwt"
@@ -12,16 +15,22 @@ import (
 )
 
 
+// maxImportFileSize upper limit of the uploaded request of the column import.
+const maxImportFileSize = 10 << 20
+
+
 type ColumnController struct {
 	columnService  service.ColumnService
 	tableService service.TableService
+	classificationService service.ClassificationService
 }
 
 
 func NewColumnController() *ColumnController {
 	columnService  := service.NewColumnService()
 	tableService := service.NewTableService()
-	return &ColumnController{columnService , tableService}
+	classificationService := service.NewClassificationService()
+	return &ColumnController{columnService , tableService, classificationService}
 }
 
 
@@ -29,12 +38,16 @@ func NewColumnController() *ColumnController {
 func (cc *ColumnController) ColumnsPage(c *gin.Context) {
 	project := c.Keys["project"].(model.Project)
 	table := c.Keys["table"].(model.Table)
+	classifications, _ := cc.classificationService.GetClassifications(project.ProjectId)
+	refColumns, _ := cc.columnService.GetProjectColumns(project.ProjectId)
 
 	columns, _ := cc.columnService.GetColumns(table.TableId)
 
 	c.HTML(200, "columns.html", gin.H{
 		"project": project,
 		"table": table,
+		"classifications": classifications,
+		"refColumns": refColumns,
 		"columns": columns,
 	})
 }
@@ -44,10 +57,14 @@ func (cc *ColumnController) ColumnsPage(c *gin.Context) {
 func (cc *ColumnController) CreateColumnPage(c *gin.Context) {
 	project := c.Keys["project"].(model.Project)
 	table := c.Keys["table"].(model.Table)
+	classifications, _ := cc.classificationService.GetClassifications(project.ProjectId)
+	refColumns, _ := cc.columnService.GetProjectColumns(project.ProjectId)
 
 	c.HTML(200, "column.html", gin.H{
 		"project": project,
 		"table": table,
+		"classifications": classifications,
+		"refColumns": refColumns,
 	})
 }
 
@@ -57,9 +74,21 @@ func (cc *ColumnController) CreateColumn(c *gin.Context) {
 	userId := jwt.GetUserId(c)
 	project := c.Keys["project"].(model.Project)
 	table := c.Keys["table"].(model.Table)
+	classifications, _ := cc.classificationService.GetClassifications(project.ProjectId)
+	refColumns, _ := cc.columnService.GetProjectColumns(project.ProjectId)
 
 	var form form.PostColumn
-	c.Bind(&form)
+	if err := c.ShouldBind(&form); err != nil {
+		c.HTML(400, "column.html", gin.H{
+			"project": project,
+			"table": table,
+			"classifications": classifications,
+			"refColumns": refColumns,
+			"column": form,
+			"error": "invalid input.",
+		})
+		return
+	}
 	err := cc.columnService.CreateColumn(form.ToCreateColumn(table.TableId, userId))
 
 	if err == nil {
@@ -74,13 +103,26 @@ func (cc *ColumnController) CreateColumn(c *gin.Context) {
 		c.HTML(409, "column.html", gin.H{
 			"project": project,
 			"table": table,
+			"classifications": classifications,
+			"refColumns": refColumns,
 			"column": form,
 			"error": "ColumnName must be unique.",
 		})
+	} else if e, ok := err.(errs.InvalidValueError); ok {
+		c.HTML(400, "column.html", gin.H{
+			"project": project,
+			"table": table,
+			"classifications": classifications,
+			"refColumns": refColumns,
+			"column": form,
+			"error": fmt.Sprintf("%s: %s", e.Field, e.Message),
+		})
 	} else {
 		c.HTML(500, "column.html", gin.H{
 			"project": project,
 			"table": table,
+			"classifications": classifications,
+			"refColumns": refColumns,
 			"column": form,
 			"error": "error occurred.",
 		})
@@ -92,11 +134,15 @@ func (cc *ColumnController) CreateColumn(c *gin.Context) {
 func (cc *ColumnController) UpdateColumnPage(c *gin.Context) {
 	project := c.Keys["project"].(model.Project)
 	table := c.Keys["table"].(model.Table)
+	classifications, _ := cc.classificationService.GetClassifications(project.ProjectId)
+	refColumns, _ := cc.columnService.GetProjectColumns(project.ProjectId)
 	column := c.Keys["column"].(model.Column)
 
 	c.HTML(200, "column.html", gin.H{
 		"project": project,
 		"table": table,
+		"classifications": classifications,
+		"refColumns": refColumns,
 		"column": column,
 	})
 }
@@ -107,10 +153,23 @@ func (cc *ColumnController) UpdateColumn(c *gin.Context) {
 	userId := jwt.GetUserId(c)
 	project := c.Keys["project"].(model.Project)
 	table := c.Keys["table"].(model.Table)
+	classifications, _ := cc.classificationService.GetClassifications(project.ProjectId)
+	refColumns, _ := cc.columnService.GetProjectColumns(project.ProjectId)
 	column := c.Keys["column"].(model.Column)
 
 	var form form.PostColumn
-	c.Bind(&form)
+	if err := c.ShouldBind(&form); err != nil {
+		form.ColumnId = column.ColumnId
+		c.HTML(400, "column.html", gin.H{
+			"project": project,
+			"table": table,
+			"classifications": classifications,
+			"refColumns": refColumns,
+			"column": form,
+			"error": "invalid input.",
+		})
+		return
+	}
 	form.ColumnId = column.ColumnId
 	err := cc.columnService.UpdateColumn(form.ToCreateColumn(table.TableId, userId))
 
@@ -126,13 +185,26 @@ func (cc *ColumnController) UpdateColumn(c *gin.Context) {
 		c.HTML(409, "column.html", gin.H{
 			"project": project,
 			"table": table,
+			"classifications": classifications,
+			"refColumns": refColumns,
 			"column": form,
 			"error": "ColumnName must be unique.",
 		})
+	} else if e, ok := err.(errs.InvalidValueError); ok {
+		c.HTML(400, "column.html", gin.H{
+			"project": project,
+			"table": table,
+			"classifications": classifications,
+			"refColumns": refColumns,
+			"column": form,
+			"error": fmt.Sprintf("%s: %s", e.Field, e.Message),
+		})
 	} else {
 		c.HTML(500, "column.html", gin.H{
 			"project": project,
 			"table": table,
+			"classifications": classifications,
+			"refColumns": refColumns,
 			"column": form,
 			"error": "error occurred.",
 		})
@@ -154,6 +226,148 @@ func (cc *ColumnController) DeleteColumn(c *gin.Context) {
 }
 
 
+//GET /:username/:project_name/tables/:table_id/columns/import
+func (cc *ColumnController) ImportColumnsPage(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	table := c.Keys["table"].(model.Table)
+
+	c.HTML(200, "columnimport.html", gin.H{
+		"project": project,
+		"table": table,
+	})
+}
+
+
+//POST /:username/:project_name/tables/:table_id/columns/import
+func (cc *ColumnController) PreviewImportColumns(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	table := c.Keys["table"].(model.Table)
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+
+	var form form.ImportColumns
+	if err := c.ShouldBind(&form); err != nil {
+		msg := "invalid input."
+		var mbe *http.MaxBytesError
+		if errors.As(err, &mbe) {
+			msg = fmt.Sprintf("file must be %d MB or smaller.", maxImportFileSize >> 20)
+		}
+		c.HTML(400, "columnimport.html", gin.H{
+			"project": project,
+			"table": table,
+			"error": msg,
+		})
+		return
+	}
+
+	columns, _ := cc.columnService.GetColumns(table.TableId)
+	rows, err := form.ReadRows(table.TableName, columns)
+	if err != nil {
+		c.HTML(400, "columnimport.html", gin.H{
+			"project": project,
+			"table": table,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	cc.renderImportPreview(c, table, rows, gin.H{
+		"project": project,
+		"table": table,
+		"filename": form.File.Filename,
+	})
+}
+
+
+//POST /:username/:project_name/tables/:table_id/columns/import/apply
+func (cc *ColumnController) ImportColumns(c *gin.Context) {
+	userId := jwt.GetUserId(c)
+	project := c.Keys["project"].(model.Project)
+	table := c.Keys["table"].(model.Table)
+
+	var f form.ApplyImportColumns
+	var columns []form.PostColumn
+	if err := c.ShouldBind(&f); err != nil || json.Unmarshal([]byte(f.Rows), &columns) != nil {
+		c.HTML(400, "columnimport.html", gin.H{
+			"project": project,
+			"table": table,
+			"error": "invalid input.",
+		})
+		return
+	}
+
+	rows := f.ValidateRows(columns)
+	for _, r := range rows {
+		if r.Error != "" {
+			cc.renderImportPreview(c, table, rows, gin.H{"project": project, "table": table})
+			return
+		}
+	}
+
+	err := cc.columnService.ImportColumns(table.TableId, form.ToCreateColumns(rows, table.TableId, userId))
+
+	if err == nil {
+		c.Redirect(303, fmt.Sprintf(
+			"/%s/%s/tables/%s/columns", 
+			c.Param("username"), c.Param("project_name"), c.Param("table_id"),
+		))
+		return
+	}
+
+	cc.renderImportPreview(c, table, rows, gin.H{"project": project, "table": table})
+}
+
+
+// renderImportPreview render rows with the validation result of each row.
+// the rows can be applied only when all rows are valid.
+func (cc *ColumnController) renderImportPreview(
+	c *gin.Context, table model.Table, rows []form.ImportColumnRow, h gin.H,
+) {
+	serviceErrors := cc.columnService.ValidateImportColumns(
+		table.TableId, form.ToCreateColumns(rows, table.TableId, 0),
+	)
+
+	valid := len(rows) > 0
+	for i, err := range serviceErrors {
+		if rows[i].Error != "" {
+			valid = false
+			continue
+		}
+		switch e := err.(type) {
+		case nil:
+		case errs.UniqueConstraintError:
+			rows[i].Error = "ColumnName must be unique."
+		case errs.InvalidValueError:
+			rows[i].Error = fmt.Sprintf("%s: %s", e.Field, e.Message)
+		default:
+			rows[i].Error = "error occurred."
+		}
+		if rows[i].Error != "" {
+			valid = false
+		}
+	}
+
+	h["rows"] = rows
+	if !valid {
+		if len(rows) == 0 {
+			h["error"] = "no columns found in the file."
+		} else {
+			h["error"] = "fix the errors and upload the file again."
+		}
+		c.HTML(400, "columnimport.html", h)
+		return
+	}
+
+	var columns []form.PostColumn
+	for _, r := range rows {
+		columns = append(columns, r.Column)
+	}
+	b, _ := json.Marshal(columns)
+	h["json"] = string(b)
+	c.HTML(200, "columnimport.html", h)
+}
+
+
 //GET /:project_cd/tables/:table_id/columns/:column_id/log
 func (cc *ColumnController) ColumnLogPage(c *gin.Context) {
 	project := c.Keys["project"].(model.Project)
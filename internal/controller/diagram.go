@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"html/template"
+	"github.com/gin-gonic/gin"
+
+	"goat-cg/internal/core/utils"
+	"goat-cg/internal/service"
+	"goat-cg/internal/model"
+)
+
+
+type DiagramController struct {
+	tableService service.TableService
+	diagramService service.DiagramService
+}
+
+
+func NewDiagramController() *DiagramController {
+	tableService := service.NewTableService()
+	diagramService := service.NewDiagramService()
+	return &DiagramController{tableService, diagramService}
+}
+
+
+// diagramFormats map export format and (file extension, content type).
+var diagramFormats = map[string][2]string{
+	"mermaid": {".mmd", "text/plain; charset=utf-8"},
+	"plantuml": {".puml", "text/plain; charset=utf-8"},
+	"dot": {".dot", "text/vnd.graphviz; charset=utf-8"},
+	"svg": {".svg", "image/svg+xml; charset=utf-8"},
+}
+
+
+//GET /:username/:project_name/diagram?table_id=1&table_id=2
+func (dc *DiagramController) DiagramPage(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+
+	tableIds, err := utils.AtoiSlice(c.QueryArray("table_id"))
+	if err != nil {
+		c.HTML(400, "400error.html", gin.H{})
+		return
+	}
+
+	tables, _ := dc.tableService.GetTables(project.ProjectId)
+	diagram, err := dc.diagramService.GetDiagram(project.ProjectId, tableIds)
+	if err != nil {
+		c.HTML(500, "500error.html", gin.H{})
+		return
+	}
+
+	c.HTML(200, "diagram.html", gin.H{
+		"project": project,
+		"tables": tables,
+		"selected": tableIdSet(tableIds),
+		"query": c.Request.URL.RawQuery,
+		"svg": template.HTML(dc.diagramService.ToSvg(diagram)),
+	})
+}
+
+
+//GET /:username/:project_name/diagram/:format?table_id=1&table_id=2
+func (dc *DiagramController) ExportDiagram(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+
+	format, ok := diagramFormats[c.Param("format")]
+	if !ok {
+		c.HTML(404, "404error.html", gin.H{})
+		return
+	}
+
+	tableIds, err := utils.AtoiSlice(c.QueryArray("table_id"))
+	if err != nil {
+		c.HTML(400, "400error.html", gin.H{})
+		return
+	}
+
+	diagram, err := dc.diagramService.GetDiagram(project.ProjectId, tableIds)
+	if err != nil {
+		c.HTML(500, "500error.html", gin.H{})
+		return
+	}
+
+	var s string
+	switch c.Param("format") {
+	case "mermaid":
+		s = dc.diagramService.ToMermaid(diagram)
+	case "plantuml":
+		s = dc.diagramService.ToPlantUml(diagram)
+	case "dot":
+		s = dc.diagramService.ToDot(diagram)
+	case "svg":
+		s = dc.diagramService.ToSvg(diagram)
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=" + project.ProjectName + "-er" + format[0])
+	c.Data(200, format[1], []byte(s))
+}
\ No newline at end of file
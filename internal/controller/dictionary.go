@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"goat-cg/internal/core/utils"
+	"goat-cg/internal/service"
+	"goat-cg/internal/model"
+)
+
+
+type DictionaryController struct {
+	tableService service.TableService
+	dictionaryService service.DictionaryService
+}
+
+
+func NewDictionaryController() *DictionaryController {
+	tableService := service.NewTableService()
+	dictionaryService := service.NewDictionaryService()
+	return &DictionaryController{tableService, dictionaryService}
+}
+
+
+// dictionaryFormats map export format and (file extension, content type).
+var dictionaryFormats = map[string][2]string{
+	"markdown": {".md", "text/markdown; charset=utf-8"},
+	"html": {"-html.zip", "application/zip"},
+	"csv": {".csv", "text/csv; charset=utf-8"},
+	"xlsx": {".xlsx", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+}
+
+
+//GET /:username/:project_name/dictionary
+func (dc *DictionaryController) DictionaryPage(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+
+	tables, _ := dc.tableService.GetTables(project.ProjectId)
+
+	c.HTML(200, "dictionary.html", gin.H{
+		"project": project,
+		"tables": tables,
+	})
+}
+
+
+//GET /:username/:project_name/dictionary/:format?table_id=1&table_id=2
+func (dc *DictionaryController) ExportDictionary(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+
+	format, ok := dictionaryFormats[c.Param("format")]
+	if !ok {
+		c.HTML(404, "404error.html", gin.H{})
+		return
+	}
+
+	tableIds, err := utils.AtoiSlice(c.QueryArray("table_id"))
+	if err != nil {
+		c.HTML(400, "400error.html", gin.H{})
+		return
+	}
+
+	dictionary, err := dc.dictionaryService.GetDictionary(project, tableIds)
+	if err != nil {
+		c.HTML(500, "500error.html", gin.H{})
+		return
+	}
+
+	var b []byte
+	switch c.Param("format") {
+	case "markdown":
+		b = []byte(dc.dictionaryService.ToMarkdown(dictionary))
+	case "html":
+		b, err = dc.dictionaryService.ToHtmlSite(dictionary)
+	case "csv":
+		b, err = dc.dictionaryService.ToCsv(dictionary)
+	case "xlsx":
+		b, err = dc.dictionaryService.ToXlsx(dictionary)
+	}
+	if err != nil {
+		c.HTML(500, "500error.html", gin.H{})
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=" + project.ProjectName + "-tables" + format[0])
+	c.Data(200, format[1], b)
+}
\ No newline at end of file
@@ -5,10 +5,13 @@ import (
 	"strconv"
 	"github.com/gin-gonic/gin"
 
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/shared/roles"
 	"goat-cg/internal/core/jwt"
 	"goat-cg/internal/core/errs"
 	"goat-cg/internal/service"
 	"goat-cg/internal/model"
+	"goat-cg/internal/dto"
 )
 
 
@@ -25,31 +28,52 @@ func NewMemberController() *MemberController {
 }
 
 
-//GET /:username/:project_name/members
-func (mc *MemberController) MembersPage (c *gin.Context) {
-	if c.Param("username") != jwt.GetUsername(c) {
-		c.HTML(404, "404error.html", gin.H{})
-		c.Abort()
-		return
+// assignableRoles roles the user of the role can give to members. (lower than their own)
+func assignableRoles(role string) []gin.H {
+	ret := []gin.H{}
+	for _, r := range []string{constant.ROLE_CLS_VIEWER, constant.ROLE_CLS_NOMAL, constant.ROLE_CLS_ADMIN} {
+		if constant.ROLE_CLS_RANK[r] < constant.ROLE_CLS_RANK[role] {
+			ret = append(ret, gin.H{"cls": r, "name": constant.ROLE_CLS_NAME[r]})
+		}
 	}
+	return ret
+}
 
+
+// renderMembersPage render members.html with the members by state and the roles the user can give.
+func (mc *MemberController) renderMembersPage(c *gin.Context, code int, h gin.H) {
 	project := c.Keys["project"].(model.Project)
+	role := c.Keys["role"].(string)
 	members, _ := mc.memberService.GetMembers(project.ProjectId)
-	c.HTML(200, "members.html", gin.H{
-		"project": project, 
-		"members": members,
-	})
+	invitations, _ := mc.memberService.GetEmailInvitations(project.ProjectId)
+
+	byState := map[string][]dto.ProjectMember{}
+	for _, m := range members {
+		byState[m.UserStatus] = append(byState[m.UserStatus], m)
+	}
+
+	h["project"] = project
+	h["members"] = byState[constant.STATE_CLS_NOMAL]
+	h["invited_members"] = byState[constant.STATE_CLS_REQUEST]
+	h["join_requests"] = byState[constant.STATE_CLS_JOIN]
+	h["blocked_members"] = byState[constant.STATE_CLS_BLOCK]
+	h["invitations"] = invitations
+	h["user_id"] = jwt.GetUserId(c)
+	h["role_name"] = constant.ROLE_CLS_NAME[role]
+	h["is_admin"] = roles.Has(role, constant.ROLE_CLS_ADMIN)
+	h["roles"] = assignableRoles(role)
+	c.HTML(code, "members.html", h)
+}
+
+
+//GET /:username/:project_name/members
+func (mc *MemberController) MembersPage (c *gin.Context) {
+	mc.renderMembersPage(c, 200, gin.H{})
 }
 
 
 //GET /:username/:project_name/members/:user_id
 func (mc *MemberController) MemberPage (c *gin.Context) {
-	if c.Param("username") != jwt.GetUsername(c) {
-		c.HTML(404, "404error.html", gin.H{})
-		c.Abort()
-		return
-	}
-
 	project := c.Keys["project"].(model.Project)
 	userId, err := strconv.Atoi(c.Param("user_id"))
 	if err != nil {
@@ -65,90 +89,349 @@ func (mc *MemberController) MemberPage (c *gin.Context) {
 		return
 	}
 
+	role := c.Keys["role"].(string)
 	c.HTML(200, "member.html", gin.H{
-		"project": project, 
+		"project": project,
 		"member": member,
+		"roles": assignableRoles(role),
+		"can_manage": constant.ROLE_CLS_RANK[member.UserRole] < constant.ROLE_CLS_RANK[role],
 	})
 }
 
 
 //POST /:username/:project_name/members/invite
 func (mc *MemberController) Invite (c *gin.Context) {
-	if c.Param("username") != jwt.GetUsername(c) {
-		c.HTML(400, "400error.html", gin.H{})
-		c.Abort()
-		return
-	}
-
-	project := c.Keys["project"].(model.Project)
 	email := c.PostForm("email")
+	userRole := c.PostForm("user_role")
 	if email == jwt.GetEmail(c) {
-		members, _ := mc.memberService.GetMembers(project.ProjectId)
-		c.HTML(400, "members.html", gin.H{
-			"project": project, 
-			"members": members,
+		mc.renderMembersPage(c, 400, gin.H{
 			"email": email,
 			"error": "Cannot invite yourself.",
 		})
+		return
 	}
 
-	err := mc.memberService.Invite(project.ProjectId, email)
+	project := c.Keys["project"].(model.Project)
+	err := mc.memberService.Invite(
+		project.ProjectId, jwt.GetUserId(c), c.Keys["role"].(string), email, userRole,
+	)
 
 	if err == nil {
 		c.Redirect(303, fmt.Sprintf("/%s/%s/members", c.Param("username"), c.Param("project_name")))
 		return
-	} 
-	
-	members, _ := mc.memberService.GetMembers(project.ProjectId)
+	}
 
-	if _, ok := err.(errs.NotFoundError); ok {
-		c.HTML(400, "members.html", gin.H{
-			"project": project, 
-			"members": members,
+	if _, ok := err.(errs.AlreadyRegisteredError); ok{
+		mc.renderMembersPage(c, 409, gin.H{
 			"email": email,
-			"error": "User not found.",
+			"error": "This user has already been invited.",
 		})
 
-	} else if _, ok := err.(errs.AlreadyRegisteredError); ok{
-		c.HTML(409, "members.html", gin.H{
-			"project": project, 
-			"members": members,
+	} else if _, ok := err.(errs.ForbiddenError); ok{
+		mc.renderMembersPage(c, 403, gin.H{
 			"email": email,
-			"error": "This user has already been invited.",
+			"error": "You cannot give this role.",
 		})
+
+	} else if e, ok := err.(errs.InvalidValueError); ok && e.Field == "email" {
+		mc.renderMembersPage(c, 400, gin.H{
+			"email": email,
+			"error": "This user is blocked.",
+		})
+
+	} else if _, ok := err.(errs.InvalidValueError); ok{
+		mc.renderMembersPage(c, 400, gin.H{
+			"email": email,
+			"error": "Unknown role.",
+		})
+
 	} else {
-		c.HTML(500, "members.html", gin.H{
-			"project": project, 
-			"members": members,
+		mc.renderMembersPage(c, 500, gin.H{
 			"email": email,
 			"error": "invitation failed.",
 		})
 	}
 }
 
+
+//POST /:username/:project_name/members/:user_id
+func (mc *MemberController) UpdateMemberRole (c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.HTML(400, "400error.html", gin.H{})
+		c.Abort()
+		return
+	}
+
+	err = mc.memberService.UpdateRole(project.ProjectId, c.Keys["role"].(string), userId, c.PostForm("user_role"))
+	if err == nil {
+		c.Redirect(303, fmt.Sprintf("/%s/%s/members", c.Param("username"), c.Param("project_name")))
+		return
+	}
+
+	if _, ok := err.(errs.NotFoundError); ok {
+		c.HTML(404, "404error.html", gin.H{})
+	} else if _, ok := err.(errs.ForbiddenError); ok {
+		c.HTML(403, "403error.html", gin.H{})
+	} else if _, ok := err.(errs.InvalidValueError); ok {
+		c.HTML(400, "400error.html", gin.H{})
+	} else {
+		c.HTML(500, "500error.html", gin.H{})
+	}
+	c.Abort()
+}
+
+
 //DELETE /:username/:project_name/members/:user_id
 func (mc *MemberController) DeleteMember (c *gin.Context) {
-	if c.Param("username") != jwt.GetUsername(c) {
+	project := c.Keys["project"].(model.Project)
+	userId, err := strconv.Atoi(c.Param("user_id"))
+
+	if err != nil {
 		c.JSON(400, gin.H{})
 		c.Abort()
 		return
 	}
 
+	err = mc.memberService.DeleteMember(project.ProjectId, c.Keys["role"].(string), userId)
+	if err != nil {
+		if _, ok := err.(errs.ForbiddenError); ok {
+			c.JSON(403, gin.H{"error": "permission denied."})
+		} else if _, ok := err.(errs.NotFoundError); ok {
+			c.JSON(404, gin.H{})
+		} else {
+			c.JSON(500, gin.H{})
+		}
+		c.Abort()
+		return
+	}
+
+	c.JSON(200, gin.H{})
+
+}
+
+
+//DELETE /:username/:project_name/members/:user_id/invitation
+func (mc *MemberController) RevokeInvitation (c *gin.Context) {
 	project := c.Keys["project"].(model.Project)
 	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.JSON(400, gin.H{})
+		c.Abort()
+		return
+	}
+
+	err = mc.memberService.RevokeInvitation(
+		project.ProjectId, jwt.GetUserId(c), c.Keys["role"].(string), userId,
+	)
+	mc.respondRevoke(c, err)
+}
 
+
+//DELETE /:username/:project_name/invitations/:invitation_id
+func (mc *MemberController) RevokeEmailInvitation (c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	invitationId, err := strconv.Atoi(c.Param("invitation_id"))
 	if err != nil {
 		c.JSON(400, gin.H{})
 		c.Abort()
 		return
 	}
 
-	if err = mc.memberService.DeleteMember(project.ProjectId, userId); err != nil {
+	err = mc.memberService.RevokeEmailInvitation(
+		project.ProjectId, jwt.GetUserId(c), c.Keys["role"].(string), invitationId,
+	)
+	mc.respondRevoke(c, err)
+}
+
+
+func (mc *MemberController) respondRevoke (c *gin.Context, err error) {
+	if err == nil {
+		c.JSON(200, gin.H{})
+		return
+	}
+
+	if _, ok := err.(errs.ForbiddenError); ok {
+		c.JSON(403, gin.H{"error": "permission denied."})
+	} else if _, ok := err.(errs.NotFoundError); ok {
+		c.JSON(404, gin.H{})
+	} else {
 		c.JSON(500, gin.H{})
+	}
+	c.Abort()
+}
+
+
+//POST /:username/invitations/:project_id/accept
+func (mc *MemberController) AcceptInvitation (c *gin.Context) {
+	mc.answerInvitation(c, mc.memberService.AcceptInvitation)
+}
+
+
+//POST /:username/invitations/:project_id/decline
+func (mc *MemberController) DeclineInvitation (c *gin.Context) {
+	mc.answerInvitation(c, mc.memberService.DeclineInvitation)
+}
+
+
+func (mc *MemberController) answerInvitation (c *gin.Context, answer func(projectId, userId int) error) {
+	username := jwt.GetUsername(c)
+	projectId, err := strconv.Atoi(c.Param("project_id"))
+	if err != nil || c.Param("username") != username {
+		c.HTML(400, "400error.html", gin.H{})
 		c.Abort()
 		return
 	}
 
-	c.JSON(200, gin.H{})
+	if err = answer(projectId, jwt.GetUserId(c)); err != nil {
+		if _, ok := err.(errs.NotFoundError); ok {
+			c.HTML(404, "404error.html", gin.H{})
+		} else if _, ok := err.(errs.InvalidValueError); ok {
+			c.HTML(400, "400error.html", gin.H{})
+		} else {
+			c.HTML(500, "500error.html", gin.H{})
+		}
+		c.Abort()
+		return
+	}
+
+	c.Redirect(303, fmt.Sprintf("/%s", username))
+}
+
+
+//POST /:username/:project_name/members/:user_id/approve
+func (mc *MemberController) ApproveJoin (c *gin.Context) {
+	mc.answerJoin(c, func(projectId int, actorRole string, userId int) error {
+		return mc.memberService.ApproveJoin(projectId, actorRole, userId, c.PostForm("user_role"))
+	})
+}
+
+
+//POST /:username/:project_name/members/:user_id/reject
+func (mc *MemberController) RejectJoin (c *gin.Context) {
+	mc.answerJoin(c, mc.memberService.RejectJoin)
+}
+
+
+//POST /:username/:project_name/members/:user_id/block
+func (mc *MemberController) BlockMember (c *gin.Context) {
+	mc.answerJoin(c, mc.memberService.BlockMember)
+}
+
+
+func (mc *MemberController) answerJoin (c *gin.Context, answer func(projectId int, actorRole string, userId int) error) {
+	project := c.Keys["project"].(model.Project)
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.HTML(400, "400error.html", gin.H{})
+		c.Abort()
+		return
+	}
+
+	if err = answer(project.ProjectId, c.Keys["role"].(string), userId); err != nil {
+		if _, ok := err.(errs.NotFoundError); ok {
+			c.HTML(404, "404error.html", gin.H{})
+		} else if _, ok := err.(errs.ForbiddenError); ok {
+			c.HTML(403, "403error.html", gin.H{})
+		} else if _, ok := err.(errs.InvalidValueError); ok {
+			c.HTML(400, "400error.html", gin.H{})
+		} else {
+			c.HTML(500, "500error.html", gin.H{})
+		}
+		c.Abort()
+		return
+	}
+
+	c.Redirect(303, fmt.Sprintf("/%s/%s/members", c.Param("username"), c.Param("project_name")))
+}
+
+
+// getJoinProject the project of the path for the users not members of it.
+func (mc *MemberController) getJoinProject (c *gin.Context) (model.Project, bool) {
+	project, err := mc.projectService.GetProjectByName(c.Param("username"), c.Param("project_name"))
+	if err != nil {
+		c.HTML(404, "404error.html", gin.H{})
+		c.Abort()
+		return project, false
+	}
+	return project, true
+}
+
+
+//GET /:username/:project_name/join
+func (mc *MemberController) JoinPage (c *gin.Context) {
+	project, ok := mc.getJoinProject(c)
+	if !ok {
+		return
+	}
+
+	userId := jwt.GetUserId(c)
+	if _, err := mc.memberService.GetRole(project, userId); err == nil {
+		c.Redirect(303, fmt.Sprintf("/%s/%s", project.Username, project.ProjectName))
+		return
+	}
+
+	status := ""
+	if m, err := mc.memberService.GetMember(project.ProjectId, userId); err == nil {
+		status = m.UserStatus
+		if m.Expired {
+			status = ""
+		}
+	}
+	if status == constant.STATE_CLS_NOMAL {
+		c.Redirect(303, fmt.Sprintf("/%s/%s", project.Username, project.ProjectName))
+		return
+	}
+
+	c.HTML(200, "join.html", gin.H{
+		"username": jwt.GetUsername(c),
+		"join_project": project,
+		"status": status,
+	})
+}
+
+
+//POST /:username/:project_name/join
+func (mc *MemberController) RequestJoin (c *gin.Context) {
+	project, ok := mc.getJoinProject(c)
+	if !ok {
+		return
+	}
+
+	// AlreadyRegisteredError: the join page tells the state.
+	err := mc.memberService.RequestJoin(project, jwt.GetUserId(c))
+	if _, ok := err.(errs.ForbiddenError); ok {
+		c.HTML(403, "403error.html", gin.H{})
+		c.Abort()
+		return
+	} else if _, ok := err.(errs.AlreadyRegisteredError); err != nil && !ok {
+		c.HTML(500, "500error.html", gin.H{})
+		c.Abort()
+		return
+	}
+
+	c.Redirect(303, fmt.Sprintf("/%s/%s/join", project.Username, project.ProjectName))
+}
+
+
+//POST /:username/:project_name/join/cancel
+func (mc *MemberController) CancelJoin (c *gin.Context) {
+	project, ok := mc.getJoinProject(c)
+	if !ok {
+		return
+	}
+
+	err := mc.memberService.CancelJoin(project.ProjectId, jwt.GetUserId(c))
+	if err != nil {
+		if _, ok := err.(errs.NotFoundError); ok {
+			c.HTML(404, "404error.html", gin.H{})
+		} else {
+			c.HTML(500, "500error.html", gin.H{})
+		}
+		c.Abort()
+		return
+	}
 
+	c.Redirect(303, fmt.Sprintf("/%s/%s/join", project.Username, project.ProjectName))
 }
\ No newline at end of file
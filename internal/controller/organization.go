@@ -0,0 +1,400 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"github.com/gin-gonic/gin"
+
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/core/jwt"
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/service"
+	"goat-cg/internal/model"
+	"goat-cg/internal/dto"
+)
+
+
+type OrganizationController struct {
+	organizationService service.OrganizationService
+}
+
+
+func NewOrganizationController() *OrganizationController {
+	organizationService := service.NewOrganizationService()
+	return &OrganizationController{organizationService}
+}
+
+
+// orgRoles ORG_ROLE_CLS for the select boxes.
+func orgRoles() []gin.H {
+	return []gin.H{
+		{"cls": constant.ORG_ROLE_CLS_MEMBER, "name": constant.ORG_ROLE_CLS_NAME[constant.ORG_ROLE_CLS_MEMBER]},
+		{"cls": constant.ORG_ROLE_CLS_OWNER, "name": constant.ORG_ROLE_CLS_NAME[constant.ORG_ROLE_CLS_OWNER]},
+	}
+}
+
+
+//GET /:username/orgs/new
+func (oc *OrganizationController) CreateOrganizationPage(c *gin.Context) {
+	username := jwt.GetUsername(c)
+
+	if c.Param("username") != username {
+		c.HTML(404, "404error.html", gin.H{})
+		c.Abort()
+		return
+	}
+
+	c.HTML(200, "organization.html", gin.H{
+		"username": username,
+	})
+}
+
+
+//POST /:username/orgs/new
+func (oc *OrganizationController) CreateOrganization(c *gin.Context) {
+	username := jwt.GetUsername(c)
+
+	if c.Param("username") != username {
+		c.HTML(400, "400error.html", gin.H{})
+		c.Abort()
+		return
+	}
+
+	orgName := c.PostForm("org_name")
+	orgMemo := c.PostForm("org_memo")
+
+	err := oc.organizationService.CreateOrganization(jwt.GetUserId(c), orgName, orgMemo)
+	if err == nil {
+		c.Redirect(303, fmt.Sprintf("/%s", orgName))
+		return
+	}
+
+	h := gin.H{
+		"username": username,
+		"org_name": orgName,
+		"org_memo": orgMemo,
+	}
+	if _, ok := err.(errs.UniqueConstraintError); ok {
+		h["error"] = "This name is already taken."
+		c.HTML(409, "organization.html", h)
+	} else if e, ok := err.(errs.InvalidValueError); ok {
+		h["error"] = e.Message
+		c.HTML(400, "organization.html", h)
+	} else {
+		h["error"] = "error occurred."
+		c.HTML(500, "organization.html", h)
+	}
+}
+
+
+// renderMembersPage render org_members.html with the members and the teams of the organization.
+func (oc *OrganizationController) renderMembersPage(c *gin.Context, code int, h gin.H) {
+	org := c.Keys["organization"].(model.Organization)
+	role := c.Keys["org_role"].(string)
+	members, _ := oc.organizationService.GetMembers(org.OrgId)
+	teams, _ := oc.organizationService.GetTeams(org.OrgId)
+
+	h["username"] = org.OrgName
+	h["org"] = org
+	h["members"] = members
+	h["teams"] = teams
+	h["user_id"] = jwt.GetUserId(c)
+	h["role_name"] = constant.ORG_ROLE_CLS_NAME[role]
+	h["is_owner"] = role == constant.ORG_ROLE_CLS_OWNER
+	h["roles"] = orgRoles()
+	c.HTML(code, "org_members.html", h)
+}
+
+
+//GET /:orgname/-/members
+func (oc *OrganizationController) MembersPage(c *gin.Context) {
+	oc.renderMembersPage(c, 200, gin.H{})
+}
+
+
+//POST /:orgname/-/members
+func (oc *OrganizationController) AddMember(c *gin.Context) {
+	org := c.Keys["organization"].(model.Organization)
+	name := c.PostForm("username")
+
+	err := oc.organizationService.AddMember(org.OrgId, name, c.PostForm("org_role"))
+	if err == nil {
+		c.Redirect(303, fmt.Sprintf("/%s/-/members", org.OrgName))
+		return
+	}
+
+	h := gin.H{"name": name}
+	if _, ok := err.(errs.AlreadyRegisteredError); ok {
+		h["error"] = "This user is already a member."
+		oc.renderMembersPage(c, 409, h)
+	} else if e, ok := err.(errs.InvalidValueError); ok {
+		h["error"] = e.Message
+		oc.renderMembersPage(c, 400, h)
+	} else {
+		h["error"] = "error occurred."
+		oc.renderMembersPage(c, 500, h)
+	}
+}
+
+
+//POST /:orgname/-/members/:user_id
+func (oc *OrganizationController) UpdateMemberRole(c *gin.Context) {
+	org := c.Keys["organization"].(model.Organization)
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.HTML(404, "404error.html", gin.H{})
+		c.Abort()
+		return
+	}
+
+	err = oc.organizationService.UpdateMemberRole(org.OrgId, userId, c.PostForm("org_role"))
+	if err == nil {
+		c.Redirect(303, fmt.Sprintf("/%s/-/members", org.OrgName))
+		return
+	}
+
+	if _, ok := err.(errs.NotFoundError); ok {
+		c.HTML(404, "404error.html", gin.H{})
+	} else if e, ok := err.(errs.InvalidValueError); ok {
+		oc.renderMembersPage(c, 400, gin.H{"error": e.Message})
+	} else {
+		oc.renderMembersPage(c, 500, gin.H{"error": "error occurred."})
+	}
+}
+
+
+//DELETE /:orgname/-/members/:user_id
+// owners remove members, and members leave the organization.
+func (oc *OrganizationController) DeleteMember(c *gin.Context) {
+	org := c.Keys["organization"].(model.Organization)
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.JSON(404, gin.H{})
+		c.Abort()
+		return
+	}
+
+	err = oc.organizationService.DeleteMember(
+		org.OrgId, jwt.GetUserId(c), c.Keys["org_role"].(string), userId,
+	)
+	if err != nil {
+		if _, ok := err.(errs.ForbiddenError); ok {
+			c.JSON(403, gin.H{"error": "permission denied."})
+		} else if _, ok := err.(errs.NotFoundError); ok {
+			c.JSON(404, gin.H{})
+		} else if e, ok := err.(errs.InvalidValueError); ok {
+			c.JSON(400, gin.H{"error": e.Message})
+		} else {
+			c.JSON(500, gin.H{"error": "error occurred."})
+		}
+		c.Abort()
+		return
+	}
+
+	c.JSON(200, gin.H{})
+}
+
+
+//POST /:orgname/-/teams
+func (oc *OrganizationController) CreateTeam(c *gin.Context) {
+	org := c.Keys["organization"].(model.Organization)
+	teamName := c.PostForm("team_name")
+
+	err := oc.organizationService.CreateTeam(org.OrgId, teamName, c.PostForm("team_memo"))
+	if err == nil {
+		c.Redirect(303, fmt.Sprintf("/%s/-/members", org.OrgName))
+		return
+	}
+
+	h := gin.H{"team_name": teamName}
+	if _, ok := err.(errs.UniqueConstraintError); ok {
+		h["team_error"] = "TeamName must be unique."
+		oc.renderMembersPage(c, 409, h)
+	} else if e, ok := err.(errs.InvalidValueError); ok {
+		h["team_error"] = "TeamName " + e.Message
+		oc.renderMembersPage(c, 400, h)
+	} else {
+		h["team_error"] = "error occurred."
+		oc.renderMembersPage(c, 500, h)
+	}
+}
+
+
+// getTeam the team of the path. (:team_id of the organization)
+func (oc *OrganizationController) getTeam(c *gin.Context) (model.Team, bool) {
+	org := c.Keys["organization"].(model.Organization)
+	teamId, err := strconv.Atoi(c.Param("team_id"))
+	if err != nil {
+		return model.Team{}, false
+	}
+
+	team, err := oc.organizationService.GetTeam(org.OrgId, teamId)
+	if err != nil {
+		return team, false
+	}
+	return team, true
+}
+
+
+// renderTeamPage render team.html with the members and the projects of the team,
+// and the members and the projects of the organization to add.
+func (oc *OrganizationController) renderTeamPage(c *gin.Context, code int, team model.Team, h gin.H) {
+	org := c.Keys["organization"].(model.Organization)
+	role := c.Keys["org_role"].(string)
+	members, _ := oc.organizationService.GetTeamMembers(team.TeamId)
+	grants, _ := oc.organizationService.GetTeamProjects(team.TeamId)
+
+	inTeam := map[int]bool{}
+	for _, m := range members {
+		inTeam[m.UserId] = true
+	}
+	orgMembers, _ := oc.organizationService.GetMembers(org.OrgId)
+	candidates := []dto.OrgMember{}
+	for _, m := range orgMembers {
+		if !inTeam[m.UserId] {
+			candidates = append(candidates, m)
+		}
+	}
+	projects, _ := oc.organizationService.GetProjects(org.OrgId, jwt.GetUserId(c))
+
+	h["username"] = org.OrgName
+	h["org"] = org
+	h["team"] = team
+	h["members"] = members
+	h["grants"] = grants
+	h["candidates"] = candidates
+	h["projects"] = projects
+	h["is_owner"] = role == constant.ORG_ROLE_CLS_OWNER
+	h["roles"] = assignableRoles(constant.ROLE_CLS_OWNER)
+	c.HTML(code, "team.html", h)
+}
+
+
+//GET /:orgname/-/teams/:team_id
+func (oc *OrganizationController) TeamPage(c *gin.Context) {
+	team, ok := oc.getTeam(c)
+	if !ok {
+		c.HTML(404, "404error.html", gin.H{})
+		c.Abort()
+		return
+	}
+
+	oc.renderTeamPage(c, 200, team, gin.H{})
+}
+
+
+//DELETE /:orgname/-/teams/:team_id
+func (oc *OrganizationController) DeleteTeam(c *gin.Context) {
+	team, ok := oc.getTeam(c)
+	if !ok {
+		c.JSON(404, gin.H{})
+		c.Abort()
+		return
+	}
+
+	if oc.organizationService.DeleteTeam(team.OrgId, team.TeamId) != nil {
+		c.JSON(500, gin.H{"error": "error occurred."})
+		c.Abort()
+		return
+	}
+
+	c.JSON(200, gin.H{})
+}
+
+
+//POST /:orgname/-/teams/:team_id/members
+func (oc *OrganizationController) AddTeamMember(c *gin.Context) {
+	team, ok := oc.getTeam(c)
+	if !ok {
+		c.HTML(404, "404error.html", gin.H{})
+		c.Abort()
+		return
+	}
+
+	userId, err := strconv.Atoi(c.PostForm("user_id"))
+	if err != nil {
+		oc.renderTeamPage(c, 400, team, gin.H{"member_error": "Select a member."})
+		return
+	}
+
+	err = oc.organizationService.AddTeamMember(team.OrgId, team.TeamId, userId)
+	if err == nil {
+		c.Redirect(303, fmt.Sprintf("/%s/-/teams/%d", c.Param("username"), team.TeamId))
+		return
+	}
+
+	if e, ok := err.(errs.InvalidValueError); ok {
+		oc.renderTeamPage(c, 400, team, gin.H{"member_error": e.Message})
+	} else {
+		oc.renderTeamPage(c, 500, team, gin.H{"member_error": "error occurred."})
+	}
+}
+
+
+//DELETE /:orgname/-/teams/:team_id/members/:user_id
+func (oc *OrganizationController) DeleteTeamMember(c *gin.Context) {
+	team, ok := oc.getTeam(c)
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if !ok || err != nil {
+		c.JSON(404, gin.H{})
+		c.Abort()
+		return
+	}
+
+	if oc.organizationService.DeleteTeamMember(team.TeamId, userId) != nil {
+		c.JSON(500, gin.H{"error": "error occurred."})
+		c.Abort()
+		return
+	}
+
+	c.JSON(200, gin.H{})
+}
+
+
+//POST /:orgname/-/teams/:team_id/projects
+func (oc *OrganizationController) GrantTeamProject(c *gin.Context) {
+	team, ok := oc.getTeam(c)
+	if !ok {
+		c.HTML(404, "404error.html", gin.H{})
+		c.Abort()
+		return
+	}
+
+	projectId, err := strconv.Atoi(c.PostForm("project_id"))
+	if err != nil {
+		oc.renderTeamPage(c, 400, team, gin.H{"project_error": "Select a project."})
+		return
+	}
+
+	err = oc.organizationService.GrantTeamProject(team.OrgId, team.TeamId, projectId, c.PostForm("user_role"))
+	if err == nil {
+		c.Redirect(303, fmt.Sprintf("/%s/-/teams/%d", c.Param("username"), team.TeamId))
+		return
+	}
+
+	if e, ok := err.(errs.InvalidValueError); ok {
+		oc.renderTeamPage(c, 400, team, gin.H{"project_error": e.Message})
+	} else {
+		oc.renderTeamPage(c, 500, team, gin.H{"project_error": "error occurred."})
+	}
+}
+
+
+//DELETE /:orgname/-/teams/:team_id/projects/:project_id
+func (oc *OrganizationController) RevokeTeamProject(c *gin.Context) {
+	team, ok := oc.getTeam(c)
+	projectId, err := strconv.Atoi(c.Param("project_id"))
+	if !ok || err != nil {
+		c.JSON(404, gin.H{})
+		c.Abort()
+		return
+	}
+
+	if oc.organizationService.RevokeTeamProject(team.TeamId, projectId) != nil {
+		c.JSON(500, gin.H{"error": "error occurred."})
+		c.Abort()
+		return
+	}
+
+	c.JSON(200, gin.H{})
+}
\ No newline at end of file
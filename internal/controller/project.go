@@ -7,50 +7,107 @@ import (
 
 	"goat-cg/internal/core/jwt"
 	"goat-cg/internal/core/errs"
+	"goat-cg/internal/shared/constant"
 	"goat-cg/internal/service"
 	"goat-cg/internal/model"
+	"goat-cg/internal/dto"
 )
 
 
 type ProjectController struct {
 	projectService  service.ProjectService
+	memberService service.MemberService
+	organizationService service.OrganizationService
 }
 
 
 func NewProjectController() *ProjectController {
 	projectService  := service.NewProjectService()
-	return &ProjectController{projectService}
+	memberService := service.NewMemberService()
+	organizationService := service.NewOrganizationService()
+	return &ProjectController{projectService, memberService, organizationService}
 }
 
 
 //GET /:username or /:username/projects
+// /:orgname is the page of the organization.
 func (cc *ProjectController) ProjectsPage(c *gin.Context) {
 	userId := jwt.GetUserId(c)
 	username := jwt.GetUsername(c)
 
 	if c.Param("username") != username {
+		if org, role, ok := cc.getOrganization(c); ok {
+			projects, _ := cc.organizationService.GetProjects(org.OrgId, userId)
+			c.HTML(200, "org.html", gin.H{
+				"username": org.OrgName,
+				"org": org,
+				"is_owner": role == constant.ORG_ROLE_CLS_OWNER,
+				"projects": projects,
+			})
+			return
+		}
 		c.Redirect(303, fmt.Sprintf("/%s", username))
+		return
 	}
 
 	projects, _ := cc.projectService.GetProjects(userId)
 	member_projects, _ := cc.projectService.GetMemberProjects(userId)
+	invitations, _ := cc.memberService.GetInvitations(userId)
+	organizations, _ := cc.organizationService.GetOrganizations(userId)
 
 	c.HTML(200, "index.html", gin.H{
 		"username": username,
 		"projects": projects,
 		"member_projects": member_projects,
+		"invitations": invitations,
+		"organizations": organizations,
 	})
 }
 
 
+// mergeProjects the projects of the lists without duplicates.
+func mergeProjects(lists ...[]model.Project) []model.Project {
+	ret := []model.Project{}
+	seen := map[int]bool{}
+	for _, projects := range lists {
+		for _, p := range projects {
+			if !seen[p.ProjectId] {
+				seen[p.ProjectId] = true
+				ret = append(ret, p)
+			}
+		}
+	}
+	return ret
+}
+
+
+// getOrganization the organization of the path (/:orgname) and ORG_ROLE_CLS of the user.
+// not ok unless the user is a member.
+func (cc *ProjectController) getOrganization(c *gin.Context) (model.Organization, string, bool) {
+	org, err := cc.organizationService.GetOrganization(c.Param("username"))
+	if err != nil {
+		return org, "", false
+	}
+	role, err := cc.organizationService.GetOrgRole(org.OrgId, jwt.GetUserId(c))
+	if err != nil {
+		return org, "", false
+	}
+	return org, role, true
+}
+
+
 //GET /:username/projects/new
+// /:orgname/projects/new for the owners of the organization.
 func (cc *ProjectController) CreateProjectPage(c *gin.Context) {
 	username := jwt.GetUsername(c)
 
 	if c.Param("username") != username {
-		c.HTML(404, "404error.html", gin.H{})
-		c.Abort()
-		return
+		if _, role, ok := cc.getOrganization(c); !ok || role != constant.ORG_ROLE_CLS_OWNER {
+			c.HTML(404, "404error.html", gin.H{})
+			c.Abort()
+			return
+		}
+		username = c.Param("username")
 	}
 	
 	c.HTML(200, "project.html", gin.H{
@@ -63,17 +120,23 @@ func (cc *ProjectController) CreateProjectPage(c *gin.Context) {
 func (cc *ProjectController) CreateProject(c *gin.Context) {
 	userId := jwt.GetUserId(c)
 	username := jwt.GetUsername(c)
+	projectName := c.PostForm("project_name")
+	projectMemo := c.PostForm("project_memo")
 
+	var err error
 	if c.Param("username") != username {
-		c.HTML(400, "400error.html", gin.H{})
-		c.Abort()
-		return
+		org, role, ok := cc.getOrganization(c)
+		if !ok || role != constant.ORG_ROLE_CLS_OWNER {
+			c.HTML(400, "400error.html", gin.H{})
+			c.Abort()
+			return
+		}
+		username = org.OrgName
+		err = cc.projectService.CreateOrgProject(org, projectName, projectMemo)
+	} else {
+		err = cc.projectService.CreateProject(userId, username, projectName, projectMemo)
 	}
 
-	projectName := c.PostForm("project_name")
-	projectMemo := c.PostForm("project_memo")
-
-	err := cc.projectService.CreateProject(userId, username, projectName, projectMemo)
 	if err == nil {
 		c.Redirect(303, fmt.Sprintf("/%s", username))
 		return
@@ -90,6 +153,13 @@ func (cc *ProjectController) CreateProject(c *gin.Context) {
 			"project": project,
 		})
 
+	} else if e, ok := err.(errs.InvalidValueError); ok {
+		c.HTML(400, "project.html", gin.H{
+			"username": username,
+			"error": "ProjectName must be " + e.Message,
+			"project": project,
+		})
+
 	} else {
 		c.HTML(500, "project.html", gin.H{
 			"username": username,
@@ -102,11 +172,9 @@ func (cc *ProjectController) CreateProject(c *gin.Context) {
 
 //GET /:username/projects/:project_id
 func (cc *ProjectController) UpdateProjectPage(c *gin.Context) {
-	userId := jwt.GetUserId(c)
-	username := jwt.GetUsername(c)
 	projectId, err := strconv.Atoi(c.Param("project_id"))
 
-	if err != nil || c.Param("username") != username {
+	if err != nil {
 		c.HTML(404, "404error.html", gin.H{})
 		c.Abort()
 		return
@@ -114,30 +182,64 @@ func (cc *ProjectController) UpdateProjectPage(c *gin.Context) {
 
 	project, err := cc.projectService.GetProject(projectId)
 
-	if err != nil || project.UserId != userId {
+	if err != nil || !cc.isOwner(c, project) {
 		c.HTML(404, "404error.html", gin.H{})
 		c.Abort()
 		return
 	}
 
 	c.HTML(200, "project.html", gin.H{
-		"username": username,
+		"username": project.Username,
 		"project": project, 
+		"members": cc.activeMembers(project),
 	})
 }
 
 
+// isOwner the user is the owner of the project of the path. (/:username is the owner: a user or an organization)
+func (cc *ProjectController) isOwner(c *gin.Context, project model.Project) bool {
+	if project.Username != c.Param("username") {
+		return false
+	}
+	role, err := cc.memberService.GetRole(project, jwt.GetUserId(c))
+	return err == nil && role == constant.ROLE_CLS_OWNER
+}
+
+
+// activeMembers the members the project can be transferred to. (none for projects of organizations)
+func (cc *ProjectController) activeMembers(project model.Project) []dto.ProjectMember {
+	if project.OrgId != 0 {
+		return nil
+	}
+	members, _ := cc.memberService.GetMembers(project.ProjectId)
+
+	var ret []dto.ProjectMember
+	for _, m := range members {
+		if m.UserStatus == constant.STATE_CLS_NOMAL {
+			ret = append(ret, m)
+		}
+	}
+	return ret
+}
+
+
 //POST /:username/projects/:project_id
 func (cc *ProjectController) UpdateProject(c *gin.Context) {
-	username := jwt.GetUsername(c)
+	username := c.Param("username")
 	projectId, err := strconv.Atoi(c.Param("project_id"))
 
-	if err != nil || c.Param("username") != username {
+	if err != nil {
 		c.HTML(400, "400error.html", gin.H{})
 		c.Abort()
 		return
 	}
 
+	if p, err := cc.projectService.GetProject(projectId); err != nil || !cc.isOwner(c, p) {
+		c.HTML(404, "404error.html", gin.H{})
+		c.Abort()
+		return
+	}
+
 	projectName := c.PostForm("project_name")
 	projectMemo := c.PostForm("project_memo")
 
@@ -159,6 +261,13 @@ func (cc *ProjectController) UpdateProject(c *gin.Context) {
 			"project": project,
 		})
 
+	} else if e, ok := err.(errs.InvalidValueError); ok {
+		c.HTML(400, "project.html", gin.H{
+			"username": username,
+			"error": "ProjectName must be " + e.Message,
+			"project": project,
+		})
+
 	} else {
 		c.HTML(500, "project.html", gin.H{
 			"username": username,
@@ -169,16 +278,70 @@ func (cc *ProjectController) UpdateProject(c *gin.Context) {
 }
 
 
+//POST /:username/projects/:project_id/transfer
+func (cc *ProjectController) TransferProject(c *gin.Context) {
+	username := c.Param("username")
+	projectId, err := strconv.Atoi(c.Param("project_id"))
+
+	if err != nil {
+		c.HTML(400, "400error.html", gin.H{})
+		c.Abort()
+		return
+	}
+
+	project, err := cc.projectService.GetProject(projectId)
+	if err != nil || !cc.isOwner(c, project) {
+		c.HTML(404, "404error.html", gin.H{})
+		c.Abort()
+		return
+	}
+
+	newOwnerId, err := strconv.Atoi(c.PostForm("user_id"))
+	if err != nil {
+		c.HTML(400, "400error.html", gin.H{})
+		c.Abort()
+		return
+	}
+
+	p, err := cc.projectService.TransferProject(projectId, newOwnerId)
+	if err == nil {
+		c.Redirect(303, fmt.Sprintf("/%s/%s", p.Username, p.ProjectName))
+		return
+	}
+
+	status, msg := 500, "error occurred."
+	if _, ok := err.(errs.UniqueConstraintError); ok {
+		status, msg = 409, "The new owner already has a project with the same name."
+	} else if e, ok := err.(errs.InvalidValueError); ok {
+		status, msg = 400, "The new owner must be a member of the project."
+		if e.Field == "project_id" {
+			msg = "Projects of organizations cannot be transferred."
+		}
+	}
+	c.HTML(status, "project.html", gin.H{
+		"username": username,
+		"project": project,
+		"members": cc.activeMembers(project),
+		"transfer_error": msg,
+	})
+}
+
+
 //DELETE /:username/projects/:project_id
 func (cc *ProjectController) DeleteProject(c *gin.Context) {
-	username := jwt.GetUsername(c)
 	projectId, err := strconv.Atoi(c.Param("project_id"))
 
-	if err != nil || c.Param("username") != username {
+	if err != nil {
 		c.JSON(400, gin.H{})
 		c.Abort()
 		return
 	}
+
+	if p, err := cc.projectService.GetProject(projectId); err != nil || !cc.isOwner(c, p) {
+		c.JSON(404, gin.H{})
+		c.Abort()
+		return
+	}
 	
 	if cc.projectService.DeleteProject(projectId) != nil {
 		c.JSON(500, gin.H{"error": "error occurred."})
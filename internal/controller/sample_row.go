@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/service"
+	"goat-cg/internal/model"
+)
+
+
+type SampleRowController struct {
+	sampleRowService service.SampleRowService
+	columnService service.ColumnService
+}
+
+
+func NewSampleRowController() *SampleRowController {
+	sampleRowService := service.NewSampleRowService()
+	columnService := service.NewColumnService()
+	return &SampleRowController{sampleRowService, columnService}
+}
+
+
+//GET /:username/:project_name/tables/:table_id/samples
+func (ctr *SampleRowController) SampleRowsPage(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	table := c.Keys["table"].(model.Table)
+
+	columns, _ := ctr.columnService.GetColumns(table.TableId)
+	text, _ := ctr.sampleRowService.GetSampleRowsCsv(table.TableId)
+
+	c.HTML(200, "samplerows.html", gin.H{
+		"project": project,
+		"table": table,
+		"columns": columns,
+		"sample_rows": text,
+	})
+}
+
+
+//POST /:username/:project_name/tables/:table_id/samples
+func (ctr *SampleRowController) UpdateSampleRows(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	table := c.Keys["table"].(model.Table)
+
+	text := c.PostForm("sample_rows")
+	err := ctr.sampleRowService.UpdateSampleRowsCsv(table.TableId, text)
+
+	if err == nil {
+		c.Redirect(303, fmt.Sprintf(
+			"/%s/%s/tables/%s/samples",
+			c.Param("username"), c.Param("project_name"), c.Param("table_id"),
+		))
+		return
+	}
+
+	columns, _ := ctr.columnService.GetColumns(table.TableId)
+	h := gin.H{
+		"project": project,
+		"table": table,
+		"columns": columns,
+		"sample_rows": text,
+	}
+	switch e := err.(type) {
+	case errs.InvalidValueError:
+		h["error"] = fmt.Sprintf("%s: %s", e.Field, e.Message)
+		c.HTML(400, "samplerows.html", h)
+	default:
+		h["error"] = "error occurred."
+		c.HTML(500, "samplerows.html", h)
+	}
+}
+
+
+//PUT /:username/:project_name/tables/:table_id/samples
+func (ctr *SampleRowController) SaveSampleRows(c *gin.Context) {
+	table := c.Keys["table"].(model.Table)
+
+	var rows []map[string]*string
+	if err := c.ShouldBindJSON(&rows); err != nil {
+		c.JSON(400, gin.H{"error": "invalid input."})
+		return
+	}
+
+	err := ctr.sampleRowService.UpdateSampleRows(table.TableId, rows)
+	switch e := err.(type) {
+	case nil:
+		c.JSON(200, gin.H{})
+	case errs.InvalidValueError:
+		c.JSON(400, gin.H{"error": fmt.Sprintf("%s: %s", e.Field, e.Message)})
+	default:
+		c.JSON(500, gin.H{"error": "error occurred."})
+	}
+}
\ No newline at end of file
@@ -15,13 +15,15 @@ import (
 type TableController struct {
 	projectService service.ProjectService
 	tableService service.TableService
+	sampleRowService service.SampleRowService
 }
 
 
 func NewTableController() *TableController {
 	projectService := service.NewProjectService()
 	tableService := service.NewTableService()
-	return &TableController{projectService, tableService}
+	sampleRowService := service.NewSampleRowService()
+	return &TableController{projectService, tableService, sampleRowService}
 }
 
 
@@ -55,8 +57,13 @@ func (tc *TableController) CreateTable(c *gin.Context) {
 
 	tableName := c.PostForm("table_name")
 	tableNameLogical := c.PostForm("table_name_logical")
+	versionFlg, err := strconv.Atoi(c.PostForm("version_flg"))
 
-	err := tc.tableService.CreateTable(project.ProjectId, userId, tableName, tableNameLogical)
+	if err != nil || versionFlg != 1 {
+		versionFlg = 0
+	}
+
+	err = tc.tableService.CreateTable(project.ProjectId, userId, tableName, tableNameLogical, versionFlg)
 
 	if err == nil {
 		c.Redirect(303, fmt.Sprintf("/%s/%s", c.Param("username"), c.Param("project_name")))
@@ -66,6 +73,7 @@ func (tc *TableController) CreateTable(c *gin.Context) {
 	var table model.Table
 	table.TableName = tableName
 	table.TableNameLogical = tableNameLogical
+	table.VersionFlg = versionFlg
 
 	if _, ok := err.(errs.UniqueConstraintError); ok {
 		c.HTML(409, "table.html", gin.H{
@@ -88,10 +96,12 @@ func (tc *TableController) CreateTable(c *gin.Context) {
 func (tc *TableController) UpdateTablePage(c *gin.Context) {
 	project := c.Keys["project"].(model.Project)
 	table := c.Keys["table"].(model.Table)
+	samples, _ := tc.sampleRowService.GetSampleRows(table.TableId, true)
 
 	c.HTML(200, "table.html", gin.H{
 		"project": project, 
 		"table": table,
+		"samples": samples,
 	})
 }
 
@@ -104,6 +114,12 @@ func (tc *TableController) UpdateTable(c *gin.Context) {
 
 	tableName := c.PostForm("table_name")
 	tableNameLogical := c.PostForm("table_name_logical")
+	versionFlg, err := strconv.Atoi(c.PostForm("version_flg"))
+
+	if err != nil || versionFlg != 1 {
+		versionFlg = 0
+	}
+
 	delFlg, err := strconv.Atoi(c.PostForm("del_flg"))
 
 	if err != nil || delFlg != 1 {
@@ -111,7 +127,7 @@ func (tc *TableController) UpdateTable(c *gin.Context) {
 	}
 
 	err = tc.tableService.UpdateTable(
-		project.ProjectId, table.TableId, userId, tableName, tableNameLogical, delFlg,
+		project.ProjectId, table.TableId, userId, tableName, tableNameLogical, versionFlg, delFlg,
 	)
 
 	if err == nil {
@@ -121,6 +137,7 @@ func (tc *TableController) UpdateTable(c *gin.Context) {
 
 	table.TableName = tableName
 	table.TableNameLogical = tableNameLogical
+	table.VersionFlg = versionFlg
 	table.DelFlg = delFlg
 
 	if _, ok := err.(errs.UniqueConstraintError); ok {
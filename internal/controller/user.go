@@ -1,23 +1,32 @@
 package controller
 
 import (
+	"fmt"
+	"strconv"
 	"github.com/gin-gonic/gin"
 
 	"goat-cg/config"
 	"goat-cg/internal/core/jwt"
 	"goat-cg/internal/core/errs"
 	"goat-cg/internal/service"
+	"goat-cg/internal/dto"
 )
 
 
 type UserController struct {
 	userService service.UserService
+	projectService service.ProjectService
+	accessTokenService service.AccessTokenService
+	organizationService service.OrganizationService
 }
 
 
 func NewUserController() *UserController {
 	userService := service.NewUserService()
-	return &UserController{userService}
+	projectService := service.NewProjectService()
+	accessTokenService := service.NewAccessTokenService()
+	organizationService := service.NewOrganizationService()
+	return &UserController{userService, projectService, accessTokenService, organizationService}
 }
 
 
@@ -34,7 +43,7 @@ func (uc *UserController) LoginPage(c *gin.Context) {
 //GET /account
 func (uc *UserController) AccountPage(c *gin.Context) {
 	user, _ := uc.userService.GetProfile(jwt.GetUserId(c))
-	c.HTML(200, "account.html", gin.H{
+	uc.renderAccountPage(c, 200, gin.H{
 		"username": user.Username,
 		"email": user.Email,
 	})
@@ -66,6 +75,14 @@ func (uc *UserController) Signup(c *gin.Context) {
 				})
 			}
 			
+		} else if e, ok := err.(errs.InvalidValueError); ok {
+			c.HTML(400, "signup.html", gin.H{
+				"username": name,
+				"password": pass,
+				"email": email,
+				"error": "Username must be " + e.Message,
+			})
+
 		} else {
 			c.HTML(500, "signup.html", gin.H{
 				"username": name,
@@ -135,7 +152,7 @@ func (uc *UserController) UpdatePassword(c *gin.Context) {
 
 	if err != nil {
 		user, _ = uc.userService.GetProfile(jwt.GetUserId(c))
-		c.HTML(400, "account.html", gin.H{
+		uc.renderAccountPage(c, 400, gin.H{
 			"password_error": "Incorrect Current Password.",
 			"username": user.Username,
 			"email": user.Email,
@@ -145,7 +162,7 @@ func (uc *UserController) UpdatePassword(c *gin.Context) {
 	}
 
 	if uc.userService.UpdatePassword(id, newPass) != nil {
-		c.HTML(500, "account.html", gin.H{
+		uc.renderAccountPage(c, 500, gin.H{
 			"password_error": "error occurred.",
 			"username": user.Username,
 			"email": user.Email,
@@ -158,6 +175,41 @@ func (uc *UserController) UpdatePassword(c *gin.Context) {
 }
 
 
+//POST /account/username
+func (uc *UserController) UpdateUsername(c *gin.Context) {
+	id := jwt.GetUserId(c)
+	name := c.PostForm("username")
+
+	err := uc.userService.UpdateName(id, name)
+	if err != nil {
+		user, _ := uc.userService.GetProfile(id)
+		if _, ok := err.(errs.UniqueConstraintError); ok {
+			uc.renderAccountPage(c, 409, gin.H{
+				"username_error": "This Username is already taken.",
+				"username": user.Username,
+				"email": user.Email,
+			})
+		} else if e, ok := err.(errs.InvalidValueError); ok {
+			uc.renderAccountPage(c, 400, gin.H{
+				"username_error": e.Message,
+				"username": user.Username,
+				"email": user.Email,
+			})
+		} else {
+			uc.renderAccountPage(c, 500, gin.H{
+				"username_error": "error occurred.",
+				"username": user.Username,
+				"email": user.Email,
+			})
+		}
+		c.Abort()
+		return
+	}
+
+	c.Redirect(303, "/logout")
+}
+
+
 //POST /account/email
 func (uc *UserController) UpdateEmail(c *gin.Context) {
 	id := jwt.GetUserId(c)
@@ -166,13 +218,13 @@ func (uc *UserController) UpdateEmail(c *gin.Context) {
 	err := uc.userService.UpdateEmail(id, email)
 	if err != nil {
 		if _, ok := err.(errs.UniqueConstraintError); ok {
-			c.HTML(409, "account.html", gin.H{
+			uc.renderAccountPage(c, 409, gin.H{
 				"email_error": "This Email is already taken.",
 				"username": jwt.GetUsername(c),
 				"email": email,
 			})
 		} else {
-			c.HTML(500, "account.html", gin.H{
+			uc.renderAccountPage(c, 500, gin.H{
 				"email_error": "error occurred.",
 				"username": jwt.GetUsername(c),
 				"email": email,
@@ -186,6 +238,82 @@ func (uc *UserController) UpdateEmail(c *gin.Context) {
 }
 
 
+//POST /:username/account/tokens
+func (uc *UserController) CreateAccessToken(c *gin.Context) {
+	userId := jwt.GetUserId(c)
+	user, _ := uc.userService.GetProfile(userId)
+
+	projectId, _ := strconv.Atoi(c.PostForm("project_id"))
+	expiresDays, _ := strconv.Atoi(c.PostForm("expires_days"))
+	in := dto.CreateAccessToken{
+		UserId: userId,
+		TokenName: c.PostForm("token_name"),
+		ScopeCls: c.PostForm("scope_cls"),
+		ProjectId: projectId,
+		ExpiresDays: expiresDays,
+	}
+
+	token, err := uc.accessTokenService.CreateAccessToken(in)
+	h := gin.H{"username": user.Username, "email": user.Email}
+	switch e := err.(type) {
+	case nil:
+		h["new_token"] = token
+		uc.renderAccountPage(c, 200, h)
+	case errs.UniqueConstraintError:
+		h["token_error"] = "This token name is already used."
+		h["token_form"] = in
+		uc.renderAccountPage(c, 409, h)
+	case errs.InvalidValueError:
+		h["token_error"] = fmt.Sprintf("%s: %s", e.Field, e.Message)
+		h["token_form"] = in
+		uc.renderAccountPage(c, 400, h)
+	default:
+		h["token_error"] = "error occurred."
+		h["token_form"] = in
+		uc.renderAccountPage(c, 500, h)
+	}
+}
+
+
+//DELETE /:username/account/tokens/:token_id
+func (uc *UserController) DeleteAccessToken(c *gin.Context) {
+	tokenId, err := strconv.Atoi(c.Param("token_id"))
+	if err != nil {
+		c.JSON(400, gin.H{})
+		c.Abort()
+		return
+	}
+
+	err = uc.accessTokenService.DeleteAccessToken(jwt.GetUserId(c), tokenId)
+	if _, ok := err.(errs.NotFoundError); ok {
+		c.JSON(404, gin.H{"error": "token not found."})
+		c.Abort()
+		return
+	} else if err != nil {
+		c.JSON(500, gin.H{"error": "error occurred."})
+		c.Abort()
+		return
+	}
+
+	c.JSON(200, gin.H{})
+}
+
+
+// renderAccountPage render account.html with access tokens and projects for the token form.
+func (uc *UserController) renderAccountPage(c *gin.Context, code int, h gin.H) {
+	userId := jwt.GetUserId(c)
+
+	tokens, _ := uc.accessTokenService.GetAccessTokens(userId)
+	projects, _ := uc.projectService.GetProjects(userId)
+	memberProjects, _ := uc.projectService.GetMemberProjects(userId)
+	orgProjects, _ := uc.organizationService.GetUserProjects(userId)
+
+	h["tokens"] = tokens
+	h["projects"] = mergeProjects(projects, memberProjects, orgProjects)
+	c.HTML(code, "account.html", h)
+}
+
+
 //GET /api/account/profile
 func (uc *UserController) GetProfile(c *gin.Context) {
 	user, err := uc.userService.GetProfile(jwt.GetUserId(c))
@@ -0,0 +1,203 @@
+package controller
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+
+	"goat-cg/internal/core/jwt"
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/shared/form"
+	"goat-cg/internal/service"
+	"goat-cg/internal/model"
+)
+
+
+type ViewController struct {
+	viewService service.ViewService
+	tableService service.TableService
+}
+
+
+func NewViewController() *ViewController {
+	viewService := service.NewViewService()
+	tableService := service.NewTableService()
+	return &ViewController{viewService, tableService}
+}
+
+
+//GET /:username/:project_name/views
+func (vc *ViewController) ViewsPage(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+
+	views, _ := vc.viewService.GetViews(project.ProjectId)
+
+	c.HTML(200, "views.html", gin.H{
+		"project": project,
+		"views": views,
+	})
+}
+
+
+//GET /:username/:project_name/views/new
+func (vc *ViewController) CreateViewPage(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+
+	tables, _ := vc.tableService.GetTables(project.ProjectId)
+
+	c.HTML(200, "view.html", gin.H{
+		"project": project,
+		"tables": tables,
+		"tableIds": tableIdSet(nil),
+	})
+}
+
+
+//POST /:username/:project_name/views/new
+func (vc *ViewController) CreateView(c *gin.Context) {
+	userId := jwt.GetUserId(c)
+	project := c.Keys["project"].(model.Project)
+
+	tables, _ := vc.tableService.GetTables(project.ProjectId)
+
+	var form form.PostView
+	if err := c.ShouldBind(&form); err != nil {
+		c.HTML(400, "view.html", gin.H{
+			"project": project,
+			"tables": tables,
+			"view": form,
+			"tableIds": tableIdSet(form.TableIds),
+			"error": "invalid input.",
+		})
+		return
+	}
+	err := vc.viewService.CreateView(form.ToCreateView(project.ProjectId, userId))
+
+	if err == nil {
+		c.Redirect(303, fmt.Sprintf(
+			"/%s/%s/views", c.Param("username"), c.Param("project_name"),
+		))
+		return
+	}
+
+	vc.renderViewError(c, err, gin.H{
+		"project": project,
+		"tables": tables,
+		"view": form,
+		"tableIds": tableIdSet(form.TableIds),
+	})
+}
+
+
+//GET /:username/:project_name/views/:view_id
+func (vc *ViewController) UpdateViewPage(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	view := c.Keys["view"].(model.View)
+
+	tables, _ := vc.tableService.GetTables(project.ProjectId)
+	viewTables, _ := vc.viewService.GetViewTables(view.ViewId)
+	viewColumns, _ := vc.viewService.GetViewColumns(view.ViewId)
+
+	var tableIds []int
+	for _, vt := range viewTables {
+		tableIds = append(tableIds, vt.TableId)
+	}
+
+	c.HTML(200, "view.html", gin.H{
+		"project": project,
+		"tables": tables,
+		"view": view,
+		"tableIds": tableIdSet(tableIds),
+		"viewColumns": viewColumns,
+	})
+}
+
+
+//POST /:username/:project_name/views/:view_id
+func (vc *ViewController) UpdateView(c *gin.Context) {
+	userId := jwt.GetUserId(c)
+	project := c.Keys["project"].(model.Project)
+	view := c.Keys["view"].(model.View)
+
+	tables, _ := vc.tableService.GetTables(project.ProjectId)
+
+	var form form.PostView
+	if err := c.ShouldBind(&form); err != nil {
+		form.ViewId = view.ViewId
+		c.HTML(400, "view.html", gin.H{
+			"project": project,
+			"tables": tables,
+			"view": form,
+			"tableIds": tableIdSet(form.TableIds),
+			"error": "invalid input.",
+		})
+		return
+	}
+	form.ViewId = view.ViewId
+	err := vc.viewService.UpdateView(form.ToCreateView(project.ProjectId, userId))
+
+	if err == nil {
+		c.Redirect(303, fmt.Sprintf(
+			"/%s/%s/views", c.Param("username"), c.Param("project_name"),
+		))
+		return
+	}
+
+	vc.renderViewError(c, err, gin.H{
+		"project": project,
+		"tables": tables,
+		"view": form,
+		"tableIds": tableIdSet(form.TableIds),
+	})
+}
+
+
+//DELETE /:username/:project_name/views/:view_id
+func (vc *ViewController) DeleteView(c *gin.Context) {
+	view := c.Keys["view"].(model.View)
+
+	if vc.viewService.DeleteView(view.ViewId) != nil {
+		c.JSON(500, gin.H{})
+		c.Abort()
+		return
+	}
+
+	c.JSON(200, gin.H{})
+}
+
+
+//GET /:username/:project_name/views/:view_id/log
+func (vc *ViewController) ViewLogPage(c *gin.Context) {
+	project := c.Keys["project"].(model.Project)
+	view := c.Keys["view"].(model.View)
+	viewLog, _ := vc.viewService.GetViewLog(view.ViewId)
+
+	c.HTML(200, "viewlog.html", gin.H{
+		"project": project,
+		"viewlog": viewLog,
+	})
+}
+
+
+// tableIdSet for checking table checkboxes in templates. (view.html, _table-select.html)
+func tableIdSet(tableIds []int) map[int]bool {
+	ret := map[int]bool{}
+	for _, id := range tableIds {
+		ret[id] = true
+	}
+	return ret
+}
+
+
+func (vc *ViewController) renderViewError(c *gin.Context, err error, h gin.H) {
+	switch e := err.(type) {
+	case errs.UniqueConstraintError:
+		h["error"] = "ViewName must be unique."
+		c.HTML(409, "view.html", h)
+	case errs.InvalidValueError:
+		h["error"] = fmt.Sprintf("%s: %s", e.Field, e.Message)
+		c.HTML(400, "view.html", h)
+	default:
+		h["error"] = "error occurred."
+		c.HTML(500, "view.html", h)
+	}
+}
\ No newline at end of file
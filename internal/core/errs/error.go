@@ -37,4 +37,29 @@ func NewAlreadyRegisteredError() error {
 
 func (e AlreadyRegisteredError) Error() string {
 	return "AlreadyRegisteredError"
+}
+
+/////////////////////////////////////////////////////////////////////////
+type InvalidValueError struct {
+	Field string
+	Message string
+}
+
+func NewInvalidValueError(field, message string) error {
+	return InvalidValueError{Field: field, Message: message}
+}
+
+func (e InvalidValueError) Error() string {
+	return fmt.Sprintf("InvalidValueError: %s %s", e.Field, e.Message)
+}
+
+/////////////////////////////////////////////////////////////////////////
+type ForbiddenError struct {}
+
+func NewForbiddenError() error {
+	return ForbiddenError{}
+}
+
+func (e ForbiddenError) Error() string {
+	return "ForbiddenError"
 }
\ No newline at end of file
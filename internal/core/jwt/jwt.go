@@ -75,12 +75,14 @@ func JwtAuthMiddleware() gin.HandlerFunc {
 
 func JwtAuthApiMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		//jwtStr, _ := extractTokenFromRequestHeader(c)
-		jwtStr, _ := extractTokenFromCookie(c)
+		jwtStr, err := extractTokenFromRequestHeader(c)
+		if err != nil {
+			jwtStr, _ = extractTokenFromCookie(c)
+		}
 		pl, err := jwtAuth(jwtStr)
 
 		if err != nil {
-			c.JSON(401, gin.H{"error": err.Error()})
+			c.JSON(401, gin.H{"error": "unauthorized.", "type": "unauthorized"})
 			c.Abort()
 			return
 		}
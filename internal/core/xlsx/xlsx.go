@@ -0,0 +1,371 @@
+// Package xlsx reads and writes minimal Office Open XML workbooks. (text cells only)
+package xlsx
+
+import (
+	"io"
+	"fmt"
+	"strings"
+	"time"
+	"archive/zip"
+	"encoding/xml"
+)
+
+
+// limits of the workbooks read. (the limits of Excel, and of the decompressed size of the parts read)
+const (
+	MaxRows = 1048576
+	MaxColumns = 16384
+	MaxReadSize = 100 << 20
+)
+
+
+type Sheet struct {
+	Name string
+	Rows [][]string
+	// HeaderRows rows from the top drawn in bold.
+	HeaderRows int
+}
+
+
+const contentTypesXml = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+%s</Types>`
+
+const rootRelsXml = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+const stylesXml = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill>` +
+`<fill><patternFill patternType="solid"><fgColor rgb="FFEEEEEE"/><bgColor indexed="64"/></patternFill></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+`<xf numFmtId="0" fontId="1" fillId="2" borderId="0" xfId="0" applyFont="1" applyFill="1"/></cellXfs>
+</styleSheet>`
+
+
+// Write write the workbook to w.
+// sheet names are shortened to 31 characters and made unique.
+func Write(w io.Writer, sheets []Sheet) error {
+	zw := zip.NewWriter(w)
+
+	names := sheetNames(sheets)
+	overrides, workbookSheets, rels := "", "", ""
+	for i := range sheets {
+		overrides += fmt.Sprintf(
+			"<Override PartName=\"/xl/worksheets/sheet%d.xml\" " +
+			"ContentType=\"application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml\"/>\n", i + 1,
+		)
+		workbookSheets += fmt.Sprintf(
+			"<sheet name=\"%s\" sheetId=\"%d\" r:id=\"rId%d\"/>", escape(names[i]), i + 1, i + 1,
+		)
+		rels += fmt.Sprintf(
+			"<Relationship Id=\"rId%d\" " +
+			"Type=\"http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet\" " +
+			"Target=\"worksheets/sheet%d.xml\"/>\n", i + 1, i + 1,
+		)
+	}
+	rels += fmt.Sprintf(
+		"<Relationship Id=\"rId%d\" " +
+		"Type=\"http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles\" " +
+		"Target=\"styles.xml\"/>\n", len(sheets) + 1,
+	)
+
+	files := []struct{ name, body string }{
+		{"[Content_Types].xml", fmt.Sprintf(contentTypesXml, overrides)},
+		{"_rels/.rels", rootRelsXml},
+		{"xl/workbook.xml", "<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"yes\"?>\n" +
+			"<workbook xmlns=\"http://schemas.openxmlformats.org/spreadsheetml/2006/main\" " +
+			"xmlns:r=\"http://schemas.openxmlformats.org/officeDocument/2006/relationships\">" +
+			"<sheets>" + workbookSheets + "</sheets></workbook>"},
+		{"xl/_rels/workbook.xml.rels", "<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"yes\"?>\n" +
+			"<Relationships xmlns=\"http://schemas.openxmlformats.org/package/2006/relationships\">\n" +
+			rels + "</Relationships>"},
+		{"xl/styles.xml", stylesXml},
+	}
+	for i, sh := range sheets {
+		files = append(files, struct{ name, body string }{
+			fmt.Sprintf("xl/worksheets/sheet%d.xml", i + 1), sheetXml(sh),
+		})
+	}
+
+	now := time.Now()
+	for _, f := range files {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: f.name, Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return err
+		}
+		if _, err = io.WriteString(fw, f.body); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+
+func sheetXml(sh Sheet) string {
+	s := "<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"yes\"?>\n" +
+		"<worksheet xmlns=\"http://schemas.openxmlformats.org/spreadsheetml/2006/main\"><sheetData>"
+
+	for r, row := range sh.Rows {
+		s += fmt.Sprintf("<row r=\"%d\">", r + 1)
+		style := ""
+		if r < sh.HeaderRows {
+			style = " s=\"1\""
+		}
+		for c, v := range row {
+			if v == "" {
+				continue
+			}
+			s += fmt.Sprintf(
+				"<c r=\"%s%d\" t=\"inlineStr\"%s><is><t xml:space=\"preserve\">%s</t></is></c>",
+				ColumnName(c), r + 1, style, escape(v),
+			)
+		}
+		s += "</row>"
+	}
+
+	return s + "</sheetData></worksheet>"
+}
+
+
+// ColumnName 0 -> "A", 25 -> "Z", 26 -> "AA"
+func ColumnName(index int) string {
+	s := ""
+	for index >= 0 {
+		s = string(rune('A' + index % 26)) + s
+		index = index / 26 - 1
+	}
+	return s
+}
+
+
+// sheetNames sheet names shortened to 31 characters without []:*?/\ and made unique.
+func sheetNames(sheets []Sheet) []string {
+	var ret []string
+	used := map[string]bool{}
+
+	for i, sh := range sheets {
+		name := strings.Map(func(r rune) rune {
+			if strings.ContainsRune("[]:*?/\\", r) {
+				return '_'
+			}
+			return r
+		}, sh.Name)
+		if name == "" {
+			name = fmt.Sprintf("Sheet%d", i + 1)
+		}
+		if r := []rune(name); len(r) > 31 {
+			name = string(r[:31])
+		}
+		base := name
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			suffix := fmt.Sprintf("~%d", n)
+			r := []rune(base)
+			if len(r) + len(suffix) > 31 {
+				r = r[:31 - len(suffix)]
+			}
+			name = string(r) + suffix
+		}
+		used[strings.ToLower(name)] = true
+		ret = append(ret, name)
+	}
+
+	return ret
+}
+
+
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+type xmlWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		Rid string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xmlRelationships struct {
+	Relationships []struct {
+		Id string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xmlText text of <si> (shared string) and <is> (inline string). 
+// rich text is the concatenation of the runs.
+type xmlText struct {
+	T string `xml:"t"`
+	R []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (x xmlText) String() string {
+	s := x.T
+	for _, r := range x.R {
+		s += r.T
+	}
+	return s
+}
+
+type xmlWorksheet struct {
+	Rows []struct {
+		R int `xml:"r,attr"`
+		Cells []struct {
+			R string `xml:"r,attr"`
+			T string `xml:"t,attr"`
+			V string `xml:"v"`
+			Is xmlText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+
+// Read read text of all sheets in the workbook.
+// numbers are returned as written in the file, formulas as their cached values.
+func Read(r io.ReaderAt, size int64) ([]Sheet, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	remaining := int64(MaxReadSize)
+	decode := func(name string, v interface{}) error {
+		f, ok := files[name]
+		if !ok {
+			return fmt.Errorf("xlsx: %s not found", name)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+
+		lr := &io.LimitedReader{R: rc, N: remaining + 1}
+		err = xml.NewDecoder(lr).Decode(v)
+		if lr.N <= 0 {
+			return fmt.Errorf("xlsx: workbook larger than %d bytes decompressed", MaxReadSize)
+		}
+		remaining -= remaining + 1 - lr.N
+		return err
+	}
+
+	var wb xmlWorkbook
+	if err = decode("xl/workbook.xml", &wb); err != nil {
+		return nil, err
+	}
+	var rels xmlRelationships
+	if err = decode("xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+	targets := map[string]string{}
+	for _, rel := range rels.Relationships {
+		t := strings.TrimPrefix(rel.Target, "/")
+		if !strings.HasPrefix(t, "xl/") {
+			t = "xl/" + t
+		}
+		targets[rel.Id] = t
+	}
+
+	var shared []string
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		var sst struct {
+			Si []xmlText `xml:"si"`
+		}
+		if err = decode("xl/sharedStrings.xml", &sst); err != nil {
+			return nil, err
+		}
+		for _, si := range sst.Si {
+			shared = append(shared, si.String())
+		}
+	}
+
+	var ret []Sheet
+	for _, s := range wb.Sheets {
+		var ws xmlWorksheet
+		if err = decode(targets[s.Rid], &ws); err != nil {
+			return nil, err
+		}
+
+		sheet := Sheet{Name: s.Name}
+		for i, row := range ws.Rows {
+			rowIndex := i
+			if row.R > 0 {
+				rowIndex = row.R - 1
+			}
+			if rowIndex >= MaxRows {
+				return nil, fmt.Errorf("xlsx: %s has more than %d rows", s.Name, MaxRows)
+			}
+			for len(sheet.Rows) <= rowIndex {
+				sheet.Rows = append(sheet.Rows, []string{})
+			}
+
+			var values []string
+			for j, c := range row.Cells {
+				col := j
+				if c.R != "" {
+					col = columnIndex(c.R)
+				}
+				if col < 0 {
+					return nil, fmt.Errorf("xlsx: invalid cell reference %q in %s", c.R, s.Name)
+				}
+				if col >= MaxColumns {
+					return nil, fmt.Errorf("xlsx: %s has more than %d columns", s.Name, MaxColumns)
+				}
+				for len(values) <= col {
+					values = append(values, "")
+				}
+
+				switch c.T {
+				case "s":
+					var idx int
+					if _, err := fmt.Sscan(c.V, &idx); err != nil || idx < 0 || idx >= len(shared) {
+						return nil, fmt.Errorf("xlsx: shared string %q not found in %s", c.V, s.Name)
+					}
+					values[col] = shared[idx]
+				case "inlineStr":
+					values[col] = c.Is.String()
+				default:
+					values[col] = c.V
+				}
+			}
+			sheet.Rows[rowIndex] = values
+		}
+		ret = append(ret, sheet)
+	}
+
+	return ret, nil
+}
+
+
+// columnIndex "B3" -> 1. -1 without the column letters, MaxColumns beyond the limit.
+func columnIndex(ref string) int {
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		n = n * 26 + int(r - 'A') + 1
+		if n > MaxColumns {
+			return MaxColumns
+		}
+	}
+	return n - 1
+}
\ No newline at end of file
@@ -0,0 +1,26 @@
+package dto
+
+
+type CreateAccessToken struct {
+	UserId int
+	TokenName string
+	ScopeCls string
+	ProjectId int
+	ExpiresDays int
+}
+
+
+// AccessToken access token for the account page. (the token itself is never shown again)
+type AccessToken struct {
+	TokenId int
+	TokenName string
+	TokenPrefix string
+	ScopeCls string
+	ScopeName string
+	ProjectId int
+	ProjectName string
+	ExpiresAt string
+	Expired bool
+	LastUsedAt string
+	CreatedAt string
+}
\ No newline at end of file
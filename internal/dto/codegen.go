@@ -0,0 +1,17 @@
+package dto
+
+
+// Codegen options of a generator. (options not used by the generator are ignored)
+// SaveProtoFields: keep the numbers of new proto fields. (editors)
+type Codegen struct {
+	ProjectName string
+	Rdbms string
+	TableIds []int
+	ViewIds []int
+	Zod bool
+	Grpc bool
+	Orm string
+	SeedFormat string
+	SeedRows int
+	SaveProtoFields bool
+}
\ No newline at end of file
@@ -2,24 +2,27 @@ package dto
 
 
 type ColumnLog struct {
-	ColumnId int
-	TableId int
-	ColumnName string
-	ColumnNameLogical string
-	DataTypeCls string
-	Precision int
-	Scale int
-	PrimaryKeyFlg int
-	NotNullFlg int
-	UniqueFlg int
-	DefaultValue string
-	Remark string
-	AlignSeq int
-	DelFlg int
-	CreateUserId int
-	CreateUsername string
-	UpdateUserId int
-	UpdateUsername string
-	CreatedAt string
-	UpdatedAt string
+	ColumnId int `json:"column_id"`
+	TableId int `json:"table_id"`
+	ColumnName string `json:"column_name"`
+	ColumnNameLogical string `json:"column_name_logical"`
+	DataTypeCls string `json:"data_type_cls"`
+	Precision int `json:"precision"`
+	Scale int `json:"scale"`
+	PrimaryKeyFlg int `json:"primary_key_flg"`
+	NotNullFlg int `json:"not_null_flg"`
+	UniqueFlg int `json:"unique_flg"`
+	DefaultValue string `json:"default_value"`
+	EnumValues string `json:"enum_values"`
+	ClassificationId int `json:"classification_id"`
+	RefColumnId int `json:"ref_column_id"`
+	Remark string `json:"remark"`
+	AlignSeq int `json:"align_seq"`
+	DelFlg int `json:"del_flg"`
+	CreateUserId int `json:"create_user_id"`
+	CreateUsername string `json:"create_username"`
+	UpdateUserId int `json:"update_user_id"`
+	UpdateUsername string `json:"update_username"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
 }
\ No newline at end of file
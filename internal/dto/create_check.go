@@ -0,0 +1,42 @@
+package dto
+
+
+import (
+	"goat-cg/internal/model"
+)
+
+type CreateCheck struct {
+	CheckId int
+	TableId int
+	ColumnId int
+	CheckName string
+	CheckTypeCls string
+	MinValue string
+	MaxValue string
+	Pattern string
+	AllowedValues string
+	Expression string
+	CreateUserId int
+	UpdateUserId int
+}
+
+
+func (d CreateCheck) ToCheck() model.Check {
+	var c model.Check
+
+	c.CheckId = d.CheckId
+	c.TableId = d.TableId
+	c.ColumnId = d.ColumnId
+	c.CheckName = d.CheckName
+	c.CheckTypeCls = d.CheckTypeCls
+	c.MinValue = d.MinValue
+	c.MaxValue = d.MaxValue
+	c.Pattern = d.Pattern
+	c.AllowedValues = d.AllowedValues
+	c.Expression = d.Expression
+	c.CreateUserId = d.CreateUserId
+	c.UpdateUserId = d.UpdateUserId
+
+	return c
+}
+
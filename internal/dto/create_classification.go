@@ -0,0 +1,32 @@
+package dto
+
+
+import (
+	"goat-cg/internal/model"
+)
+
+type CreateClassification struct {
+	ClassificationId int
+	ProjectId int
+	ClassificationName string
+	ClassificationNameLogical string
+	Remark string
+	Values []model.ClassificationValue
+	CreateUserId int
+	UpdateUserId int
+}
+
+
+func (d CreateClassification) ToClassification() model.Classification {
+	var c model.Classification
+
+	c.ClassificationId = d.ClassificationId
+	c.ProjectId = d.ProjectId
+	c.ClassificationName = d.ClassificationName
+	c.ClassificationNameLogical = d.ClassificationNameLogical
+	c.Remark = d.Remark
+	c.CreateUserId = d.CreateUserId
+	c.UpdateUserId = d.UpdateUserId
+
+	return c
+}
\ No newline at end of file
@@ -17,6 +17,9 @@ type CreateColumn struct {
 	NotNullFlg int
 	UniqueFlg int
 	DefaultValue string
+	EnumValues string
+	ClassificationId int
+	RefColumnId int
 	Remark string
 	AlignSeq int
 	CreateUserId int
@@ -39,6 +42,9 @@ func (d CreateColumn) ToColumn() model.Column {
 	c.NotNullFlg = d.NotNullFlg
 	c.UniqueFlg = d.UniqueFlg
 	c.DefaultValue = d.DefaultValue
+	c.EnumValues = d.EnumValues
+	c.ClassificationId = d.ClassificationId
+	c.RefColumnId = d.RefColumnId
 	c.Remark = d.Remark
 	c.AlignSeq = d.AlignSeq
 	c.DelFlg = d.DelFlg
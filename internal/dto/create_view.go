@@ -0,0 +1,33 @@
+package dto
+
+
+import (
+	"goat-cg/internal/model"
+)
+
+type CreateView struct {
+	ViewId int
+	ProjectId int
+	ViewName string
+	ViewNameLogical string
+	ViewSql string
+	TableIds []int
+	Columns string
+	CreateUserId int
+	UpdateUserId int
+}
+
+
+func (d CreateView) ToView() model.View {
+	var v model.View
+
+	v.ViewId = d.ViewId
+	v.ProjectId = d.ProjectId
+	v.ViewName = d.ViewName
+	v.ViewNameLogical = d.ViewNameLogical
+	v.ViewSql = d.ViewSql
+	v.CreateUserId = d.CreateUserId
+	v.UpdateUserId = d.UpdateUserId
+
+	return v
+}
\ No newline at end of file
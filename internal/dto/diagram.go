@@ -0,0 +1,38 @@
+package dto
+
+
+// Diagram tables and relationships drawn in ER diagrams.
+type Diagram struct {
+	Tables []DiagramTable
+	Relations []DiagramRelation
+}
+
+
+type DiagramTable struct {
+	TableName string
+	TableNameLogical string
+	Columns []DiagramColumn
+}
+
+
+type DiagramColumn struct {
+	ColumnName string
+	ColumnNameLogical string
+	DataType string
+	PrimaryKey bool
+	ForeignKey bool
+	Unique bool
+	NotNull bool
+}
+
+
+// DiagramRelation reference from a column of FromTable to a column of ToTable.
+// Unique: one-to-one, NotNull: reference is required.
+type DiagramRelation struct {
+	FromTable string
+	FromColumn string
+	ToTable string
+	ToColumn string
+	Unique bool
+	NotNull bool
+}
\ No newline at end of file
@@ -0,0 +1,36 @@
+package dto
+
+
+// Dictionary table definition document of a project.
+type Dictionary struct {
+	ProjectName string
+	Tables []DictionaryTable
+}
+
+
+type DictionaryTable struct {
+	TableName string
+	TableNameLogical string
+	UpdatedAt string
+	Columns []DictionaryColumn
+	// sample rows which match the definition.
+	Samples SampleRows
+}
+
+
+// DictionaryColumn a row of the table definition. 
+// (No is "-" for columns added by code generation)
+type DictionaryColumn struct {
+	No string
+	ColumnName string
+	ColumnNameLogical string
+	DataType string
+	Precision int
+	Scale int
+	PrimaryKey bool
+	NotNull bool
+	Unique bool
+	DefaultValue string
+	Remark string
+	UpdatedAt string
+}
\ No newline at end of file
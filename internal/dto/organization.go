@@ -0,0 +1,35 @@
+package dto
+
+
+// Organization organization of the user. OrgRole: ORG_ROLE_CLS of the user.
+type Organization struct {
+	OrgId int `json:"org_id"`
+	OrgName string `json:"org_name"`
+	OrgMemo string `json:"org_memo"`
+	OrgRole string `json:"org_role"`
+	RoleName string `json:"role_name"`
+	CreatedAt string `json:"created_at"`
+}
+
+
+type OrgMember struct {
+	OrgId int `json:"org_id"`
+	UserId int `json:"user_id"`
+	Username string `json:"username"`
+	Email string `json:"email"`
+	OrgRole string `json:"org_role"`
+	RoleName string `json:"role_name"`
+	CreatedAt string `json:"created_at"`
+}
+
+
+// TeamProject the role (ROLE_CLS) granted to the team on the project.
+type TeamProject struct {
+	TeamId int `json:"team_id"`
+	ProjectId int `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	Ownername string `json:"ownername"`
+	UserRole string `json:"user_role"`
+	RoleName string `json:"role_name"`
+	CreatedAt string `json:"created_at"`
+}
\ No newline at end of file
@@ -0,0 +1,13 @@
+package dto
+
+
+// ProjectColumn column with its table name. (choices of referenced column)
+type ProjectColumn struct {
+	ColumnId int
+	TableId int
+	TableName string
+	ColumnName string
+	DataTypeCls string
+	PrimaryKeyFlg int
+	UniqueFlg int
+}
\ No newline at end of file
@@ -2,12 +2,36 @@ package dto
 
 
 type ProjectMember struct {
-	ProjectId int
-	UserId int
-	Username string
-	Email string
-	UserStatus string
-	UserRole string
-	CreatedAt string
-	UpdatedAt string
+	ProjectId int `json:"project_id"`
+	UserId int `json:"user_id"`
+	Username string `json:"username"`
+	Email string `json:"email"`
+	UserStatus string `json:"user_status"`
+	StatusName string `json:"status_name"`
+	UserRole string `json:"user_role"`
+	RoleName string `json:"role_name"`
+	InvitedBy int `json:"invited_by"`
+	InvitedByName string `json:"invited_by_name"`
+	ExpiresAt string `json:"expires_at"`
+	Expired bool `json:"expired"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+
+// Invitation pending invitation. to a user (UserId) or to an email not signed up yet (InvitationId).
+type Invitation struct {
+	InvitationId int `json:"invitation_id,omitempty"`
+	ProjectId int `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	Ownername string `json:"ownername"`
+	UserId int `json:"user_id,omitempty"`
+	Email string `json:"email"`
+	UserRole string `json:"user_role"`
+	RoleName string `json:"role_name"`
+	InvitedBy int `json:"invited_by"`
+	InvitedByName string `json:"invited_by_name"`
+	ExpiresAt string `json:"expires_at"`
+	Expired bool `json:"expired"`
+	CreatedAt string `json:"created_at"`
 }
\ No newline at end of file
@@ -0,0 +1,15 @@
+package dto
+
+
+// SampleRows sample rows of a table. values are in the order of Columns. (NULL is "")
+type SampleRows struct {
+	Columns []string
+	Rows []SampleRowValues
+}
+
+
+type SampleRowValues struct {
+	Values []string
+	Stale bool
+	StaleReason string
+}
\ No newline at end of file
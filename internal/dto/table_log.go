@@ -2,15 +2,16 @@ package dto
 
 
 type TableLog struct {
-	TableId int
-	ProjectId int
-	TableName string
-	TableNameLogical string
-	DelFlg int
-	CreateUserId int
-	CreateUsername string
-	UpdateUserId int
-	UpdateUsername string
-	CreatedAt string
-	UpdatedAt string
+	TableId int `json:"table_id"`
+	ProjectId int `json:"project_id"`
+	TableName string `json:"table_name"`
+	TableNameLogical string `json:"table_name_logical"`
+	VersionFlg int `json:"version_flg"`
+	DelFlg int `json:"del_flg"`
+	CreateUserId int `json:"create_user_id"`
+	CreateUsername string `json:"create_username"`
+	UpdateUserId int `json:"update_user_id"`
+	UpdateUsername string `json:"update_username"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
 }
\ No newline at end of file
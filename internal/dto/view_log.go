@@ -0,0 +1,15 @@
+package dto
+
+
+type ViewLog struct {
+	ViewId int
+	ViewName string
+	ViewNameLogical string
+	ViewSql string
+	CreateUserId int
+	CreateUsername string
+	UpdateUserId int
+	UpdateUsername string
+	CreatedAt string
+	UpdatedAt string
+}
\ No newline at end of file
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"strings"
+	"github.com/gin-gonic/gin"
+
+	"goat-cg/internal/core/jwt"
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/model"
+	"goat-cg/internal/repository"
+	"goat-cg/internal/service"
+)
+
+
+// CONTEXT_KEY_ACCESS_TOKEN model.AccessToken of the request authenticated by a personal access token.
+const CONTEXT_KEY_ACCESS_TOKEN = "access_token"
+
+
+// ApiAuthMiddleware authenticate by "Authorization: Bearer goat_..." (personal access token)
+// or JWT of the header or the cookie. requests out of the scope of the token are forbidden.
+func ApiAuthMiddleware() gin.HandlerFunc {
+	jwtAuth := jwt.JwtAuthApiMiddleware()
+
+	return func(c *gin.Context) {
+		token := strings.TrimSpace(strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "))
+		if !strings.HasPrefix(token, service.ACCESS_TOKEN_PREFIX) {
+			jwtAuth(c)
+			return
+		}
+
+		t, err := service.NewAccessTokenService().Authenticate(token)
+		if err != nil {
+			c.JSON(401, gin.H{"error": "unauthorized.", "type": "unauthorized"})
+			c.Abort()
+			return
+		}
+		user, err := service.NewUserService().GetProfile(t.UserId)
+		if err != nil {
+			c.JSON(401, gin.H{"error": "unauthorized.", "type": "unauthorized"})
+			c.Abort()
+			return
+		}
+
+		if !accessTokenAllows(c, t) {
+			c.JSON(403, gin.H{"error": "out of the scope of the token.", "type": "forbidden"})
+			c.Abort()
+			return
+		}
+
+		c.Set(jwt.CONTEXT_KEY_PAYLOAD, jwt.JwtPayload{
+			CustomClaims: jwt.CustomClaims{UserId: user.UserId, Username: user.Username, Email: user.Email},
+		})
+		c.Set(CONTEXT_KEY_ACCESS_TOKEN, t)
+		c.Next()
+	}
+}
+
+
+// accessTokenAllows the request is in the scope of the token.
+// tokens are for /api/v1 only. (not for the account API)
+func accessTokenAllows(c *gin.Context, t model.AccessToken) bool {
+	path := c.FullPath()
+	if !strings.HasPrefix(path, "/api/v1/") {
+		return false
+	}
+
+	switch t.ScopeCls {
+	case constant.SCOPE_CLS_READ:
+		if c.Request.Method != "GET" {
+			return false
+		}
+	case constant.SCOPE_CLS_CODEGEN:
+		if path != "/api/v1/:username/:project_name/codegen/:generator" {
+			return false
+		}
+	case constant.SCOPE_CLS_WRITE:
+	default:
+		return false
+	}
+
+	if t.ProjectId != 0 {
+		if c.Param("project_name") == "" {
+			return false
+		}
+		p, err := repository.NewProjectRepository().GetOne(
+			&model.Project{Username: c.Param("username"), ProjectName: c.Param("project_name")},
+		)
+		if err != nil {
+			p, err = service.NewProjectService().GetRenamedProject(c.Param("username"), c.Param("project_name"))
+		}
+		if err != nil || p.ProjectId != t.ProjectId {
+			return false
+		}
+	}
+
+	return true
+}
\ No newline at end of file
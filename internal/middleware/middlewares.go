@@ -1,39 +1,117 @@
 package middleware
 
 import (
+	"fmt"
 	"errors"
 	"strconv"
+	"strings"
 	"github.com/gin-gonic/gin"
 
 	"goat-cg/internal/core/jwt"
 	"goat-cg/internal/model"
 	"goat-cg/internal/repository"
 	"goat-cg/internal/query"
+	"goat-cg/internal/service"
 )
 
 
+// PathParameterValidationMiddleware set project, table ... of the path parameters to the context.
+// users not members of the project are led to the page to request to join it.
 func PathParameterValidationMiddleware() gin.HandlerFunc {
+	return pathParameterValidation(func(c *gin.Context) {
+		if _, ok := c.Get("project"); !ok && c.Request.Method == "GET" {
+			pr := repository.NewProjectRepository()
+			p, err := pr.GetOne(&model.Project{Username: c.Param("username"), ProjectName: c.Param("project_name")})
+			if err == nil {
+				c.Redirect(303, fmt.Sprintf("/%s/%s/join", p.Username, p.ProjectName))
+				return
+			}
+		}
+		c.HTML(404, "404error.html", gin.H{})
+	})
+}
+
+
+// ApiPathParameterValidationMiddleware PathParameterValidationMiddleware responding JSON.
+func ApiPathParameterValidationMiddleware() gin.HandlerFunc {
+	return pathParameterValidation(func(c *gin.Context) {
+		c.JSON(404, gin.H{"error": "not found.", "type": "not_found"})
+	})
+}
+
+
+// pathParameterValidation set project, table ... of the path parameters to the context.
+// notFound: response if they are not found or not accessible.
+func pathParameterValidation(notFound func(c *gin.Context)) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		project, err := validateProjectNameAndGetProject(c)
 		if err != nil {
-			c.HTML(404, "404error.html", gin.H{})
+			if !redirectRenamedProject(c) {
+				notFound(c)
+			}
 			c.Abort()
 			return
 		} 
 
+		role, err := service.NewMemberService().GetRole(project, jwt.GetUserId(c))
+		if err != nil {
+			notFound(c)
+			c.Abort()
+			return
+		}
+
 		c.Set("project", project)
+		c.Set(CONTEXT_KEY_ROLE, role)
+
+		if c.Param("classification_id") != "" {
+			classificationId, err := strconv.Atoi(c.Param("classification_id"))
+			if err != nil {
+				notFound(c)
+				c.Abort()
+				return
+			}
+
+			classification, err := validateClassificationIdAndGetClassification(
+				project.ProjectId, classificationId,
+			)
+			if err != nil {
+				notFound(c)
+				c.Abort()
+				return
+			}
+
+			c.Set("classification", classification)
+		}
+
+		if c.Param("view_id") != "" {
+			viewId, err := strconv.Atoi(c.Param("view_id"))
+			if err != nil {
+				notFound(c)
+				c.Abort()
+				return
+			}
+
+			view, err := validateViewIdAndGetView(project.ProjectId, viewId)
+			if err != nil {
+				notFound(c)
+				c.Abort()
+				return
+			}
+
+			c.Set("view", view)
+		}
 
 		if c.Param("table_id") != "" {
 			tableId, err := strconv.Atoi(c.Param("table_id"))
 			if err != nil {
-				c.HTML(404, "404error.html", gin.H{})
+				notFound(c)
 				c.Abort()
 				return
 			}
 
 			table, err := validateTableIdAndGetTable(project.ProjectId, tableId)
 			if err != nil {
-				c.HTML(404, "404error.html", gin.H{})
+				notFound(c)
 				c.Abort()
 				return
 			}
@@ -43,26 +121,73 @@ func PathParameterValidationMiddleware() gin.HandlerFunc {
 			if c.Param("column_id") != "" {
 				columnId, err := strconv.Atoi(c.Param("column_id"))
 				if err != nil {
-					c.HTML(404, "404error.html", gin.H{})
+					notFound(c)
 					c.Abort()
 					return
 				}
 	
 				column, err := validateColumnIdAndGetColumn(table.TableId, columnId)
 				if err != nil {
-					c.HTML(404, "404error.html", gin.H{})
+					notFound(c)
 					c.Abort()
 					return
 				}
 	
 				c.Set("column", column)
 			}
+
+			if c.Param("check_id") != "" {
+				checkId, err := strconv.Atoi(c.Param("check_id"))
+				if err != nil {
+					notFound(c)
+					c.Abort()
+					return
+				}
+	
+				check, err := validateCheckIdAndGetCheck(table.TableId, checkId)
+				if err != nil {
+					notFound(c)
+					c.Abort()
+					return
+				}
+	
+				c.Set("check", check)
+			}
 		}
 
 		c.Next()
 	}
 }
 
+// redirectRenamedProject redirect the URLs of the project renamed or transferred to the current ones.
+// only for users accessible to the project. the redirects are temporary (not cached by browsers)
+// since the old name can be taken by a new project.
+func redirectRenamedProject(c *gin.Context) bool {
+	ownername := c.Param("username")
+	projectName := c.Param("project_name")
+
+	p, err := service.NewProjectService().GetRenamedProject(ownername, projectName)
+	if err != nil {
+		return false
+	}
+	if _, err = service.NewMemberService().GetRole(p, jwt.GetUserId(c)); err != nil {
+		return false
+	}
+
+	url := *c.Request.URL
+	url.Path = strings.Replace(
+		url.Path, "/" + ownername + "/" + projectName, "/" + p.Username + "/" + p.ProjectName, 1,
+	)
+	url.RawPath = ""
+
+	if c.Request.Method == "GET" {
+		c.Redirect(302, url.RequestURI())
+	} else {
+		c.Redirect(307, url.RequestURI())
+	}
+	return true
+}
+
 func validateProjectNameAndGetProject (c *gin.Context) (model.Project, error) {
 	userId := jwt.GetUserId(c)
 	username := jwt.GetUsername(c)
@@ -80,6 +205,15 @@ func validateProjectNameAndGetProject (c *gin.Context) (model.Project, error) {
 		p, err = pq.GetMemberProject(userId, ownername, projectName)
 	}
 
+	// projects of organizations. (the role of the user is checked by GetRole)
+	if err != nil && ownername != "" && projectName != "" {
+		pr := repository.NewProjectRepository()
+		p, err = pr.GetOne(&model.Project{Username: ownername, ProjectName: projectName})
+		if err == nil && p.OrgId == 0 {
+			err = errors.New("validateProjectNameAndGetProject")
+		}
+	}
+
 	if err != nil {
 		return p, errors.New("validateProjectNameAndGetProject")
 	}
@@ -105,4 +239,34 @@ func validateColumnIdAndGetColumn (tableId, columnId int) (model.Column, error)
 		return c, errors.New("validateColumnIdAndGetColumn")
 	}
 	return c, nil
+}
+
+func validateCheckIdAndGetCheck (tableId, checkId int) (model.Check, error) {
+	cr := repository.NewCheckRepository()
+	c, err := cr.GetOne(&model.Check{CheckId: checkId})
+	
+	if err != nil || c.TableId != tableId {
+		return c, errors.New("validateCheckIdAndGetCheck")
+	}
+	return c, nil
+}
+
+func validateClassificationIdAndGetClassification (projectId, classificationId int) (model.Classification, error) {
+	cr := repository.NewClassificationRepository()
+	c, err := cr.GetOne(&model.Classification{ClassificationId: classificationId})
+	
+	if err != nil || c.ProjectId != projectId {
+		return c, errors.New("validateClassificationIdAndGetClassification")
+	}
+	return c, nil
+}
+
+func validateViewIdAndGetView (projectId, viewId int) (model.View, error) {
+	vr := repository.NewViewRepository()
+	v, err := vr.GetOne(&model.View{ViewId: viewId})
+	
+	if err != nil || v.ProjectId != projectId {
+		return v, errors.New("validateViewIdAndGetView")
+	}
+	return v, nil
 }
\ No newline at end of file
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"goat-cg/internal/core/jwt"
+	"goat-cg/internal/service"
+)
+
+
+// CONTEXT_KEY_ORGANIZATION model.Organization of the path. (set by OrgMiddleware)
+const CONTEXT_KEY_ORGANIZATION = "organization"
+
+// CONTEXT_KEY_ORG_ROLE ORG_ROLE_CLS of the user in the organization of the path. (set by OrgMiddleware)
+const CONTEXT_KEY_ORG_ROLE = "org_role"
+
+
+// OrgMiddleware set the organization of the path (:username) and the role of the user to the context.
+// not found unless the user is a member.
+func OrgMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		os := service.NewOrganizationService()
+		org, err := os.GetOrganization(c.Param("username"))
+		if err != nil {
+			c.HTML(404, "404error.html", gin.H{})
+			c.Abort()
+			return
+		}
+
+		role, err := os.GetOrgRole(org.OrgId, jwt.GetUserId(c))
+		if err != nil {
+			c.HTML(404, "404error.html", gin.H{})
+			c.Abort()
+			return
+		}
+
+		c.Set(CONTEXT_KEY_ORGANIZATION, org)
+		c.Set(CONTEXT_KEY_ORG_ROLE, role)
+		c.Next()
+	}
+}
+
+
+// OrgRoleMiddleware forbid the request unless the user has the role in the organization.
+// use after OrgMiddleware.
+func OrgRoleMiddleware(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString(CONTEXT_KEY_ORG_ROLE) != role {
+			if c.Request.Method == "GET" || c.Request.Method == "POST" {
+				c.HTML(403, "403error.html", gin.H{})
+			} else {
+				c.JSON(403, gin.H{"error": "permission denied."})
+			}
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
\ No newline at end of file
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/shared/roles"
+)
+
+
+// CONTEXT_KEY_ROLE ROLE_CLS of the user in the project of the path. (set by PathParameterValidationMiddleware)
+const CONTEXT_KEY_ROLE = "role"
+
+
+// RoleMiddleware forbid the request unless the user has the role in the project.
+// use after PathParameterValidationMiddleware.
+// viewer: read and codegen, editor (ROLE_CLS_NOMAL): edit the definitions,
+// admin: manage the members, owner: update and delete the project.
+func RoleMiddleware(role string) gin.HandlerFunc {
+	return roleRequired(role, func(c *gin.Context) {
+		if c.Request.Method == "GET" || c.Request.Method == "POST" {
+			c.HTML(403, "403error.html", gin.H{})
+		} else {
+			c.JSON(403, gin.H{"error": "permission denied."})
+		}
+	})
+}
+
+
+// ApiRoleMiddleware RoleMiddleware responding JSON.
+// use after ApiPathParameterValidationMiddleware.
+func ApiRoleMiddleware(role string) gin.HandlerFunc {
+	return roleRequired(role, func(c *gin.Context) {
+		c.JSON(403, gin.H{
+			"error": "the role " + constant.ROLE_CLS_NAME[role] + " is required.",
+			"type": "forbidden",
+		})
+	})
+}
+
+
+func roleRequired(role string, forbidden func(c *gin.Context)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !roles.Has(c.GetString(CONTEXT_KEY_ROLE), role) {
+			forbidden(c)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
\ No newline at end of file
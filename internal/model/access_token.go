@@ -0,0 +1,18 @@
+package model
+
+
+// AccessToken personal access token of the API. (only the hash of the token is stored)
+// ProjectId: 0 for all projects of the user.
+type AccessToken struct {
+	TokenId int `db:"token_id" json:"token_id"`
+	UserId int `db:"user_id" json:"user_id"`
+	TokenName string `db:"token_name" json:"token_name"`
+	TokenHash string `db:"token_hash" json:"-"`
+	TokenPrefix string `db:"token_prefix" json:"token_prefix"`
+	ScopeCls string `db:"scope_cls" json:"scope_cls"`
+	ProjectId int `db:"project_id" json:"project_id"`
+	ExpiresAt string `db:"expires_at" json:"expires_at"`
+	LastUsedAt string `db:"last_used_at" json:"last_used_at"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+	UpdatedAt string `db:"updated_at" json:"updated_at"`
+}
\ No newline at end of file
@@ -0,0 +1,19 @@
+package model
+
+
+type Check struct {
+	CheckId int `db:"check_id" json:"check_id"`
+	TableId int `db:"table_id" json:"table_id"`
+	ColumnId int `db:"column_id" json:"column_id"`
+	CheckName string `db:"check_name" json:"check_name"`
+	CheckTypeCls string `db:"check_type_cls" json:"check_type_cls"`
+	MinValue string `db:"min_value" json:"min_value"`
+	MaxValue string `db:"max_value" json:"max_value"`
+	Pattern string `db:"pattern" json:"pattern"`
+	AllowedValues string `db:"allowed_values" json:"allowed_values"`
+	Expression string `db:"expression" json:"expression"`
+	CreateUserId int `db:"create_user_id" json:"create_user_id"`
+	UpdateUserId int `db:"update_user_id" json:"update_user_id"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+	UpdatedAt string `db:"updated_at" json:"updated_at"`
+}
\ No newline at end of file
@@ -0,0 +1,25 @@
+package model
+
+
+type Classification struct {
+	ClassificationId int `db:"classification_id" json:"classification_id"`
+	ProjectId int `db:"project_id" json:"project_id"`
+	ClassificationName string `db:"classification_name" json:"classification_name"`
+	ClassificationNameLogical string `db:"classification_name_logical" json:"classification_name_logical"`
+	Remark string `db:"remark" json:"remark"`
+	CreateUserId int `db:"create_user_id" json:"create_user_id"`
+	UpdateUserId int `db:"update_user_id" json:"update_user_id"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+	UpdatedAt string `db:"updated_at" json:"updated_at"`
+}
+
+
+type ClassificationValue struct {
+	ClassificationId int `db:"classification_id" json:"classification_id"`
+	Code string `db:"code" json:"code"`
+	ValueName string `db:"value_name" json:"value_name"`
+	Label string `db:"label" json:"label"`
+	AlignSeq int `db:"align_seq" json:"align_seq"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+	UpdatedAt string `db:"updated_at" json:"updated_at"`
+}
\ No newline at end of file
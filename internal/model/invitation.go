@@ -0,0 +1,15 @@
+package model
+
+
+// Invitation invitation to the email of a user not signed up yet.
+// claimed at the signup. (becomes Member of STATE_CLS_REQUEST)
+type Invitation struct {
+	InvitationId int `db:"invitation_id" json:"invitation_id"`
+	ProjectId int `db:"project_id" json:"project_id"`
+	Email string `db:"email" json:"email"`
+	UserRole string `db:"user_role" json:"user_role"`
+	InvitedBy int `db:"invited_by" json:"invited_by"`
+	ExpiresAt string `db:"expires_at" json:"expires_at"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+	UpdatedAt string `db:"updated_at" json:"updated_at"`
+}
\ No newline at end of file
@@ -1,11 +1,14 @@
 package model
 
 
+// Member UserStatus: STATE_CLS. InvitedBy, ExpiresAt: of the invitation (STATE_CLS_REQUEST).
 type Member struct {
 	ProjectId int `db:"project_id" json:"project_id"`
 	UserId int `db:"user_id" json:"user_id"`
 	UserStatus string `db:"user_status" json:"user_status"`
 	UserRole string `db:"user_role" json:"user_role"`
-	CreatedAt string `db:"created_at " json:"created_at "`
-	UpdatedAt string `db:"created_at " json:"created_at "`
+	InvitedBy int `db:"invited_by" json:"invited_by"`
+	ExpiresAt string `db:"expires_at" json:"expires_at"`
+	CreatedAt string `db:"created_at " json:"created_at"`
+	UpdatedAt string `db:"updated_at" json:"updated_at"`
 }
\ No newline at end of file
@@ -0,0 +1,20 @@
+package model
+
+
+// Organization account owning projects. (PROJECT.ORG_ID, PROJECT.USERNAME is OrgName)
+type Organization struct {
+	OrgId int `db:"org_id" json:"org_id"`
+	OrgName string `db:"org_name" json:"org_name"`
+	OrgMemo string `db:"org_memo" json:"org_memo"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+	UpdatedAt string `db:"updated_at" json:"updated_at"`
+}
+
+
+type OrgMember struct {
+	OrgId int `db:"org_id" json:"org_id"`
+	UserId int `db:"user_id" json:"user_id"`
+	OrgRole string `db:"org_role" json:"org_role"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+	UpdatedAt string `db:"updated_at" json:"updated_at"`
+}
\ No newline at end of file
@@ -7,6 +7,7 @@ type Project struct {
 	ProjectMemo string `db:"project_memo" json:"project_memo"`
 	UserId int `db:"user_id" json:"user_id"`
 	Username string `db:"username" json:"username"`
-	CreatedAt string `db:"created_at " json:"created_at "`
+	OrgId int `db:"org_id" json:"org_id"`
+	CreatedAt string `db:"created_at " json:"created_at"`
 	UpdatedAt string `db:"updated_at" json:"updated_at"`
 }
\ No newline at end of file
@@ -0,0 +1,10 @@
+package model
+
+
+// ProjectRedirect former "username/project_name" of the project. (renamed or transferred)
+type ProjectRedirect struct {
+	Username string `db:"username" json:"username"`
+	ProjectName string `db:"project_name" json:"project_name"`
+	ProjectId int `db:"project_id" json:"project_id"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+}
\ No newline at end of file
@@ -0,0 +1,13 @@
+package model
+
+
+// ProtoField field number of a column in the generated protobuf message.
+// ColumnId is 0 for version, created_at and updated_at. (identified by FieldName)
+type ProtoField struct {
+	TableId int `db:"table_id" json:"table_id"`
+	FieldNo int `db:"field_no" json:"field_no"`
+	ColumnId int `db:"column_id" json:"column_id"`
+	FieldName string `db:"field_name" json:"field_name"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+	UpdatedAt string `db:"updated_at" json:"updated_at"`
+}
\ No newline at end of file
@@ -0,0 +1,15 @@
+package model
+
+
+// SampleRow row attached to a table as seed data.
+// RowData is a JSON object of column_name and value. (null for NULL)
+// StaleFlg is on when the row does not match the current definition of the table. (StaleReason)
+type SampleRow struct {
+	TableId int `db:"table_id" json:"table_id"`
+	RowNo int `db:"row_no" json:"row_no"`
+	RowData string `db:"row_data" json:"row_data"`
+	StaleFlg int `db:"stale_flg" json:"stale_flg"`
+	StaleReason string `db:"stale_reason" json:"stale_reason"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+	UpdatedAt string `db:"updated_at" json:"updated_at"`
+}
\ No newline at end of file
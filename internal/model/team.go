@@ -0,0 +1,29 @@
+package model
+
+
+// Team users of the organization granted roles on its projects at once.
+type Team struct {
+	TeamId int `db:"team_id" json:"team_id"`
+	OrgId int `db:"org_id" json:"org_id"`
+	TeamName string `db:"team_name" json:"team_name"`
+	TeamMemo string `db:"team_memo" json:"team_memo"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+	UpdatedAt string `db:"updated_at" json:"updated_at"`
+}
+
+
+type TeamMember struct {
+	TeamId int `db:"team_id" json:"team_id"`
+	UserId int `db:"user_id" json:"user_id"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+}
+
+
+// TeamProject the role (ROLE_CLS) the members of the team have in the project.
+type TeamProject struct {
+	TeamId int `db:"team_id" json:"team_id"`
+	ProjectId int `db:"project_id" json:"project_id"`
+	UserRole string `db:"user_role" json:"user_role"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+	UpdatedAt string `db:"updated_at" json:"updated_at"`
+}
\ No newline at end of file
@@ -6,6 +6,6 @@ type User struct {
 	Username string `db:"username" json:"username"`
 	Password string `db:"password" json:"password"`
 	Email string `db:"email" json:"email"`
-	CreatedAt string `db:"created_at " json:"created_at "`
+	CreatedAt string `db:"created_at " json:"created_at"`
 	UpdatedAt string `db:"updated_at" json:"updated_at"`
 }
\ No newline at end of file
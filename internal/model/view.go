@@ -0,0 +1,30 @@
+package model
+
+
+type View struct {
+	ViewId int `db:"view_id" json:"view_id"`
+	ProjectId int `db:"project_id" json:"project_id"`
+	ViewName string `db:"view_name" json:"view_name"`
+	ViewNameLogical string `db:"view_name_logical" json:"view_name_logical"`
+	ViewSql string `db:"view_sql" json:"view_sql"`
+	CreateUserId int `db:"create_user_id" json:"create_user_id"`
+	UpdateUserId int `db:"update_user_id" json:"update_user_id"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+	UpdatedAt string `db:"updated_at" json:"updated_at"`
+}
+
+
+type ViewTable struct {
+	ViewId int `db:"view_id" json:"view_id"`
+	TableId int `db:"table_id" json:"table_id"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+}
+
+
+type ViewColumn struct {
+	ViewId int `db:"view_id" json:"view_id"`
+	ColumnName string `db:"column_name" json:"column_name"`
+	DataTypeCls string `db:"data_type_cls" json:"data_type_cls"`
+	AlignSeq int `db:"align_seq" json:"align_seq"`
+	CreatedAt string `db:"created_at" json:"created_at"`
+}
\ No newline at end of file
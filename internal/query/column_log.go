@@ -10,6 +10,7 @@ import (
 
 type ColumnQuery interface {
 	GetColumnLog(id int) ([]dto.ColumnLog, error)
+	GetProjectColumns(projectId int) ([]dto.ProjectColumn, error)
 }
 
 
@@ -38,6 +39,9 @@ func (que *columnQuery)GetColumnLog(id int) ([]dto.ColumnLog, error){
 			cl.not_null_flg,
 			cl.unique_flg,
 			cl.default_value,
+			cl.enum_values,
+			cl.classification_id,
+			cl.ref_column_id,
 			cl.remark,
 			cl.align_seq,
 			cl.del_flg,
@@ -77,6 +81,9 @@ func (que *columnQuery)GetColumnLog(id int) ([]dto.ColumnLog, error){
 			&x.NotNullFlg,
 			&x.UniqueFlg,
 			&x.DefaultValue,
+			&x.EnumValues,
+			&x.ClassificationId,
+			&x.RefColumnId,
 			&x.Remark,
 			&x.AlignSeq,
 			&x.DelFlg,
@@ -93,5 +100,54 @@ func (que *columnQuery)GetColumnLog(id int) ([]dto.ColumnLog, error){
 		ret = append(ret, x)
 	}
 
+	return ret, nil
+}
+
+
+// GetProjectColumns get columns of all tables in the project. (ordered by table, align_seq)
+func (que *columnQuery)GetProjectColumns(projectId int) ([]dto.ProjectColumn, error){
+	rows, err := que.db.Query(
+		`SELECT 
+			c.column_id,
+			c.table_id,
+			t.table_name,
+			c.column_name,
+			c.data_type_cls,
+			c.primary_key_flg,
+			c.unique_flg
+		 FROM
+			 column_def c
+			 INNER JOIN table_def t ON c.table_id = t.table_id
+		 WHERE 
+			 t.project_id = ?
+			 AND c.del_flg = 0
+			 AND t.del_flg = 0
+		 ORDER BY t.table_name, c.align_seq`,
+		 projectId,
+	)
+	defer rows.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []dto.ProjectColumn{}
+	for rows.Next() {
+		x := dto.ProjectColumn{}
+		err = rows.Scan(
+			&x.ColumnId,
+			&x.TableId,
+			&x.TableName,
+			&x.ColumnName,
+			&x.DataTypeCls,
+			&x.PrimaryKeyFlg,
+			&x.UniqueFlg,
+		)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, x)
+	}
+
 	return ret, nil
 }
\ No newline at end of file
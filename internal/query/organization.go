@@ -0,0 +1,222 @@
+package query
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/dto"
+	"goat-cg/internal/core/db"
+)
+
+
+type OrganizationQuery interface {
+	GetUserOrganizations(userId int) ([]dto.Organization, error)
+	GetOrgMembers(orgId int) ([]dto.OrgMember, error)
+	GetTeamMembers(teamId int) ([]dto.OrgMember, error)
+	GetTeamProjects(teamId int) ([]dto.TeamProject, error)
+	GetTeamRoles(projectId, userId int) ([]string, error)
+}
+
+
+type organizationQuery struct {
+	db *sql.DB
+}
+
+
+func NewOrganizationQuery() OrganizationQuery {
+	db := db.GetDB()
+	return &organizationQuery{db}
+}
+
+
+// GetUserOrganizations organizations the user is a member of.
+func (que *organizationQuery) GetUserOrganizations(userId int) ([]dto.Organization, error) {
+	rows, err := que.db.Query(
+		`SELECT
+			o.org_id,
+			o.org_name,
+			o.org_memo,
+			om.org_role,
+			o.created_at
+		 FROM 
+			 organization o
+			 INNER JOIN org_member om ON om.org_id = o.org_id
+		 WHERE om.user_id = ?
+		 ORDER BY o.org_name`,
+		 userId,
+	)
+	defer rows.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []dto.Organization{}
+	for rows.Next() {
+		x := dto.Organization{}
+		err = rows.Scan(
+			&x.OrgId,
+			&x.OrgName,
+			&x.OrgMemo,
+			&x.OrgRole,
+			&x.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, x)
+	}
+
+	return ret, nil
+}
+
+
+func (que *organizationQuery) GetOrgMembers(orgId int) ([]dto.OrgMember, error) {
+	rows, err := que.db.Query(
+		`SELECT
+			om.org_id,
+			om.user_id,
+			u.username,
+			u.email,
+			om.org_role,
+			om.created_at
+		 FROM 
+			 org_member om
+			 INNER JOIN users u ON u.user_id = om.user_id
+		 WHERE om.org_id = ?
+		 ORDER BY u.username`,
+		 orgId,
+	)
+	defer rows.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scanOrgMembers(rows)
+}
+
+
+// GetTeamMembers members of the team with their roles in the organization.
+func (que *organizationQuery) GetTeamMembers(teamId int) ([]dto.OrgMember, error) {
+	rows, err := que.db.Query(
+		`SELECT
+			t.org_id,
+			tm.user_id,
+			u.username,
+			u.email,
+			IFNULL(om.org_role, ''),
+			tm.created_at
+		 FROM 
+			 team_member tm
+			 INNER JOIN team t ON t.team_id = tm.team_id
+			 INNER JOIN users u ON u.user_id = tm.user_id
+			 LEFT JOIN org_member om ON om.org_id = t.org_id AND om.user_id = tm.user_id
+		 WHERE tm.team_id = ?
+		 ORDER BY u.username`,
+		 teamId,
+	)
+	defer rows.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scanOrgMembers(rows)
+}
+
+
+func scanOrgMembers(rows *sql.Rows) ([]dto.OrgMember, error) {
+	ret := []dto.OrgMember{}
+	for rows.Next() {
+		x := dto.OrgMember{}
+		err := rows.Scan(
+			&x.OrgId,
+			&x.UserId,
+			&x.Username,
+			&x.Email,
+			&x.OrgRole,
+			&x.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, x)
+	}
+
+	return ret, nil
+}
+
+
+func (que *organizationQuery) GetTeamProjects(teamId int) ([]dto.TeamProject, error) {
+	rows, err := que.db.Query(
+		`SELECT
+			tp.team_id,
+			tp.project_id,
+			p.project_name,
+			p.username,
+			tp.user_role,
+			tp.created_at
+		 FROM 
+			 team_project tp
+			 INNER JOIN project p ON p.project_id = tp.project_id
+		 WHERE tp.team_id = ?
+		 ORDER BY p.project_name`,
+		 teamId,
+	)
+	defer rows.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []dto.TeamProject{}
+	for rows.Next() {
+		x := dto.TeamProject{}
+		err = rows.Scan(
+			&x.TeamId,
+			&x.ProjectId,
+			&x.ProjectName,
+			&x.Ownername,
+			&x.UserRole,
+			&x.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, x)
+	}
+
+	return ret, nil
+}
+
+
+// GetTeamRoles roles (ROLE_CLS) granted to the user on the project through the teams.
+func (que *organizationQuery) GetTeamRoles(projectId, userId int) ([]string, error) {
+	rows, err := que.db.Query(
+		`SELECT
+			tp.user_role
+		 FROM 
+			 team_project tp
+			 INNER JOIN team_member tm ON tm.team_id = tp.team_id
+		 WHERE tp.project_id = ?
+		 AND tm.user_id = ?`,
+		 projectId,
+		 userId,
+	)
+	defer rows.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []string{}
+	for rows.Next() {
+		var role string
+		if err = rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		ret = append(ret, role)
+	}
+
+	return ret, nil
+}
\ No newline at end of file
@@ -3,6 +3,7 @@ package query
 import (
 	"database/sql"
 
+	"goat-cg/internal/shared/constant"
 	"goat-cg/internal/model"
 	"goat-cg/internal/core/db"
 )
@@ -33,6 +34,7 @@ func (que *projectQuery) GetMemberProjects(userId int) ([]model.Project, error)
 			p.project_memo,
 			p.user_id,
 			p.username,
+			p.org_id,
 			p.created_at,
 			p.updated_at 
 		 FROM 
@@ -40,8 +42,10 @@ func (que *projectQuery) GetMemberProjects(userId int) ([]model.Project, error)
 			 project_member pm
 		 WHERE 
 			 p.project_id = pm.project_id
-		 AND pm.user_id = ?`, 
+		 AND pm.user_id = ?
+		 AND pm.user_status = ?`, 
 		 userId,
+		 constant.STATE_CLS_NOMAL,
 	)
 	defer rows.Close()
 
@@ -58,6 +62,7 @@ func (que *projectQuery) GetMemberProjects(userId int) ([]model.Project, error)
 			&p.ProjectMemo, 
 			&p.UserId,
 			&p.Username,
+			&p.OrgId,
 			&p.CreatedAt,
 			&p.UpdatedAt,
 		)
@@ -80,6 +85,7 @@ func (que *projectQuery) GetMemberProject(userId int, ownername, projectName str
 			p.project_memo,
 			p.user_id,
 			p.username,
+			p.org_id,
 			p.created_at,
 			p.updated_at 
 		 FROM 
@@ -88,9 +94,11 @@ func (que *projectQuery) GetMemberProject(userId int, ownername, projectName str
 		 WHERE 
 			 p.project_id = pm.project_id
 		 AND pm.user_id = ?
+		 AND pm.user_status = ?
 		 AND p.username = ? 
 		 AND p.project_name = ?`, 
 		 userId,
+		 constant.STATE_CLS_NOMAL,
 		 ownername,
 		 projectName,
 	).Scan(
@@ -99,6 +107,7 @@ func (que *projectQuery) GetMemberProject(userId int, ownername, projectName str
 		&ret.ProjectMemo,
 		&ret.UserId,
 		&ret.Username,
+		&ret.OrgId,
 		&ret.CreatedAt,
 		&ret.UpdatedAt,
 	)
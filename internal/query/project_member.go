@@ -3,6 +3,7 @@ package query
 import (
 	"database/sql"
 
+	"goat-cg/internal/shared/constant"
 	"goat-cg/internal/dto"
 	"goat-cg/internal/core/db"
 )
@@ -11,6 +12,8 @@ import (
 type ProjectMemberQuery interface {
 	GetProjectMembers(projectId int) ([]dto.ProjectMember, error)
 	GetProjectMember(projectId, userId int) (dto.ProjectMember, error)
+	GetUserInvitations(userId int) ([]dto.Invitation, error)
+	GetEmailInvitations(projectId int) ([]dto.Invitation, error)
 }
 
 
@@ -34,13 +37,16 @@ func (que *projectMemberQuery)GetProjectMembers(projectId int) ([]dto.ProjectMem
 			u.email,
 			pm.user_status,
 			pm.user_role,
+			pm.invited_by,
+			IFNULL(iu.username, ''),
+			pm.expires_at,
 			pm.created_at,
 			pm.updated_at
 		 FROM 
-			 project_member pm,
-			 users u
-		 WHERE pm.project_id = ?
-		  AND u.user_id = pm.user_id`, 
+			 project_member pm
+			 INNER JOIN users u ON u.user_id = pm.user_id
+			 LEFT JOIN users iu ON iu.user_id = pm.invited_by
+		 WHERE pm.project_id = ?`, 
 		 projectId,
 	)
 	defer rows.Close()
@@ -59,6 +65,9 @@ func (que *projectMemberQuery)GetProjectMembers(projectId int) ([]dto.ProjectMem
 			&x.Email,
 			&x.UserStatus,
 			&x.UserRole,
+			&x.InvitedBy,
+			&x.InvitedByName,
+			&x.ExpiresAt,
 			&x.CreatedAt,
 			&x.UpdatedAt,
 		)
@@ -82,13 +91,16 @@ func (que *projectMemberQuery)GetProjectMember(projectId, userId int) (dto.Proje
 			u.email,
 			pm.user_status,
 			pm.user_role,
+			pm.invited_by,
+			IFNULL(iu.username, ''),
+			pm.expires_at,
 			pm.created_at,
 			pm.updated_at
 		 FROM 
-			 project_member pm,
-			 users u
+			 project_member pm
+			 INNER JOIN users u ON u.user_id = pm.user_id
+			 LEFT JOIN users iu ON iu.user_id = pm.invited_by
 		 WHERE pm.project_id = ?
-		  AND u.user_id = pm.user_id
 		  AND u.user_id = ? `,
 		 projectId,
 		 userId,
@@ -99,9 +111,121 @@ func (que *projectMemberQuery)GetProjectMember(projectId, userId int) (dto.Proje
 		&ret.Email,
 		&ret.UserStatus,
 		&ret.UserRole,
+		&ret.InvitedBy,
+		&ret.InvitedByName,
+		&ret.ExpiresAt,
 		&ret.CreatedAt,
 		&ret.UpdatedAt,
 	)
 
 	return ret, err
+}
+
+
+// GetUserInvitations invitations to the user (project_member of STATE_CLS_REQUEST).
+func (que *projectMemberQuery)GetUserInvitations(userId int) ([]dto.Invitation, error) {
+	rows, err := que.db.Query(
+		`SELECT
+			p.project_id,
+			p.project_name,
+			p.username,
+			pm.user_id,
+			u.email,
+			pm.user_role,
+			pm.invited_by,
+			IFNULL(iu.username, ''),
+			pm.expires_at,
+			pm.created_at
+		 FROM 
+			 project_member pm
+			 INNER JOIN project p ON p.project_id = pm.project_id
+			 INNER JOIN users u ON u.user_id = pm.user_id
+			 LEFT JOIN users iu ON iu.user_id = pm.invited_by
+		 WHERE pm.user_id = ?
+		  AND pm.user_status = ?
+		 ORDER BY pm.created_at DESC`, 
+		 userId,
+		 constant.STATE_CLS_REQUEST,
+	)
+	defer rows.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []dto.Invitation{}
+	for rows.Next() {
+		x := dto.Invitation{}
+		err = rows.Scan(
+			&x.ProjectId,
+			&x.ProjectName,
+			&x.Ownername,
+			&x.UserId,
+			&x.Email,
+			&x.UserRole,
+			&x.InvitedBy,
+			&x.InvitedByName,
+			&x.ExpiresAt,
+			&x.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, x)
+	}
+
+	return ret, nil
+}
+
+
+// GetEmailInvitations invitations of the project to the emails not signed up yet.
+func (que *projectMemberQuery)GetEmailInvitations(projectId int) ([]dto.Invitation, error) {
+	rows, err := que.db.Query(
+		`SELECT
+			i.invitation_id,
+			p.project_id,
+			p.project_name,
+			p.username,
+			i.email,
+			i.user_role,
+			i.invited_by,
+			IFNULL(iu.username, ''),
+			i.expires_at,
+			i.created_at
+		 FROM 
+			 project_invitation i
+			 INNER JOIN project p ON p.project_id = i.project_id
+			 LEFT JOIN users iu ON iu.user_id = i.invited_by
+		 WHERE i.project_id = ?
+		 ORDER BY i.invitation_id`, 
+		 projectId,
+	)
+	defer rows.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []dto.Invitation{}
+	for rows.Next() {
+		x := dto.Invitation{}
+		err = rows.Scan(
+			&x.InvitationId,
+			&x.ProjectId,
+			&x.ProjectName,
+			&x.Ownername,
+			&x.Email,
+			&x.UserRole,
+			&x.InvitedBy,
+			&x.InvitedByName,
+			&x.ExpiresAt,
+			&x.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, x)
+	}
+
+	return ret, nil
 }
\ No newline at end of file
@@ -30,6 +30,7 @@ func (que *tableQuery)GetTableLog(id int) ([]dto.TableLog, error){
 			tl.table_id,
 			tl.table_name,
 			tl.table_name_logical,
+			tl.version_flg,
 			tl.del_flg,
 			tl.create_user_id,
 			u1.username create_username,
@@ -59,6 +60,7 @@ func (que *tableQuery)GetTableLog(id int) ([]dto.TableLog, error){
 			&x.TableId, 
 			&x.TableName,
 			&x.TableNameLogical,
+			&x.VersionFlg,
 			&x.DelFlg,
 			&x.CreateUserId,
 			&x.CreateUsername,
@@ -0,0 +1,77 @@
+package query
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/dto"
+	"goat-cg/internal/core/db"
+)
+
+
+type ViewQuery interface {
+	GetViewLog(id int) ([]dto.ViewLog, error)
+}
+
+
+type viewQuery struct {
+	db *sql.DB
+}
+
+
+func NewViewQuery() ViewQuery {
+	db := db.GetDB()
+	return &viewQuery{db}
+}
+
+
+func (que *viewQuery)GetViewLog(id int) ([]dto.ViewLog, error){
+	rows, err := que.db.Query(
+		`SELECT 
+			vl.view_id,
+			vl.view_name,
+			vl.view_name_logical,
+			vl.view_sql,
+			vl.create_user_id,
+			u1.username create_username,
+			vl.update_user_id,
+			u2.username update_username,
+			vl.created_at,
+			vl.updated_at
+		 FROM 
+			 view_def_log vl
+			 LEFT OUTER JOIN users u1 ON vl.create_user_id = u1.user_id
+			 LEFT OUTER JOIN users u2 ON vl.update_user_id = u2.user_id
+		 WHERE 
+			 vl.view_id = ?
+		 ORDER BY vl.updated_at`, 
+		 id,
+	)
+	defer rows.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []dto.ViewLog{}
+	for rows.Next() {
+		x := dto.ViewLog{}
+		err = rows.Scan(
+			&x.ViewId, 
+			&x.ViewName,
+			&x.ViewNameLogical,
+			&x.ViewSql,
+			&x.CreateUserId,
+			&x.CreateUsername,
+			&x.UpdateUserId,
+			&x.UpdateUsername,
+			&x.CreatedAt,
+			&x.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, x)
+	}
+
+	return ret, nil
+}
\ No newline at end of file
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/model"
+)
+
+
+type AccessTokenRepository interface {
+	Get(t *model.AccessToken) ([]model.AccessToken, error)
+	GetOne(t *model.AccessToken) (model.AccessToken, error)
+	Insert(t *model.AccessToken, tx *sql.Tx) error
+	UpdateLastUsedAt(t *model.AccessToken, tx *sql.Tx) error
+	Delete(t *model.AccessToken, tx *sql.Tx) error
+}
+
+
+type accessTokenRepository struct {
+	db *sql.DB
+}
+
+
+func NewAccessTokenRepository() AccessTokenRepository {
+	db := db.GetDB()
+	return &accessTokenRepository{db}
+}
+
+
+func (rep *accessTokenRepository) Get(t *model.AccessToken) ([]model.AccessToken, error) {
+	where, binds := db.BuildWhereClause(t)
+	query :=
+	`SELECT
+		token_id,
+		user_id,
+		token_name,
+		token_hash,
+		token_prefix,
+		scope_cls,
+		project_id,
+		expires_at,
+		last_used_at,
+		created_at,
+		updated_at
+	 FROM access_token ` + where + ` ORDER BY token_id`
+
+	rows, err := rep.db.Query(query, binds...)
+	defer rows.Close()
+
+	if err != nil {
+		return []model.AccessToken{}, err
+	}
+
+	ret := []model.AccessToken{}
+	for rows.Next() {
+		t := model.AccessToken{}
+		err = rows.Scan(
+			&t.TokenId,
+			&t.UserId,
+			&t.TokenName,
+			&t.TokenHash,
+			&t.TokenPrefix,
+			&t.ScopeCls,
+			&t.ProjectId,
+			&t.ExpiresAt,
+			&t.LastUsedAt,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+		)
+		if err != nil {
+			return []model.AccessToken{}, err
+		}
+		ret = append(ret, t)
+	}
+
+	return ret, nil
+}
+
+
+func (rep *accessTokenRepository) GetOne(t *model.AccessToken) (model.AccessToken, error) {
+	var ret model.AccessToken
+	where, binds := db.BuildWhereClause(t)
+	query :=
+	`SELECT
+		token_id,
+		user_id,
+		token_name,
+		token_hash,
+		token_prefix,
+		scope_cls,
+		project_id,
+		expires_at,
+		last_used_at,
+		created_at,
+		updated_at
+	 FROM access_token ` + where
+
+	err := rep.db.QueryRow(query, binds...).Scan(
+		&ret.TokenId,
+		&ret.UserId,
+		&ret.TokenName,
+		&ret.TokenHash,
+		&ret.TokenPrefix,
+		&ret.ScopeCls,
+		&ret.ProjectId,
+		&ret.ExpiresAt,
+		&ret.LastUsedAt,
+		&ret.CreatedAt,
+		&ret.UpdatedAt,
+	)
+
+	return ret, err
+}
+
+
+func (rep *accessTokenRepository) Insert(t *model.AccessToken, tx *sql.Tx) error {
+	cmd :=
+	`INSERT INTO access_token (
+		user_id,
+		token_name,
+		token_hash,
+		token_prefix,
+		scope_cls,
+		project_id,
+		expires_at
+	 ) VALUES(?,?,?,?,?,?,?)`
+	binds := []interface{}{
+		t.UserId,
+		t.TokenName,
+		t.TokenHash,
+		t.TokenPrefix,
+		t.ScopeCls,
+		t.ProjectId,
+		t.ExpiresAt,
+	}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
+
+
+// UpdateLastUsedAt set last_used_at to now by token_id.
+func (rep *accessTokenRepository) UpdateLastUsedAt(t *model.AccessToken, tx *sql.Tx) error {
+	cmd :=
+	`UPDATE access_token
+	 SET last_used_at = DATETIME('now', 'localtime')
+	 WHERE token_id = ?`
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, t.TokenId)
+    } else {
+        _, err = rep.db.Exec(cmd, t.TokenId)
+    }
+
+	return err
+}
+
+
+func (rep *accessTokenRepository) Delete(t *model.AccessToken, tx *sql.Tx) error {
+	where, binds := db.BuildWhereClause(t)
+	cmd := "DELETE FROM access_token " + where
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
\ No newline at end of file
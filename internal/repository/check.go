@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/model"
+)
+
+
+type CheckRepository interface {
+	Get(c *model.Check) ([]model.Check, error)
+	GetOne(c *model.Check) (model.Check, error)
+	Insert(c *model.Check, tx *sql.Tx) error
+	Update(c *model.Check, tx *sql.Tx) error
+	Delete(c *model.Check, tx *sql.Tx) error
+}
+
+
+type checkRepository struct {
+	db *sql.DB
+}
+
+
+func NewCheckRepository() CheckRepository {
+	db := db.GetDB()
+	return &checkRepository{db}
+}
+
+
+func (rep *checkRepository) Get(c *model.Check) ([]model.Check, error) {
+	where, binds := db.BuildWhereClause(c)
+	query := 
+	`SELECT 
+		check_id,
+		table_id,
+		column_id,
+		check_name,
+		check_type_cls,
+		min_value,
+		max_value,
+		pattern,
+		allowed_values,
+		expression,
+		create_user_id,
+		update_user_id,
+		created_at,
+		updated_at
+	 FROM check_def ` + where
+
+	rows, err := rep.db.Query(query, binds...)
+	defer rows.Close()
+
+	if err != nil {
+		return []model.Check{}, err
+	}
+
+	ret := []model.Check{}
+	for rows.Next() {
+		c := model.Check{}
+		err = rows.Scan(
+			&c.CheckId,
+			&c.TableId,
+			&c.ColumnId,
+			&c.CheckName,
+			&c.CheckTypeCls,
+			&c.MinValue,
+			&c.MaxValue,
+			&c.Pattern,
+			&c.AllowedValues,
+			&c.Expression,
+			&c.CreateUserId,
+			&c.UpdateUserId,
+			&c.CreatedAt,
+			&c.UpdatedAt,
+		)
+		if err != nil {
+			return []model.Check{}, err
+		}
+		ret = append(ret, c)
+	}
+
+	return ret, nil
+}
+
+
+func (rep *checkRepository) GetOne(c *model.Check) (model.Check, error) {
+	var ret model.Check
+	where, binds := db.BuildWhereClause(c)
+	query :=
+	`SELECT 
+		check_id,
+		table_id,
+		column_id,
+		check_name,
+		check_type_cls,
+		min_value,
+		max_value,
+		pattern,
+		allowed_values,
+		expression,
+		create_user_id,
+		update_user_id,
+		created_at,
+		updated_at
+	 FROM check_def ` + where
+
+	err := rep.db.QueryRow(query, binds...).Scan(
+		&ret.CheckId,
+		&ret.TableId,
+		&ret.ColumnId,
+		&ret.CheckName,
+		&ret.CheckTypeCls,
+		&ret.MinValue,
+		&ret.MaxValue,
+		&ret.Pattern,
+		&ret.AllowedValues,
+		&ret.Expression,
+		&ret.CreateUserId,
+		&ret.UpdateUserId,
+		&ret.CreatedAt,
+		&ret.UpdatedAt,
+	)
+
+	return ret, err
+}
+
+
+func (rep *checkRepository) Insert(c *model.Check, tx *sql.Tx) error {
+	cmd := 
+	`INSERT INTO check_def (
+		table_id,
+		column_id,
+		check_name,
+		check_type_cls,
+		min_value,
+		max_value,
+		pattern,
+		allowed_values,
+		expression,
+		create_user_id,
+		update_user_id
+	 ) VALUES(?,?,?,?,?,?,?,?,?,?,?)`
+	binds := []interface{}{
+		c.TableId,
+		c.ColumnId,
+		c.CheckName,
+		c.CheckTypeCls,
+		c.MinValue,
+		c.MaxValue,
+		c.Pattern,
+		c.AllowedValues,
+		c.Expression,
+		c.CreateUserId,
+		c.UpdateUserId,
+	}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+	
+	return err
+}
+
+
+func (rep *checkRepository) Update(c *model.Check, tx *sql.Tx) error {
+	cmd := 
+	`UPDATE check_def
+	 SET 
+	    column_id = ?,
+	    check_name = ?,
+	    check_type_cls = ?,
+	    min_value = ?,
+	    max_value = ?,
+	    pattern = ?,
+	    allowed_values = ?,
+	    expression = ?,
+	    update_user_id = ?
+	 WHERE check_id = ?`
+	binds := []interface{}{
+		c.ColumnId,
+		c.CheckName,
+		c.CheckTypeCls,
+		c.MinValue,
+		c.MaxValue,
+		c.Pattern,
+		c.AllowedValues,
+		c.Expression,
+		c.UpdateUserId,
+		c.CheckId,
+	}
+	
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+	
+	return err
+}
+
+
+func (rep *checkRepository) Delete(c *model.Check, tx *sql.Tx) error {
+	where, binds := db.BuildWhereClause(c)
+	cmd := "DELETE FROM check_def " + where
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+	
+	return err
+}
\ No newline at end of file
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/model"
+)
+
+
+type ClassificationRepository interface {
+	Get(c *model.Classification) ([]model.Classification, error)
+	GetOne(c *model.Classification) (model.Classification, error)
+	Insert(c *model.Classification, tx *sql.Tx) error
+	Update(c *model.Classification, tx *sql.Tx) error
+	Delete(c *model.Classification, tx *sql.Tx) error
+}
+
+
+type classificationRepository struct {
+	db *sql.DB
+}
+
+
+func NewClassificationRepository() ClassificationRepository {
+	db := db.GetDB()
+	return &classificationRepository{db}
+}
+
+
+func (rep *classificationRepository) Get(c *model.Classification) ([]model.Classification, error) {
+	where, binds := db.BuildWhereClause(c)
+	query := 
+	`SELECT 
+		classification_id,
+		project_id,
+		classification_name,
+		classification_name_logical,
+		remark,
+		create_user_id,
+		update_user_id,
+		created_at,
+		updated_at
+	 FROM classification ` + where
+
+	rows, err := rep.db.Query(query, binds...)
+	defer rows.Close()
+
+	if err != nil {
+		return []model.Classification{}, err
+	}
+
+	ret := []model.Classification{}
+	for rows.Next() {
+		c := model.Classification{}
+		err = rows.Scan(
+			&c.ClassificationId,
+			&c.ProjectId,
+			&c.ClassificationName,
+			&c.ClassificationNameLogical,
+			&c.Remark,
+			&c.CreateUserId,
+			&c.UpdateUserId,
+			&c.CreatedAt,
+			&c.UpdatedAt,
+		)
+		if err != nil {
+			return []model.Classification{}, err
+		}
+		ret = append(ret, c)
+	}
+
+	return ret, nil
+}
+
+
+func (rep *classificationRepository) GetOne(c *model.Classification) (model.Classification, error) {
+	var ret model.Classification
+	where, binds := db.BuildWhereClause(c)
+	query := 
+	`SELECT 
+		classification_id,
+		project_id,
+		classification_name,
+		classification_name_logical,
+		remark,
+		create_user_id,
+		update_user_id,
+		created_at,
+		updated_at
+	 FROM classification ` + where
+
+	err := rep.db.QueryRow(query, binds...).Scan(
+		&ret.ClassificationId,
+		&ret.ProjectId,
+		&ret.ClassificationName,
+		&ret.ClassificationNameLogical,
+		&ret.Remark,
+		&ret.CreateUserId,
+		&ret.UpdateUserId,
+		&ret.CreatedAt,
+		&ret.UpdatedAt,
+	)
+
+	return ret, err
+}
+
+
+// Insert set the generated ClassificationId to c.
+func (rep *classificationRepository) Insert(c *model.Classification, tx *sql.Tx) error {
+	cmd := 
+	`INSERT INTO classification (
+		project_id,
+		classification_name,
+		classification_name_logical,
+		remark,
+		create_user_id,
+		update_user_id
+	 ) VALUES(?,?,?,?,?,?)`
+	binds := []interface{}{
+		c.ProjectId,
+		c.ClassificationName,
+		c.ClassificationNameLogical,
+		c.Remark,
+		c.CreateUserId,
+		c.UpdateUserId,
+	}
+
+	var err error
+	var result sql.Result
+	if tx != nil {
+        result, err = tx.Exec(cmd, binds...)
+    } else {
+        result, err = rep.db.Exec(cmd, binds...)
+    }
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	c.ClassificationId = int(id)
+	
+	return err
+}
+
+
+func (rep *classificationRepository) Update(c *model.Classification, tx *sql.Tx) error {
+	cmd := 
+	`UPDATE classification
+	 SET 
+	    classification_name = ?,
+	    classification_name_logical = ?,
+	    remark = ?,
+	    update_user_id = ?
+	 WHERE classification_id = ?`
+	binds := []interface{}{
+		c.ClassificationName,
+		c.ClassificationNameLogical,
+		c.Remark,
+		c.UpdateUserId,
+		c.ClassificationId,
+	}
+	
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+	
+	return err
+}
+
+
+func (rep *classificationRepository) Delete(c *model.Classification, tx *sql.Tx) error {
+	where, binds := db.BuildWhereClause(c)
+	cmd := "DELETE FROM classification " + where
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+	
+	return err
+}
\ No newline at end of file
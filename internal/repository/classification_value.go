@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/model"
+)
+
+
+type ClassificationValueRepository interface {
+	Get(cv *model.ClassificationValue) ([]model.ClassificationValue, error)
+	Insert(cv *model.ClassificationValue, tx *sql.Tx) error
+	Delete(cv *model.ClassificationValue, tx *sql.Tx) error
+}
+
+
+type classificationValueRepository struct {
+	db *sql.DB
+}
+
+
+func NewClassificationValueRepository() ClassificationValueRepository {
+	db := db.GetDB()
+	return &classificationValueRepository{db}
+}
+
+
+// Get return values ordered by align_seq.
+func (rep *classificationValueRepository) Get(cv *model.ClassificationValue) ([]model.ClassificationValue, error) {
+	where, binds := db.BuildWhereClause(cv)
+	query := 
+	`SELECT 
+		classification_id,
+		code,
+		value_name,
+		label,
+		align_seq,
+		created_at,
+		updated_at
+	 FROM classification_value ` + where + ` ORDER BY align_seq`
+
+	rows, err := rep.db.Query(query, binds...)
+	defer rows.Close()
+
+	if err != nil {
+		return []model.ClassificationValue{}, err
+	}
+
+	ret := []model.ClassificationValue{}
+	for rows.Next() {
+		cv := model.ClassificationValue{}
+		err = rows.Scan(
+			&cv.ClassificationId,
+			&cv.Code,
+			&cv.ValueName,
+			&cv.Label,
+			&cv.AlignSeq,
+			&cv.CreatedAt,
+			&cv.UpdatedAt,
+		)
+		if err != nil {
+			return []model.ClassificationValue{}, err
+		}
+		ret = append(ret, cv)
+	}
+
+	return ret, nil
+}
+
+
+func (rep *classificationValueRepository) Insert(cv *model.ClassificationValue, tx *sql.Tx) error {
+	cmd := 
+	`INSERT INTO classification_value (
+		classification_id,
+		code,
+		value_name,
+		label,
+		align_seq
+	 ) VALUES(?,?,?,?,?)`
+	binds := []interface{}{
+		cv.ClassificationId,
+		cv.Code,
+		cv.ValueName,
+		cv.Label,
+		cv.AlignSeq,
+	}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+	
+	return err
+}
+
+
+func (rep *classificationValueRepository) Delete(cv *model.ClassificationValue, tx *sql.Tx) error {
+	where, binds := db.BuildWhereClause(cv)
+	cmd := "DELETE FROM classification_value " + where
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+	
+	return err
+}
\ No newline at end of file
@@ -43,6 +43,9 @@ func (rep *columnRepository) Get(c *model.Column) ([]model.Column, error) {
 		not_null_flg,
 		unique_flg,
 		default_value,
+		enum_values,
+		classification_id,
+		ref_column_id,
 		remark,
 		align_seq,
 		del_flg,
@@ -74,6 +77,9 @@ func (rep *columnRepository) Get(c *model.Column) ([]model.Column, error) {
 			&c.NotNullFlg,
 			&c.UniqueFlg,
 			&c.DefaultValue,
+			&c.EnumValues,
+			&c.ClassificationId,
+			&c.RefColumnId,
 			&c.Remark,
 			&c.AlignSeq,
 			&c.DelFlg,
@@ -108,6 +114,9 @@ func (rep *columnRepository) GetOne(c *model.Column) (model.Column, error) {
 		not_null_flg,
 		unique_flg,
 		default_value,
+		enum_values,
+		classification_id,
+		ref_column_id,
 		remark,
 		align_seq,
 		del_flg,
@@ -129,6 +138,9 @@ func (rep *columnRepository) GetOne(c *model.Column) (model.Column, error) {
 		&ret.NotNullFlg,
 		&ret.UniqueFlg,
 		&ret.DefaultValue,
+		&ret.EnumValues,
+		&ret.ClassificationId,
+		&ret.RefColumnId,
 		&ret.Remark,
 		&ret.AlignSeq,
 		&ret.DelFlg,
@@ -155,12 +167,15 @@ func (rep *columnRepository) Insert(c *model.Column, tx *sql.Tx) error {
 		not_null_flg,
 		unique_flg,
 		default_value,
+		enum_values,
+		classification_id,
+		ref_column_id,
 		remark,
 		align_seq,
 		del_flg,
 		create_user_id,
 		update_user_id
-	 ) VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
+	 ) VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
 	binds := []interface{}{
 		c.TableId,
 		c.ColumnName, 
@@ -172,6 +187,9 @@ func (rep *columnRepository) Insert(c *model.Column, tx *sql.Tx) error {
 		c.NotNullFlg,
 		c.UniqueFlg,
 		c.DefaultValue,
+		c.EnumValues,
+		c.ClassificationId,
+		c.RefColumnId,
 		c.Remark,
 		c.AlignSeq,
 		c.DelFlg,
@@ -203,6 +221,9 @@ func (rep *columnRepository) Update(c *model.Column, tx *sql.Tx) error {
 	    not_null_flg = ?,
 	    unique_flg = ?,
 	    default_value = ?,
+	    enum_values = ?,
+	    classification_id = ?,
+	    ref_column_id = ?,
 	    remark = ?,
 	    align_seq = ?,
 	    del_flg = ?,
@@ -218,6 +239,9 @@ func (rep *columnRepository) Update(c *model.Column, tx *sql.Tx) error {
 		c.NotNullFlg,
 		c.UniqueFlg,
 		c.DefaultValue,
+		c.EnumValues,
+		c.ClassificationId,
+		c.RefColumnId,
 		c.Remark,
 		c.AlignSeq,
 		c.DelFlg,
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/model"
+)
+
+
+type InvitationRepository interface {
+	Get(i *model.Invitation) ([]model.Invitation, error)
+	GetOne(i *model.Invitation) (model.Invitation, error)
+	Upsert(i *model.Invitation, tx *sql.Tx) error
+	Delete(i *model.Invitation, tx *sql.Tx) error
+}
+
+
+type invitationRepository struct {
+	db *sql.DB
+}
+
+
+func NewInvitationRepository() InvitationRepository {
+	db := db.GetDB()
+	return &invitationRepository{db}
+}
+
+
+func (rep *invitationRepository) Get(i *model.Invitation) ([]model.Invitation, error) {
+	where, binds := db.BuildWhereClause(i)
+	query :=
+	`SELECT
+		invitation_id,
+		project_id,
+		email,
+		user_role,
+		invited_by,
+		expires_at,
+		created_at,
+		updated_at
+	 FROM project_invitation ` + where + ` ORDER BY invitation_id`
+
+	rows, err := rep.db.Query(query, binds...)
+	defer rows.Close()
+
+	if err != nil {
+		return []model.Invitation{}, err
+	}
+
+	ret := []model.Invitation{}
+	for rows.Next() {
+		i := model.Invitation{}
+		err = rows.Scan(
+			&i.InvitationId,
+			&i.ProjectId,
+			&i.Email,
+			&i.UserRole,
+			&i.InvitedBy,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		)
+		if err != nil {
+			return []model.Invitation{}, err
+		}
+		ret = append(ret, i)
+	}
+
+	return ret, nil
+}
+
+
+func (rep *invitationRepository) GetOne(i *model.Invitation) (model.Invitation, error) {
+	var ret model.Invitation
+	where, binds := db.BuildWhereClause(i)
+	query :=
+	`SELECT
+		invitation_id,
+		project_id,
+		email,
+		user_role,
+		invited_by,
+		expires_at,
+		created_at,
+		updated_at
+	 FROM project_invitation ` + where
+
+	err := rep.db.QueryRow(query, binds...).Scan(
+		&ret.InvitationId,
+		&ret.ProjectId,
+		&ret.Email,
+		&ret.UserRole,
+		&ret.InvitedBy,
+		&ret.ExpiresAt,
+		&ret.CreatedAt,
+		&ret.UpdatedAt,
+	)
+
+	return ret, err
+}
+
+
+// Upsert insert or replace the invitation by project_id and email.
+func (rep *invitationRepository) Upsert(i *model.Invitation, tx *sql.Tx) error {
+	cmd :=
+	`REPLACE INTO project_invitation (
+		project_id,
+		email,
+		user_role,
+		invited_by,
+		expires_at
+	 ) VALUES(?,?,?,?,?)`
+	binds := []interface{}{
+		i.ProjectId,
+		i.Email,
+		i.UserRole,
+		i.InvitedBy,
+		i.ExpiresAt,
+	}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
+
+
+func (rep *invitationRepository) Delete(i *model.Invitation, tx *sql.Tx) error {
+	where, binds := db.BuildWhereClause(i)
+	cmd := "DELETE FROM project_invitation " + where
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
\ No newline at end of file
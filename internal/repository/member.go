@@ -38,6 +38,8 @@ func (rep *memberRepository) Get(m *model.Member) ([]model.Member, error) {
 		user_id,
 		user_status,
 		user_role,
+		invited_by,
+		expires_at,
 		created_at,
 		updated_at
 	 FROM project_member ` + where
@@ -57,6 +59,8 @@ func (rep *memberRepository) Get(m *model.Member) ([]model.Member, error) {
 			&m.UserId, 
 			&m.UserStatus, 
 			&m.UserRole,
+			&m.InvitedBy,
+			&m.ExpiresAt,
 			&m.CreatedAt, 
 			&m.UpdatedAt,
 		)
@@ -79,6 +83,8 @@ func (rep *memberRepository) GetOne(m *model.Member) (model.Member, error) {
 		user_id,
 		user_status,
 		user_role,
+		invited_by,
+		expires_at,
 		created_at,
 		updated_at
 	 FROM project_member ` + where
@@ -88,6 +94,8 @@ func (rep *memberRepository) GetOne(m *model.Member) (model.Member, error) {
 		&ret.UserId, 
 		&ret.UserStatus, 
 		&ret.UserRole,
+		&ret.InvitedBy,
+		&ret.ExpiresAt,
 		&ret.CreatedAt, 
 		&ret.UpdatedAt,
 	)
@@ -102,10 +110,12 @@ func (rep *memberRepository) Insert(m *model.Member, tx *sql.Tx) error {
 		project_id,
 		user_id, 
 		user_status, 
-		user_role
+		user_role,
+		invited_by,
+		expires_at
 	 ) 
-	 VALUES(?,?,?,?)`
-	binds := []interface{}{m.ProjectId, m.UserId, m.UserStatus, m.UserRole}
+	 VALUES(?,?,?,?,?,?)`
+	binds := []interface{}{m.ProjectId, m.UserId, m.UserStatus, m.UserRole, m.InvitedBy, m.ExpiresAt}
 
 	var err error
 	if tx != nil {
@@ -120,12 +130,14 @@ func (rep *memberRepository) Insert(m *model.Member, tx *sql.Tx) error {
 
 func (rep *memberRepository) Update(m *model.Member, tx *sql.Tx) error {
 	cmd := 
-	`UPDATE users 
+	`UPDATE project_member 
 	 SET user_status = ?,
-	 	 user_role = ?
+	 	 user_role = ?,
+	 	 invited_by = ?,
+	 	 expires_at = ?
 	 WHERE user_id = ?
 	   AND project_id = ?`
-	binds := []interface{}{m.UserStatus, m.UserRole, m.UserId, m.ProjectId}
+	binds := []interface{}{m.UserStatus, m.UserRole, m.InvitedBy, m.ExpiresAt, m.UserId, m.ProjectId}
 	
 	var err error
 	if tx != nil {
@@ -162,10 +174,12 @@ func (rep *memberRepository) Upsert(m *model.Member, tx *sql.Tx) error {
 		project_id, 
 		user_id, 
 		user_status, 
-		user_role
+		user_role,
+		invited_by,
+		expires_at
 	 ) 
-	 VALUES(?,?,?,?)`
-	binds := []interface{}{m.ProjectId, m.UserId, m.UserStatus, m.UserRole}
+	 VALUES(?,?,?,?,?,?)`
+	binds := []interface{}{m.ProjectId, m.UserId, m.UserStatus, m.UserRole, m.InvitedBy, m.ExpiresAt}
 
 	var err error
 	if tx != nil {
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/model"
+)
+
+
+type OrgMemberRepository interface {
+	Get(m *model.OrgMember) ([]model.OrgMember, error)
+	GetOne(m *model.OrgMember) (model.OrgMember, error)
+	Upsert(m *model.OrgMember, tx *sql.Tx) error
+	Delete(m *model.OrgMember, tx *sql.Tx) error
+}
+
+
+type orgMemberRepository struct {
+	db *sql.DB
+}
+
+
+func NewOrgMemberRepository() OrgMemberRepository {
+	db := db.GetDB()
+	return &orgMemberRepository{db}
+}
+
+
+func (rep *orgMemberRepository) Get(m *model.OrgMember) ([]model.OrgMember, error) {
+	where, binds := db.BuildWhereClause(m)
+	query :=
+	`SELECT
+		org_id,
+		user_id,
+		org_role,
+		created_at,
+		updated_at
+	 FROM org_member ` + where
+	rows, err := rep.db.Query(query, binds...)
+	defer rows.Close()
+
+	if err != nil {
+		return []model.OrgMember{}, err
+	}
+
+	ret := []model.OrgMember{}
+	for rows.Next() {
+		x := model.OrgMember{}
+		err = rows.Scan(
+			&x.OrgId,
+			&x.UserId,
+			&x.OrgRole,
+			&x.CreatedAt,
+			&x.UpdatedAt,
+		)
+		if err != nil {
+			return []model.OrgMember{}, err
+		}
+		ret = append(ret, x)
+	}
+
+	return ret, nil
+}
+
+
+func (rep *orgMemberRepository) GetOne(m *model.OrgMember) (model.OrgMember, error) {
+	var ret model.OrgMember
+	where, binds := db.BuildWhereClause(m)
+	query :=
+	`SELECT
+		org_id,
+		user_id,
+		org_role,
+		created_at,
+		updated_at
+	 FROM org_member ` + where
+
+	err := rep.db.QueryRow(query, binds...).Scan(
+		&ret.OrgId,
+		&ret.UserId,
+		&ret.OrgRole,
+		&ret.CreatedAt,
+		&ret.UpdatedAt,
+	)
+
+	return ret, err
+}
+
+
+// Upsert insert or replace the member by org_id and user_id.
+func (rep *orgMemberRepository) Upsert(m *model.OrgMember, tx *sql.Tx) error {
+	cmd :=
+	`REPLACE INTO org_member (
+		org_id,
+		user_id,
+		org_role
+	 ) VALUES(?,?,?)`
+	binds := []interface{}{m.OrgId, m.UserId, m.OrgRole}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
+
+
+func (rep *orgMemberRepository) Delete(m *model.OrgMember, tx *sql.Tx) error {
+	where, binds := db.BuildWhereClause(m)
+	cmd := "DELETE FROM org_member " + where
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
\ No newline at end of file
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/model"
+)
+
+
+type OrganizationRepository interface {
+	Get(o *model.Organization) ([]model.Organization, error)
+	GetOne(o *model.Organization) (model.Organization, error)
+	Insert(o *model.Organization, tx *sql.Tx) error
+	Update(o *model.Organization, tx *sql.Tx) error
+	Delete(o *model.Organization, tx *sql.Tx) error
+}
+
+
+type organizationRepository struct {
+	db *sql.DB
+}
+
+
+func NewOrganizationRepository() OrganizationRepository {
+	db := db.GetDB()
+	return &organizationRepository{db}
+}
+
+
+func (rep *organizationRepository) Get(o *model.Organization) ([]model.Organization, error) {
+	where, binds := db.BuildWhereClause(o)
+	query :=
+	`SELECT
+		org_id,
+		org_name,
+		org_memo,
+		created_at,
+		updated_at
+	 FROM organization ` + where
+	rows, err := rep.db.Query(query, binds...)
+	defer rows.Close()
+
+	if err != nil {
+		return []model.Organization{}, err
+	}
+
+	ret := []model.Organization{}
+	for rows.Next() {
+		x := model.Organization{}
+		err = rows.Scan(
+			&x.OrgId,
+			&x.OrgName,
+			&x.OrgMemo,
+			&x.CreatedAt,
+			&x.UpdatedAt,
+		)
+		if err != nil {
+			return []model.Organization{}, err
+		}
+		ret = append(ret, x)
+	}
+
+	return ret, nil
+}
+
+
+func (rep *organizationRepository) GetOne(o *model.Organization) (model.Organization, error) {
+	var ret model.Organization
+	where, binds := db.BuildWhereClause(o)
+	query :=
+	`SELECT
+		org_id,
+		org_name,
+		org_memo,
+		created_at,
+		updated_at
+	 FROM organization ` + where
+
+	err := rep.db.QueryRow(query, binds...).Scan(
+		&ret.OrgId,
+		&ret.OrgName,
+		&ret.OrgMemo,
+		&ret.CreatedAt,
+		&ret.UpdatedAt,
+	)
+
+	return ret, err
+}
+
+
+func (rep *organizationRepository) Insert(o *model.Organization, tx *sql.Tx) error {
+	cmd :=
+	`INSERT INTO organization (
+		org_name,
+		org_memo
+	 ) VALUES(?,?)`
+	binds := []interface{}{o.OrgName, o.OrgMemo}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
+
+
+func (rep *organizationRepository) Update(o *model.Organization, tx *sql.Tx) error {
+	cmd :=
+	`UPDATE organization
+	 SET org_name = ?,
+	     org_memo = ?
+	 WHERE org_id = ?`
+	binds := []interface{}{o.OrgName, o.OrgMemo, o.OrgId}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
+
+
+func (rep *organizationRepository) Delete(o *model.Organization, tx *sql.Tx) error {
+	where, binds := db.BuildWhereClause(o)
+	cmd := "DELETE FROM organization " + where
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
\ No newline at end of file
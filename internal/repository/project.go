@@ -13,6 +13,7 @@ type ProjectRepository interface {
 	GetOne(p *model.Project) (model.Project, error)
 	Insert(p *model.Project, tx *sql.Tx) error
 	Update(p *model.Project, tx *sql.Tx) error
+	UpdateUsername(userId int, username string, tx *sql.Tx) error
 	Delete(p *model.Project, tx *sql.Tx) error
 }
 
@@ -37,6 +38,7 @@ func (rep *projectRepository) Get(p *model.Project) ([]model.Project, error) {
 		project_memo,
 		user_id,
 		username,
+		org_id,
 		created_at,
 		updated_at
 	 FROM project ` + where
@@ -56,6 +58,7 @@ func (rep *projectRepository) Get(p *model.Project) ([]model.Project, error) {
 			&p.ProjectMemo, 
 			&p.UserId,
 			&p.Username,
+			&p.OrgId,
 			&p.CreatedAt,
 			&p.UpdatedAt,
 		)
@@ -79,6 +82,7 @@ func (rep *projectRepository) GetOne(p *model.Project) (model.Project, error) {
 		project_memo,
 		user_id,
 		username,
+		org_id,
 		created_at,
 		updated_at
 	 FROM project ` + where
@@ -89,6 +93,7 @@ func (rep *projectRepository) GetOne(p *model.Project) (model.Project, error) {
 		&ret.ProjectMemo,
 		&ret.UserId,
 		&ret.Username,
+		&ret.OrgId,
 		&ret.CreatedAt,
 		&ret.UpdatedAt,
 	)
@@ -103,9 +108,10 @@ func (rep *projectRepository) Insert(p *model.Project, tx *sql.Tx) error {
 		project_name,
 		project_memo,
 		user_id,
-		username 
-	 ) VALUES(?,?,?,?)`
-	binds := []interface{}{p.ProjectName, p.ProjectMemo, p.UserId, p.Username}
+		username,
+		org_id 
+	 ) VALUES(?,?,?,?,?)`
+	binds := []interface{}{p.ProjectName, p.ProjectMemo, p.UserId, p.Username, p.OrgId}
 
 	var err error
 	if tx != nil {
@@ -119,15 +125,42 @@ func (rep *projectRepository) Insert(p *model.Project, tx *sql.Tx) error {
 
 
 func (rep *projectRepository) Update(p *model.Project, tx *sql.Tx) error {
-	_, err := rep.db.Exec(
-		`UPDATE project 
-		 SET project_name = ?,
-		 	 project_memo = ? 
-		 WHERE project_id = ?`,
-		p.ProjectName, 
-		p.ProjectMemo,
-		p.ProjectId, 
-	)
+	cmd := 
+	`UPDATE project 
+	 SET project_name = ?,
+	 	 project_memo = ?,
+	 	 user_id = ?,
+	 	 username = ?,
+	 	 org_id = ? 
+	 WHERE project_id = ?`
+	binds := []interface{}{p.ProjectName, p.ProjectMemo, p.UserId, p.Username, p.OrgId, p.ProjectId}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
+
+
+// UpdateUsername set username of the projects of the user. (project.username is the owner name of the URL)
+func (rep *projectRepository) UpdateUsername(userId int, username string, tx *sql.Tx) error {
+	cmd := 
+	`UPDATE project 
+	 SET username = ? 
+	 WHERE user_id = ?
+	 AND org_id = 0`
+	binds := []interface{}{username, userId}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
 
 	return err
 }
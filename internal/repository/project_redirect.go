@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/model"
+)
+
+
+type ProjectRedirectRepository interface {
+	GetOne(r *model.ProjectRedirect) (model.ProjectRedirect, error)
+	Upsert(r *model.ProjectRedirect, tx *sql.Tx) error
+	Delete(r *model.ProjectRedirect, tx *sql.Tx) error
+}
+
+
+type projectRedirectRepository struct {
+	db *sql.DB
+}
+
+
+func NewProjectRedirectRepository() ProjectRedirectRepository {
+	db := db.GetDB()
+	return &projectRedirectRepository{db}
+}
+
+
+func (rep *projectRedirectRepository) GetOne(r *model.ProjectRedirect) (model.ProjectRedirect, error) {
+	var ret model.ProjectRedirect
+	where, binds := db.BuildWhereClause(r)
+	query :=
+	`SELECT
+		username,
+		project_name,
+		project_id,
+		created_at
+	 FROM project_redirect ` + where
+
+	err := rep.db.QueryRow(query, binds...).Scan(
+		&ret.Username,
+		&ret.ProjectName,
+		&ret.ProjectId,
+		&ret.CreatedAt,
+	)
+
+	return ret, err
+}
+
+
+// Upsert insert or replace the redirect by username and project_name.
+func (rep *projectRedirectRepository) Upsert(r *model.ProjectRedirect, tx *sql.Tx) error {
+	cmd :=
+	`REPLACE INTO project_redirect (
+		username,
+		project_name,
+		project_id
+	 ) VALUES(?,?,?)`
+	binds := []interface{}{r.Username, r.ProjectName, r.ProjectId}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
+
+
+func (rep *projectRedirectRepository) Delete(r *model.ProjectRedirect, tx *sql.Tx) error {
+	where, binds := db.BuildWhereClause(r)
+	cmd := "DELETE FROM project_redirect " + where
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
\ No newline at end of file
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/model"
+)
+
+
+type ProtoFieldRepository interface {
+	Get(pf *model.ProtoField) ([]model.ProtoField, error)
+	Insert(pf *model.ProtoField, tx *sql.Tx) error
+	Update(pf *model.ProtoField, tx *sql.Tx) error
+}
+
+
+type protoFieldRepository struct {
+	db *sql.DB
+}
+
+
+func NewProtoFieldRepository() ProtoFieldRepository {
+	db := db.GetDB()
+	return &protoFieldRepository{db}
+}
+
+
+// Get return fields ordered by field_no.
+func (rep *protoFieldRepository) Get(pf *model.ProtoField) ([]model.ProtoField, error) {
+	where, binds := db.BuildWhereClause(pf)
+	query := 
+	`SELECT 
+		table_id,
+		field_no,
+		column_id,
+		field_name,
+		created_at,
+		updated_at
+	 FROM proto_field ` + where + ` ORDER BY field_no`
+
+	rows, err := rep.db.Query(query, binds...)
+	defer rows.Close()
+
+	if err != nil {
+		return []model.ProtoField{}, err
+	}
+
+	ret := []model.ProtoField{}
+	for rows.Next() {
+		pf := model.ProtoField{}
+		err = rows.Scan(
+			&pf.TableId,
+			&pf.FieldNo,
+			&pf.ColumnId,
+			&pf.FieldName,
+			&pf.CreatedAt,
+			&pf.UpdatedAt,
+		)
+		if err != nil {
+			return []model.ProtoField{}, err
+		}
+		ret = append(ret, pf)
+	}
+
+	return ret, nil
+}
+
+
+func (rep *protoFieldRepository) Insert(pf *model.ProtoField, tx *sql.Tx) error {
+	cmd := 
+	`INSERT INTO proto_field (
+		table_id,
+		field_no,
+		column_id,
+		field_name
+	 ) VALUES(?,?,?,?)`
+	binds := []interface{}{
+		pf.TableId,
+		pf.FieldNo,
+		pf.ColumnId,
+		pf.FieldName,
+	}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+	
+	return err
+}
+
+
+// Update update field_name (renamed column) by table_id and field_no.
+func (rep *protoFieldRepository) Update(pf *model.ProtoField, tx *sql.Tx) error {
+	cmd := 
+	`UPDATE proto_field
+	 SET field_name = ?
+	 WHERE table_id = ?
+	   AND field_no = ?`
+	binds := []interface{}{
+		pf.FieldName,
+		pf.TableId,
+		pf.FieldNo,
+	}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+	
+	return err
+}
\ No newline at end of file
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/model"
+)
+
+
+type SampleRowRepository interface {
+	Get(sr *model.SampleRow) ([]model.SampleRow, error)
+	Insert(sr *model.SampleRow, tx *sql.Tx) error
+	UpdateStale(sr *model.SampleRow, tx *sql.Tx) error
+	DeleteByTableId(tableId int, tx *sql.Tx) error
+}
+
+
+type sampleRowRepository struct {
+	db *sql.DB
+}
+
+
+func NewSampleRowRepository() SampleRowRepository {
+	db := db.GetDB()
+	return &sampleRowRepository{db}
+}
+
+
+// Get return rows ordered by row_no.
+func (rep *sampleRowRepository) Get(sr *model.SampleRow) ([]model.SampleRow, error) {
+	where, binds := db.BuildWhereClause(sr)
+	query :=
+	`SELECT
+		table_id,
+		row_no,
+		row_data,
+		stale_flg,
+		stale_reason,
+		created_at,
+		updated_at
+	 FROM sample_row ` + where + ` ORDER BY row_no`
+
+	rows, err := rep.db.Query(query, binds...)
+	defer rows.Close()
+
+	if err != nil {
+		return []model.SampleRow{}, err
+	}
+
+	ret := []model.SampleRow{}
+	for rows.Next() {
+		sr := model.SampleRow{}
+		err = rows.Scan(
+			&sr.TableId,
+			&sr.RowNo,
+			&sr.RowData,
+			&sr.StaleFlg,
+			&sr.StaleReason,
+			&sr.CreatedAt,
+			&sr.UpdatedAt,
+		)
+		if err != nil {
+			return []model.SampleRow{}, err
+		}
+		ret = append(ret, sr)
+	}
+
+	return ret, nil
+}
+
+
+func (rep *sampleRowRepository) Insert(sr *model.SampleRow, tx *sql.Tx) error {
+	cmd :=
+	`INSERT INTO sample_row (
+		table_id,
+		row_no,
+		row_data,
+		stale_flg,
+		stale_reason
+	 ) VALUES(?,?,?,?,?)`
+	binds := []interface{}{
+		sr.TableId,
+		sr.RowNo,
+		sr.RowData,
+		sr.StaleFlg,
+		sr.StaleReason,
+	}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
+
+
+// UpdateStale update stale_flg and stale_reason by table_id and row_no.
+func (rep *sampleRowRepository) UpdateStale(sr *model.SampleRow, tx *sql.Tx) error {
+	cmd :=
+	`UPDATE sample_row
+	 SET stale_flg = ?,
+		 stale_reason = ?
+	 WHERE table_id = ?
+	   AND row_no = ?`
+	binds := []interface{}{
+		sr.StaleFlg,
+		sr.StaleReason,
+		sr.TableId,
+		sr.RowNo,
+	}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
+
+
+// DeleteByTableId delete all rows of the table. (rows are replaced together)
+func (rep *sampleRowRepository) DeleteByTableId(tableId int, tx *sql.Tx) error {
+	cmd :=
+	`DELETE FROM sample_row
+	 WHERE table_id = ?`
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, tableId)
+    } else {
+        _, err = rep.db.Exec(cmd, tableId)
+    }
+
+	return err
+}
\ No newline at end of file
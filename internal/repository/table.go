@@ -36,6 +36,7 @@ func (rep *tableRepository) Get(t *model.Table) ([]model.Table, error){
 	 	project_id,
 		table_name,
 		table_name_logical,
+		version_flg,
 		del_flg,
 		create_user_id,
 		update_user_id,
@@ -57,6 +58,7 @@ func (rep *tableRepository) Get(t *model.Table) ([]model.Table, error){
 			&t.ProjectId, 
 			&t.TableName,
 			&t.TableNameLogical,
+			&t.VersionFlg,
 			&t.DelFlg,
 			&t.CreateUserId,
 			&t.UpdateUserId,
@@ -82,6 +84,7 @@ func (rep *tableRepository) GetOne(t *model.Table) (model.Table, error){
 	 	project_id,
 		table_name,
 		table_name_logical,
+		version_flg,
 		del_flg,
 		create_user_id,
 		update_user_id,
@@ -94,7 +97,8 @@ func (rep *tableRepository) GetOne(t *model.Table) (model.Table, error){
 		&ret.ProjectId, 
 		&ret.TableName,
 		&ret.TableNameLogical,
-		&t.DelFlg,
+		&ret.VersionFlg,
+		&ret.DelFlg,
 		&ret.CreateUserId,
 		&ret.UpdateUserId,
 		&ret.CreatedAt,
@@ -111,14 +115,16 @@ func (rep *tableRepository) Insert(t *model.Table, tx *sql.Tx) error {
 		project_id, 
 		table_name,
 		table_name_logical,
+		version_flg,
 		del_flg,
 		create_user_id,
 		update_user_id
-	 ) VALUES(?,?,?,?,?,?)`
+	 ) VALUES(?,?,?,?,?,?,?)`
 	binds := []interface{}{
-		t.ProjectId, 
+		t.ProjectId,
 		t.TableName,
 		t.TableNameLogical,
+		t.VersionFlg,
 		t.DelFlg,
 		t.CreateUserId,
 		t.UpdateUserId,
@@ -139,12 +145,14 @@ func (rep *tableRepository) Update(t *model.Table, tx *sql.Tx) error {
 	`UPDATE table_def
 	 SET table_name = ?,
 		 table_name_logical = ?,
+		 version_flg = ?,
 		 del_flg = ?,
 		 update_user_id = ?
 	 WHERE table_id= ?`
 	binds := []interface{}{
 		t.TableName,
 		t.TableNameLogical,
+		t.VersionFlg,
 		t.DelFlg,
 		t.UpdateUserId,
 		t.TableId,
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/model"
+)
+
+
+type TeamRepository interface {
+	Get(t *model.Team) ([]model.Team, error)
+	GetOne(t *model.Team) (model.Team, error)
+	Insert(t *model.Team, tx *sql.Tx) error
+	Update(t *model.Team, tx *sql.Tx) error
+	Delete(t *model.Team, tx *sql.Tx) error
+}
+
+
+type teamRepository struct {
+	db *sql.DB
+}
+
+
+func NewTeamRepository() TeamRepository {
+	db := db.GetDB()
+	return &teamRepository{db}
+}
+
+
+func (rep *teamRepository) Get(t *model.Team) ([]model.Team, error) {
+	where, binds := db.BuildWhereClause(t)
+	query :=
+	`SELECT
+		team_id,
+		org_id,
+		team_name,
+		team_memo,
+		created_at,
+		updated_at
+	 FROM team ` + where
+	rows, err := rep.db.Query(query, binds...)
+	defer rows.Close()
+
+	if err != nil {
+		return []model.Team{}, err
+	}
+
+	ret := []model.Team{}
+	for rows.Next() {
+		x := model.Team{}
+		err = rows.Scan(
+			&x.TeamId,
+			&x.OrgId,
+			&x.TeamName,
+			&x.TeamMemo,
+			&x.CreatedAt,
+			&x.UpdatedAt,
+		)
+		if err != nil {
+			return []model.Team{}, err
+		}
+		ret = append(ret, x)
+	}
+
+	return ret, nil
+}
+
+
+func (rep *teamRepository) GetOne(t *model.Team) (model.Team, error) {
+	var ret model.Team
+	where, binds := db.BuildWhereClause(t)
+	query :=
+	`SELECT
+		team_id,
+		org_id,
+		team_name,
+		team_memo,
+		created_at,
+		updated_at
+	 FROM team ` + where
+
+	err := rep.db.QueryRow(query, binds...).Scan(
+		&ret.TeamId,
+		&ret.OrgId,
+		&ret.TeamName,
+		&ret.TeamMemo,
+		&ret.CreatedAt,
+		&ret.UpdatedAt,
+	)
+
+	return ret, err
+}
+
+
+func (rep *teamRepository) Insert(t *model.Team, tx *sql.Tx) error {
+	cmd :=
+	`INSERT INTO team (
+		org_id,
+		team_name,
+		team_memo
+	 ) VALUES(?,?,?)`
+	binds := []interface{}{t.OrgId, t.TeamName, t.TeamMemo}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
+
+
+func (rep *teamRepository) Update(t *model.Team, tx *sql.Tx) error {
+	cmd :=
+	`UPDATE team
+	 SET team_name = ?,
+	     team_memo = ?
+	 WHERE team_id = ?`
+	binds := []interface{}{t.TeamName, t.TeamMemo, t.TeamId}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
+
+
+func (rep *teamRepository) Delete(t *model.Team, tx *sql.Tx) error {
+	where, binds := db.BuildWhereClause(t)
+	cmd := "DELETE FROM team " + where
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
\ No newline at end of file
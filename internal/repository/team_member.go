@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/model"
+)
+
+
+type TeamMemberRepository interface {
+	Get(m *model.TeamMember) ([]model.TeamMember, error)
+	GetOne(m *model.TeamMember) (model.TeamMember, error)
+	Upsert(m *model.TeamMember, tx *sql.Tx) error
+	Delete(m *model.TeamMember, tx *sql.Tx) error
+}
+
+
+type teamMemberRepository struct {
+	db *sql.DB
+}
+
+
+func NewTeamMemberRepository() TeamMemberRepository {
+	db := db.GetDB()
+	return &teamMemberRepository{db}
+}
+
+
+func (rep *teamMemberRepository) Get(m *model.TeamMember) ([]model.TeamMember, error) {
+	where, binds := db.BuildWhereClause(m)
+	query :=
+	`SELECT
+		team_id,
+		user_id,
+		created_at
+	 FROM team_member ` + where
+	rows, err := rep.db.Query(query, binds...)
+	defer rows.Close()
+
+	if err != nil {
+		return []model.TeamMember{}, err
+	}
+
+	ret := []model.TeamMember{}
+	for rows.Next() {
+		x := model.TeamMember{}
+		err = rows.Scan(
+			&x.TeamId,
+			&x.UserId,
+			&x.CreatedAt,
+		)
+		if err != nil {
+			return []model.TeamMember{}, err
+		}
+		ret = append(ret, x)
+	}
+
+	return ret, nil
+}
+
+
+func (rep *teamMemberRepository) GetOne(m *model.TeamMember) (model.TeamMember, error) {
+	var ret model.TeamMember
+	where, binds := db.BuildWhereClause(m)
+	query :=
+	`SELECT
+		team_id,
+		user_id,
+		created_at
+	 FROM team_member ` + where
+
+	err := rep.db.QueryRow(query, binds...).Scan(
+		&ret.TeamId,
+		&ret.UserId,
+		&ret.CreatedAt,
+	)
+
+	return ret, err
+}
+
+
+// Upsert insert or replace the member by team_id and user_id.
+func (rep *teamMemberRepository) Upsert(m *model.TeamMember, tx *sql.Tx) error {
+	cmd :=
+	`REPLACE INTO team_member (
+		team_id,
+		user_id
+	 ) VALUES(?,?)`
+	binds := []interface{}{m.TeamId, m.UserId}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
+
+
+func (rep *teamMemberRepository) Delete(m *model.TeamMember, tx *sql.Tx) error {
+	where, binds := db.BuildWhereClause(m)
+	cmd := "DELETE FROM team_member " + where
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
\ No newline at end of file
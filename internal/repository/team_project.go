@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/model"
+)
+
+
+type TeamProjectRepository interface {
+	Get(tp *model.TeamProject) ([]model.TeamProject, error)
+	GetOne(tp *model.TeamProject) (model.TeamProject, error)
+	Upsert(tp *model.TeamProject, tx *sql.Tx) error
+	Delete(tp *model.TeamProject, tx *sql.Tx) error
+}
+
+
+type teamProjectRepository struct {
+	db *sql.DB
+}
+
+
+func NewTeamProjectRepository() TeamProjectRepository {
+	db := db.GetDB()
+	return &teamProjectRepository{db}
+}
+
+
+func (rep *teamProjectRepository) Get(tp *model.TeamProject) ([]model.TeamProject, error) {
+	where, binds := db.BuildWhereClause(tp)
+	query :=
+	`SELECT
+		team_id,
+		project_id,
+		user_role,
+		created_at,
+		updated_at
+	 FROM team_project ` + where
+	rows, err := rep.db.Query(query, binds...)
+	defer rows.Close()
+
+	if err != nil {
+		return []model.TeamProject{}, err
+	}
+
+	ret := []model.TeamProject{}
+	for rows.Next() {
+		x := model.TeamProject{}
+		err = rows.Scan(
+			&x.TeamId,
+			&x.ProjectId,
+			&x.UserRole,
+			&x.CreatedAt,
+			&x.UpdatedAt,
+		)
+		if err != nil {
+			return []model.TeamProject{}, err
+		}
+		ret = append(ret, x)
+	}
+
+	return ret, nil
+}
+
+
+func (rep *teamProjectRepository) GetOne(tp *model.TeamProject) (model.TeamProject, error) {
+	var ret model.TeamProject
+	where, binds := db.BuildWhereClause(tp)
+	query :=
+	`SELECT
+		team_id,
+		project_id,
+		user_role,
+		created_at,
+		updated_at
+	 FROM team_project ` + where
+
+	err := rep.db.QueryRow(query, binds...).Scan(
+		&ret.TeamId,
+		&ret.ProjectId,
+		&ret.UserRole,
+		&ret.CreatedAt,
+		&ret.UpdatedAt,
+	)
+
+	return ret, err
+}
+
+
+// Upsert insert or replace the role of the team by team_id and project_id.
+func (rep *teamProjectRepository) Upsert(tp *model.TeamProject, tx *sql.Tx) error {
+	cmd :=
+	`REPLACE INTO team_project (
+		team_id,
+		project_id,
+		user_role
+	 ) VALUES(?,?,?)`
+	binds := []interface{}{tp.TeamId, tp.ProjectId, tp.UserRole}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
+
+
+func (rep *teamProjectRepository) Delete(tp *model.TeamProject, tx *sql.Tx) error {
+	where, binds := db.BuildWhereClause(tp)
+	cmd := "DELETE FROM team_project " + where
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+
+	return err
+}
\ No newline at end of file
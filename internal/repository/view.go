@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/model"
+)
+
+
+type ViewRepository interface {
+	Get(v *model.View) ([]model.View, error)
+	GetOne(v *model.View) (model.View, error)
+	Insert(v *model.View, tx *sql.Tx) error
+	Update(v *model.View, tx *sql.Tx) error
+	Delete(v *model.View, tx *sql.Tx) error
+}
+
+
+type viewRepository struct {
+	db *sql.DB
+}
+
+
+func NewViewRepository() ViewRepository {
+	db := db.GetDB()
+	return &viewRepository{db}
+}
+
+
+func (rep *viewRepository) Get(v *model.View) ([]model.View, error) {
+	where, binds := db.BuildWhereClause(v)
+	query := 
+	`SELECT 
+		view_id,
+		project_id,
+		view_name,
+		view_name_logical,
+		view_sql,
+		create_user_id,
+		update_user_id,
+		created_at,
+		updated_at
+	 FROM view_def ` + where
+
+	rows, err := rep.db.Query(query, binds...)
+	defer rows.Close()
+
+	if err != nil {
+		return []model.View{}, err
+	}
+
+	ret := []model.View{}
+	for rows.Next() {
+		v := model.View{}
+		err = rows.Scan(
+			&v.ViewId,
+			&v.ProjectId,
+			&v.ViewName,
+			&v.ViewNameLogical,
+			&v.ViewSql,
+			&v.CreateUserId,
+			&v.UpdateUserId,
+			&v.CreatedAt,
+			&v.UpdatedAt,
+		)
+		if err != nil {
+			return []model.View{}, err
+		}
+		ret = append(ret, v)
+	}
+
+	return ret, nil
+}
+
+
+func (rep *viewRepository) GetOne(v *model.View) (model.View, error) {
+	var ret model.View
+	where, binds := db.BuildWhereClause(v)
+	query := 
+	`SELECT 
+		view_id,
+		project_id,
+		view_name,
+		view_name_logical,
+		view_sql,
+		create_user_id,
+		update_user_id,
+		created_at,
+		updated_at
+	 FROM view_def ` + where
+
+	err := rep.db.QueryRow(query, binds...).Scan(
+		&ret.ViewId,
+		&ret.ProjectId,
+		&ret.ViewName,
+		&ret.ViewNameLogical,
+		&ret.ViewSql,
+		&ret.CreateUserId,
+		&ret.UpdateUserId,
+		&ret.CreatedAt,
+		&ret.UpdatedAt,
+	)
+
+	return ret, err
+}
+
+
+// Insert set the generated ViewId to v.
+func (rep *viewRepository) Insert(v *model.View, tx *sql.Tx) error {
+	cmd := 
+	`INSERT INTO view_def (
+		project_id,
+		view_name,
+		view_name_logical,
+		view_sql,
+		create_user_id,
+		update_user_id
+	 ) VALUES(?,?,?,?,?,?)`
+	binds := []interface{}{
+		v.ProjectId,
+		v.ViewName,
+		v.ViewNameLogical,
+		v.ViewSql,
+		v.CreateUserId,
+		v.UpdateUserId,
+	}
+
+	var err error
+	var result sql.Result
+	if tx != nil {
+        result, err = tx.Exec(cmd, binds...)
+    } else {
+        result, err = rep.db.Exec(cmd, binds...)
+    }
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	v.ViewId = int(id)
+	
+	return err
+}
+
+
+func (rep *viewRepository) Update(v *model.View, tx *sql.Tx) error {
+	cmd := 
+	`UPDATE view_def
+	 SET 
+	    view_name = ?,
+	    view_name_logical = ?,
+	    view_sql = ?,
+	    update_user_id = ?
+	 WHERE view_id = ?`
+	binds := []interface{}{
+		v.ViewName,
+		v.ViewNameLogical,
+		v.ViewSql,
+		v.UpdateUserId,
+		v.ViewId,
+	}
+	
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+	
+	return err
+}
+
+
+func (rep *viewRepository) Delete(v *model.View, tx *sql.Tx) error {
+	where, binds := db.BuildWhereClause(v)
+	cmd := "DELETE FROM view_def " + where
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+	
+	return err
+}
\ No newline at end of file
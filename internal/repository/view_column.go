@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/model"
+)
+
+
+type ViewColumnRepository interface {
+	Get(vc *model.ViewColumn) ([]model.ViewColumn, error)
+	Insert(vc *model.ViewColumn, tx *sql.Tx) error
+	Delete(vc *model.ViewColumn, tx *sql.Tx) error
+}
+
+
+type viewColumnRepository struct {
+	db *sql.DB
+}
+
+
+func NewViewColumnRepository() ViewColumnRepository {
+	db := db.GetDB()
+	return &viewColumnRepository{db}
+}
+
+
+// Get return columns ordered by align_seq.
+func (rep *viewColumnRepository) Get(vc *model.ViewColumn) ([]model.ViewColumn, error) {
+	where, binds := db.BuildWhereClause(vc)
+	query := 
+	`SELECT 
+		view_id,
+		column_name,
+		data_type_cls,
+		align_seq,
+		created_at
+	 FROM view_column ` + where + ` ORDER BY align_seq`
+
+	rows, err := rep.db.Query(query, binds...)
+	defer rows.Close()
+
+	if err != nil {
+		return []model.ViewColumn{}, err
+	}
+
+	ret := []model.ViewColumn{}
+	for rows.Next() {
+		vc := model.ViewColumn{}
+		err = rows.Scan(
+			&vc.ViewId,
+			&vc.ColumnName,
+			&vc.DataTypeCls,
+			&vc.AlignSeq,
+			&vc.CreatedAt,
+		)
+		if err != nil {
+			return []model.ViewColumn{}, err
+		}
+		ret = append(ret, vc)
+	}
+
+	return ret, nil
+}
+
+
+func (rep *viewColumnRepository) Insert(vc *model.ViewColumn, tx *sql.Tx) error {
+	cmd := 
+	`INSERT INTO view_column (
+		view_id,
+		column_name,
+		data_type_cls,
+		align_seq
+	 ) VALUES(?,?,?,?)`
+	binds := []interface{}{
+		vc.ViewId,
+		vc.ColumnName,
+		vc.DataTypeCls,
+		vc.AlignSeq,
+	}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+	
+	return err
+}
+
+
+func (rep *viewColumnRepository) Delete(vc *model.ViewColumn, tx *sql.Tx) error {
+	where, binds := db.BuildWhereClause(vc)
+	cmd := "DELETE FROM view_column " + where
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+	
+	return err
+}
\ No newline at end of file
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/model"
+)
+
+
+type ViewTableRepository interface {
+	Get(vt *model.ViewTable) ([]model.ViewTable, error)
+	Insert(vt *model.ViewTable, tx *sql.Tx) error
+	Delete(vt *model.ViewTable, tx *sql.Tx) error
+}
+
+
+type viewTableRepository struct {
+	db *sql.DB
+}
+
+
+func NewViewTableRepository() ViewTableRepository {
+	db := db.GetDB()
+	return &viewTableRepository{db}
+}
+
+
+func (rep *viewTableRepository) Get(vt *model.ViewTable) ([]model.ViewTable, error) {
+	where, binds := db.BuildWhereClause(vt)
+	query := 
+	`SELECT 
+		view_id,
+		table_id,
+		created_at
+	 FROM view_table ` + where
+
+	rows, err := rep.db.Query(query, binds...)
+	defer rows.Close()
+
+	if err != nil {
+		return []model.ViewTable{}, err
+	}
+
+	ret := []model.ViewTable{}
+	for rows.Next() {
+		vt := model.ViewTable{}
+		err = rows.Scan(
+			&vt.ViewId,
+			&vt.TableId,
+			&vt.CreatedAt,
+		)
+		if err != nil {
+			return []model.ViewTable{}, err
+		}
+		ret = append(ret, vt)
+	}
+
+	return ret, nil
+}
+
+
+func (rep *viewTableRepository) Insert(vt *model.ViewTable, tx *sql.Tx) error {
+	cmd := 
+	`INSERT INTO view_table (
+		view_id,
+		table_id
+	 ) VALUES(?,?)`
+	binds := []interface{}{
+		vt.ViewId,
+		vt.TableId,
+	}
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+	
+	return err
+}
+
+
+func (rep *viewTableRepository) Delete(vt *model.ViewTable, tx *sql.Tx) error {
+	where, binds := db.BuildWhereClause(vt)
+	cmd := "DELETE FROM view_table " + where
+
+	var err error
+	if tx != nil {
+        _, err = tx.Exec(cmd, binds...)
+    } else {
+        _, err = rep.db.Exec(cmd, binds...)
+    }
+	
+	return err
+}
\ No newline at end of file
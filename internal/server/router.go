@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"goat-cg/internal/core/jwt"
+	"goat-cg/internal/shared/constant"
 	"goat-cg/internal/middleware"
 	"goat-cg/internal/controller"
 )
@@ -37,45 +38,142 @@ func SetRouter(r *gin.Engine) {
 			au.GET("/projects/:project_id", pc.UpdateProjectPage)
 			au.POST("/projects/:project_id", pc.UpdateProject)
 			au.DELETE("/projects/:project_id", pc.DeleteProject)
+			au.POST("/projects/:project_id/transfer", pc.TransferProject)
 			au.GET("/account", uc.AccountPage)
 			au.POST("/account/password", uc.UpdatePassword)
 			au.POST("/account/email", uc.UpdateEmail)
+			au.POST("/account/username", uc.UpdateUsername)
+			au.POST("/account/tokens", uc.CreateAccessToken)
+			au.DELETE("/account/tokens/:token_id", uc.DeleteAccessToken)
+
+			oc := controller.NewOrganizationController()
+
+			au.GET("/orgs/new", oc.CreateOrganizationPage)
+			au.POST("/orgs/new", oc.CreateOrganization)
+
+			auo := au.Group("/-", middleware.OrgMiddleware())
+			{
+				orgOwner := middleware.OrgRoleMiddleware(constant.ORG_ROLE_CLS_OWNER)
+
+				auo.GET("/members", oc.MembersPage)
+				auo.POST("/members", orgOwner, oc.AddMember)
+				auo.POST("/members/:user_id", orgOwner, oc.UpdateMemberRole)
+				auo.DELETE("/members/:user_id", oc.DeleteMember)
+				auo.POST("/teams", orgOwner, oc.CreateTeam)
+				auo.GET("/teams/:team_id", oc.TeamPage)
+				auo.DELETE("/teams/:team_id", orgOwner, oc.DeleteTeam)
+				auo.POST("/teams/:team_id/members", orgOwner, oc.AddTeamMember)
+				auo.DELETE("/teams/:team_id/members/:user_id", orgOwner, oc.DeleteTeamMember)
+				auo.POST("/teams/:team_id/projects", orgOwner, oc.GrantTeamProject)
+				auo.DELETE("/teams/:team_id/projects/:project_id", orgOwner, oc.RevokeTeamProject)
+			}
+
+			imc := controller.NewMemberController()
+
+			au.POST("/invitations/:project_id/accept", imc.AcceptInvitation)
+			au.POST("/invitations/:project_id/decline", imc.DeclineInvitation)
+			au.GET("/:project_name/join", imc.JoinPage)
+			au.POST("/:project_name/join", imc.RequestJoin)
+			au.POST("/:project_name/join/cancel", imc.CancelJoin)
 
 			aup := au.Group("/:project_name", middleware.PathParameterValidationMiddleware())
 			{
+				editor := middleware.RoleMiddleware(constant.ROLE_CLS_NOMAL)
+				admin := middleware.RoleMiddleware(constant.ROLE_CLS_ADMIN)
+
 				tc := controller.NewTableController()
 				mc := controller.NewMemberController()
 
 				aup.GET("", tc.TablesPage)
 				aup.GET("/tables", tc.TablesPage)
-				aup.GET("/tables/new", tc.CreateTablePage)
-				aup.POST("/tables/new", tc.CreateTable)
+				aup.GET("/tables/new", editor, tc.CreateTablePage)
+				aup.POST("/tables/new", editor, tc.CreateTable)
 				aup.GET("/tables/:table_id", tc.UpdateTablePage)
-				aup.POST("/tables/:table_id", tc.UpdateTable)
-				aup.DELETE("/tables/:table_id", tc.DeleteTable)
+				aup.POST("/tables/:table_id", editor, tc.UpdateTable)
+				aup.DELETE("/tables/:table_id", editor, tc.DeleteTable)
 				aup.GET("/tables/:table_id/log", tc.TableLogPage)
 				aup.GET("/members", mc.MembersPage)
-				aup.GET("/members/:user_id", mc.MemberPage)
-				aup.DELETE("/members/:user_id", mc.DeleteMember)
-				aup.POST("/members/invite", mc.Invite)
+				aup.GET("/members/:user_id", admin, mc.MemberPage)
+				aup.POST("/members/:user_id", admin, mc.UpdateMemberRole)
+				aup.DELETE("/members/:user_id", admin, mc.DeleteMember)
+				aup.POST("/members/:user_id/approve", admin, mc.ApproveJoin)
+				aup.POST("/members/:user_id/reject", admin, mc.RejectJoin)
+				aup.POST("/members/:user_id/block", admin, mc.BlockMember)
+				aup.POST("/members/invite", admin, mc.Invite)
+				aup.DELETE("/members/:user_id/invitation", mc.RevokeInvitation)
+				aup.DELETE("/invitations/:invitation_id", mc.RevokeEmailInvitation)
+
+				clc := controller.NewClassificationController()
+
+				aup.GET("/classifications", clc.ClassificationsPage)
+				aup.GET("/classifications/new", editor, clc.CreateClassificationPage)
+				aup.POST("/classifications/new", editor, clc.CreateClassification)
+				aup.GET("/classifications/:classification_id", clc.UpdateClassificationPage)
+				aup.POST("/classifications/:classification_id", editor, clc.UpdateClassification)
+				aup.DELETE("/classifications/:classification_id", editor, clc.DeleteClassification)
+
+				vc := controller.NewViewController()
+
+				aup.GET("/views", vc.ViewsPage)
+				aup.GET("/views/new", editor, vc.CreateViewPage)
+				aup.POST("/views/new", editor, vc.CreateView)
+				aup.GET("/views/:view_id", vc.UpdateViewPage)
+				aup.POST("/views/:view_id", editor, vc.UpdateView)
+				aup.DELETE("/views/:view_id", editor, vc.DeleteView)
+				aup.GET("/views/:view_id/log", vc.ViewLogPage)
+
+				dc := controller.NewDiagramController()
+
+				aup.GET("/diagram", dc.DiagramPage)
+				aup.GET("/diagram/:format", dc.ExportDiagram)
+
+				dic := controller.NewDictionaryController()
+
+				aup.GET("/dictionary", dic.DictionaryPage)
+				aup.GET("/dictionary/:format", dic.ExportDictionary)
 	
 	
 				cgc := controller.NewCodegenController()
 	
 				aup.GET("/codegen", cgc.CodegenPage)
 				aup.POST("/codegen/goat", cgc.CodegenGOAT)
+				aup.POST("/codegen/openapi", cgc.CodegenOpenApi)
+				aup.POST("/codegen/typescript", cgc.CodegenTypeScript)
+				aup.POST("/codegen/proto", cgc.CodegenProto)
+				aup.POST("/codegen/graphql", cgc.CodegenGraphql)
+				aup.POST("/codegen/jsonschema", cgc.CodegenJsonSchema)
+				aup.POST("/codegen/orm", cgc.CodegenOrm)
+				aup.POST("/codegen/seed", cgc.CodegenSeed)
 	
 				aupt := aup.Group("/tables/:table_id")
 				{
 					cc := controller.NewColumnController()
 	
 					aupt.GET("/columns", cc.ColumnsPage)
-					aupt.GET("/columns/new", cc.CreateColumnPage)
-					aupt.POST("/columns/new", cc.CreateColumn)
+					aupt.GET("/columns/new", editor, cc.CreateColumnPage)
+					aupt.POST("/columns/new", editor, cc.CreateColumn)
+					aupt.GET("/columns/import", editor, cc.ImportColumnsPage)
+					aupt.POST("/columns/import", editor, cc.PreviewImportColumns)
+					aupt.POST("/columns/import/apply", editor, cc.ImportColumns)
 					aupt.GET("/columns/:column_id", cc.UpdateColumnPage)
-					aupt.POST("/columns/:column_id", cc.UpdateColumn)
-					aupt.DELETE("/columns/:column_id", cc.DeleteColumn)
+					aupt.POST("/columns/:column_id", editor, cc.UpdateColumn)
+					aupt.DELETE("/columns/:column_id", editor, cc.DeleteColumn)
 					aupt.GET("/columns/:column_id/log", cc.ColumnLogPage)
+
+					ckc := controller.NewCheckController()
+
+					aupt.GET("/checks", ckc.ChecksPage)
+					aupt.GET("/checks/new", editor, ckc.CreateCheckPage)
+					aupt.POST("/checks/new", editor, ckc.CreateCheck)
+					aupt.GET("/checks/:check_id", ckc.UpdateCheckPage)
+					aupt.POST("/checks/:check_id", editor, ckc.UpdateCheck)
+					aupt.DELETE("/checks/:check_id", editor, ckc.DeleteCheck)
+
+					src := controller.NewSampleRowController()
+
+					aupt.GET("/samples", src.SampleRowsPage)
+					aupt.POST("/samples", editor, src.UpdateSampleRows)
+					aupt.PUT("/samples", editor, src.SaveSampleRows)
 				}
 			}
 		} 
@@ -83,12 +181,76 @@ func SetRouter(r *gin.Engine) {
 	}
 
 	//response JSON (Authorized request)
-	api := r.Group("/api", jwt.JwtAuthApiMiddleware())
+	api := r.Group("/api", middleware.ApiAuthMiddleware())
 	{
 		api.GET("/account/profile", uc.GetProfile)
 		//api.PUT("/account/username", uc.UpdateUsername)
 		//api.PUT("/account/password", uc.UpdatePassword)
 		//api.PUT("/account/email", uc.UpdateEmail)
 		api.DELETE("/account", uc.DeleteAccount)
+
+		v1 := api.Group("/v1")
+		{
+			pac := controller.NewProjectApiController()
+
+			v1.GET("/projects", pac.GetProjects)
+			v1.POST("/projects", pac.CreateProject)
+
+			imac := controller.NewMemberApiController()
+
+			v1.GET("/invitations", imac.GetInvitations)
+			v1.POST("/invitations/:project_id/accept", imac.AcceptInvitation)
+			v1.POST("/invitations/:project_id/decline", imac.DeclineInvitation)
+			v1.POST("/:username/:project_name/join", imac.RequestJoin)
+			v1.DELETE("/:username/:project_name/join", imac.CancelJoin)
+
+			v1p := v1.Group("/:username/:project_name", middleware.ApiPathParameterValidationMiddleware())
+			{
+				apiEditor := middleware.ApiRoleMiddleware(constant.ROLE_CLS_NOMAL)
+				apiAdmin := middleware.ApiRoleMiddleware(constant.ROLE_CLS_ADMIN)
+				apiOwner := middleware.ApiRoleMiddleware(constant.ROLE_CLS_OWNER)
+
+				v1p.GET("", pac.GetProject)
+				v1p.PUT("", apiOwner, pac.UpdateProject)
+				v1p.DELETE("", apiOwner, pac.DeleteProject)
+				v1p.POST("/transfer", apiOwner, pac.TransferProject)
+
+				tac := controller.NewTableApiController()
+
+				v1p.GET("/tables", tac.GetTables)
+				v1p.POST("/tables", apiEditor, tac.CreateTable)
+				v1p.GET("/tables/:table_id", tac.GetTable)
+				v1p.PUT("/tables/:table_id", apiEditor, tac.UpdateTable)
+				v1p.DELETE("/tables/:table_id", apiEditor, tac.DeleteTable)
+				v1p.GET("/tables/:table_id/log", tac.GetTableLog)
+
+				cac := controller.NewColumnApiController()
+
+				v1p.GET("/tables/:table_id/columns", cac.GetColumns)
+				v1p.POST("/tables/:table_id/columns", apiEditor, cac.CreateColumn)
+				v1p.GET("/tables/:table_id/columns/:column_id", cac.GetColumn)
+				v1p.PUT("/tables/:table_id/columns/:column_id", apiEditor, cac.UpdateColumn)
+				v1p.DELETE("/tables/:table_id/columns/:column_id", apiEditor, cac.DeleteColumn)
+				v1p.GET("/tables/:table_id/columns/:column_id/log", cac.GetColumnLog)
+
+				mac := controller.NewMemberApiController()
+
+				v1p.GET("/members", mac.GetMembers)
+				v1p.POST("/members", apiAdmin, mac.Invite)
+				v1p.GET("/members/:user_id", mac.GetMember)
+				v1p.PUT("/members/:user_id", apiAdmin, mac.UpdateMemberRole)
+				v1p.DELETE("/members/:user_id", apiAdmin, mac.DeleteMember)
+				v1p.POST("/members/:user_id/approve", apiAdmin, mac.ApproveJoin)
+				v1p.POST("/members/:user_id/reject", apiAdmin, mac.RejectJoin)
+				v1p.POST("/members/:user_id/block", apiAdmin, mac.BlockMember)
+				v1p.DELETE("/members/:user_id/invitation", mac.RevokeInvitation)
+				v1p.GET("/invitations", mac.GetEmailInvitations)
+				v1p.DELETE("/invitations/:invitation_id", mac.RevokeEmailInvitation)
+
+				cgac := controller.NewCodegenApiController()
+
+				v1p.POST("/codegen/:generator", cgac.Generate)
+			}
+		}
 	}
 }
\ No newline at end of file
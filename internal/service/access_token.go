@@ -0,0 +1,189 @@
+package service
+
+import (
+	"time"
+	"strings"
+	"database/sql"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/core/logger"
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/model"
+	"goat-cg/internal/dto"
+	"goat-cg/internal/repository"
+)
+
+
+// ACCESS_TOKEN_PREFIX prefix of personal access tokens. (tells them from JWT)
+const ACCESS_TOKEN_PREFIX = "goat_"
+
+// datetimeFormat format of DATETIME('now', 'localtime') of the DB.
+const datetimeFormat = "2006-01-02 15:04:05"
+
+
+type AccessTokenService interface {
+	GetAccessTokens(userId int) ([]dto.AccessToken, error)
+	CreateAccessToken(in dto.CreateAccessToken) (string, error)
+	DeleteAccessToken(userId, tokenId int) error
+	Authenticate(token string) (model.AccessToken, error)
+}
+
+
+type accessTokenService struct {
+	accessTokenRepository repository.AccessTokenRepository
+	projectRepository repository.ProjectRepository
+	memberService MemberService
+}
+
+
+func NewAccessTokenService() AccessTokenService {
+	accessTokenRepository := repository.NewAccessTokenRepository()
+	projectRepository := repository.NewProjectRepository()
+	memberService := NewMemberService()
+	return &accessTokenService{accessTokenRepository, projectRepository, memberService}
+}
+
+
+// GetAccessTokens get tokens of the user.
+func (srv *accessTokenService) GetAccessTokens(userId int) ([]dto.AccessToken, error) {
+	tokens, err := srv.accessTokenRepository.Get(&model.AccessToken{UserId: userId})
+	if err != nil {
+		logger.Error(err.Error())
+		return []dto.AccessToken{}, err
+	}
+
+	now := time.Now().Format(datetimeFormat)
+	ret := []dto.AccessToken{}
+	for _, t := range tokens {
+		at := dto.AccessToken{
+			TokenId: t.TokenId,
+			TokenName: t.TokenName,
+			TokenPrefix: t.TokenPrefix,
+			ScopeCls: t.ScopeCls,
+			ScopeName: constant.SCOPE_CLS_NAME[t.ScopeCls],
+			ProjectId: t.ProjectId,
+			ExpiresAt: t.ExpiresAt,
+			Expired: t.ExpiresAt != "" && t.ExpiresAt < now,
+			LastUsedAt: t.LastUsedAt,
+			CreatedAt: t.CreatedAt,
+		}
+		if t.ProjectId != 0 {
+			p, err := srv.projectRepository.GetOne(&model.Project{ProjectId: t.ProjectId})
+			if err == nil {
+				at.ProjectName = p.Username + "/" + p.ProjectName
+			}
+		}
+		ret = append(ret, at)
+	}
+
+	return ret, nil
+}
+
+
+// CreateAccessToken create a token and return it. (only the hash is stored)
+// ExpiresDays: 0 for no expiry. ProjectId: 0 for all projects of the user.
+func (srv *accessTokenService) CreateAccessToken(in dto.CreateAccessToken) (string, error) {
+	in.TokenName = strings.TrimSpace(in.TokenName)
+	if in.TokenName == "" || len(in.TokenName) > 50 {
+		return "", errs.NewInvalidValueError("token_name", "must be 1-50 characters.")
+	}
+	if _, ok := constant.SCOPE_CLS_NAME[in.ScopeCls]; !ok {
+		return "", errs.NewInvalidValueError("scope_cls", "unknown scope.")
+	}
+	if in.ExpiresDays < 0 || in.ExpiresDays > 366 {
+		return "", errs.NewInvalidValueError("expires_days", "must be 0-366.")
+	}
+	if in.ProjectId != 0 && !srv.canAccessProject(in.UserId, in.ProjectId) {
+		return "", errs.NewInvalidValueError("project_id", "project not found.")
+	}
+
+	_, err := srv.accessTokenRepository.GetOne(&model.AccessToken{UserId: in.UserId, TokenName: in.TokenName})
+	if err == nil {
+		return "", errs.NewUniqueConstraintError("token_name")
+	}
+
+	b := make([]byte, 20)
+	if _, err = rand.Read(b); err != nil {
+		logger.Error(err.Error())
+		return "", err
+	}
+	token := ACCESS_TOKEN_PREFIX + hex.EncodeToString(b)
+
+	var t model.AccessToken
+	t.UserId = in.UserId
+	t.TokenName = in.TokenName
+	t.TokenHash = hashAccessToken(token)
+	t.TokenPrefix = token[:len(ACCESS_TOKEN_PREFIX) + 6]
+	t.ScopeCls = in.ScopeCls
+	t.ProjectId = in.ProjectId
+	if in.ExpiresDays > 0 {
+		t.ExpiresAt = time.Now().AddDate(0, 0, in.ExpiresDays).Format(datetimeFormat)
+	}
+
+	if err = srv.accessTokenRepository.Insert(&t, nil); err != nil {
+		logger.Error(err.Error())
+		return "", err
+	}
+
+	return token, nil
+}
+
+
+// DeleteAccessToken revoke the token of the user.
+func (srv *accessTokenService) DeleteAccessToken(userId, tokenId int) error {
+	t, err := srv.accessTokenRepository.GetOne(&model.AccessToken{TokenId: tokenId})
+	if err != nil || t.UserId != userId {
+		return errs.NewNotFoundError()
+	}
+
+	if err = srv.accessTokenRepository.Delete(&model.AccessToken{TokenId: tokenId, UserId: userId}, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+// Authenticate get the token not expired and record its use.
+func (srv *accessTokenService) Authenticate(token string) (model.AccessToken, error) {
+	t, err := srv.accessTokenRepository.GetOne(&model.AccessToken{TokenHash: hashAccessToken(token)})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			logger.Error(err.Error())
+		}
+		return model.AccessToken{}, errs.NewNotFoundError()
+	}
+
+	if t.ExpiresAt != "" && t.ExpiresAt < time.Now().Format(datetimeFormat) {
+		return model.AccessToken{}, errs.NewInvalidValueError("token", "expired.")
+	}
+
+	if err = srv.accessTokenRepository.UpdateLastUsedAt(&t, nil); err != nil {
+		logger.Error(err.Error())
+	}
+
+	return t, nil
+}
+
+
+// canAccessProject the user has a role in the project. (the owner, a member or by the teams)
+func (srv *accessTokenService) canAccessProject(userId, projectId int) bool {
+	p, err := srv.projectRepository.GetOne(&model.Project{ProjectId: projectId})
+	if err != nil {
+		return false
+	}
+
+	_, err = srv.memberService.GetRole(p, userId)
+	return err == nil
+}
+
+
+// hashAccessToken sha256 of the token. (tokens are random, so no salt is needed)
+func hashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
\ No newline at end of file
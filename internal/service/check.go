@@ -0,0 +1,221 @@
+package service
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"database/sql"
+
+	"goat-cg/internal/dto"
+	"goat-cg/internal/core/logger"
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/model"
+	"goat-cg/internal/repository"
+)
+
+
+type CheckService interface {
+	GetCheck(checkId int) (model.Check, error)
+	GetChecks(tableId int) ([]model.Check, error)
+	CreateCheck(in dto.CreateCheck) error
+	UpdateCheck(in dto.CreateCheck) error
+	DeleteCheck(checkId int) error
+}
+
+
+// checkNamePattern check names are written to DDL as constraint names and to Go as variable names.
+var checkNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+
+type checkService struct {
+	checkRepository repository.CheckRepository
+	columnRepository repository.ColumnRepository
+	sampleRowService SampleRowService
+}
+
+
+func NewCheckService() CheckService {
+	checkRepository := repository.NewCheckRepository()
+	columnRepository := repository.NewColumnRepository()
+	sampleRowService := NewSampleRowService()
+	return &checkService{checkRepository, columnRepository, sampleRowService}
+}
+
+
+// GetCheck get Check record by checkId.
+func (srv *checkService) GetCheck(checkId int) (model.Check, error) {
+	check, err := srv.checkRepository.GetOne(&model.Check{CheckId: checkId})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logger.Debug(err.Error())
+		} else {
+			logger.Error(err.Error())
+		}
+	}
+
+	return check, err
+}
+
+
+// GetChecks get Check records by tableId.
+func (srv *checkService) GetChecks(tableId int) ([]model.Check, error) {
+	checks, err := srv.checkRepository.Get(&model.Check{TableId: tableId})
+
+	if err != nil {
+		logger.Error(err.Error())
+	}
+
+	return checks, err
+}
+
+
+// CreateCheck create new Check record.
+func (srv *checkService) CreateCheck(sin dto.CreateCheck) error {
+	_, err := srv.checkRepository.GetOne(&model.Check{CheckName: sin.CheckName, TableId: sin.TableId})
+	if err == nil {
+		return errs.NewUniqueConstraintError("check_name")
+	}
+
+	if err = srv.validateCheck(&sin); err != nil {
+		return err
+	}
+
+	check := sin.ToCheck()
+
+	if err = srv.checkRepository.Insert(&check, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+	srv.sampleRowService.ValidateSampleRows(check.TableId)
+
+	return nil
+}
+
+
+// UpdateCheck update Check record by checkId.
+func (srv *checkService) UpdateCheck(sin dto.CreateCheck) error {
+	chk, err := srv.checkRepository.GetOne(&model.Check{CheckName: sin.CheckName, TableId: sin.TableId})
+	if err == nil && chk.CheckId != sin.CheckId {
+		return errs.NewUniqueConstraintError("check_name")
+	}
+
+	if err = srv.validateCheck(&sin); err != nil {
+		return err
+	}
+
+	check := sin.ToCheck()
+
+	if err = srv.checkRepository.Update(&check, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+	srv.sampleRowService.ValidateSampleRows(check.TableId)
+
+	return nil
+}
+
+
+// DeleteCheck delete Check record by checkId.
+// (physical delete)
+func (srv *checkService) DeleteCheck(checkId int) error {
+	check, err := srv.checkRepository.GetOne(&model.Check{CheckId: checkId})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	if err = srv.checkRepository.Delete(&model.Check{CheckId: checkId}, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+	srv.sampleRowService.ValidateSampleRows(check.TableId)
+
+	return nil
+}
+
+
+// validateCheck validate the definition can be written to DDL and Validate().
+// table level checks (ColumnId = 0) are expressions only.
+func (srv *checkService) validateCheck(sin *dto.CreateCheck) error {
+	if !checkNamePattern.MatchString(sin.CheckName) {
+		return errs.NewInvalidValueError("check_name", "lowercase letters, digits or '_', starting with a letter.")
+	}
+
+	if sin.ColumnId == 0 {
+		if sin.CheckTypeCls != constant.CHECK_TYPE_CLS_EXPRESSION {
+			return errs.NewInvalidValueError("check_type_cls", "table level check must be an expression.")
+		}
+		return nil
+	}
+
+	column, err := srv.columnRepository.GetOne(&model.Column{ColumnId: sin.ColumnId})
+	if err != nil || column.TableId != sin.TableId {
+		return errs.NewInvalidValueError("column_id", "column not found.")
+	}
+
+	return validateCheckValues(sin, column.DataTypeCls)
+}
+
+
+// validateCheckValues validate the values of the column level check against the data type of the column.
+// (also when the data type of the column is changed) duplicates of the allowed values are removed.
+func validateCheckValues(sin *dto.CreateCheck, dataTypeCls string) error {
+	switch sin.CheckTypeCls {
+	case constant.CHECK_TYPE_CLS_RANGE:
+		if sin.MinValue == "" && sin.MaxValue == "" {
+			return errs.NewInvalidValueError("min_value", "min or max is required.")
+		}
+		if isNumericType(dataTypeCls) {
+			for _, v := range []string{sin.MinValue, sin.MaxValue} {
+				if v != "" && !isNumberLiteral(v) {
+					return errs.NewInvalidValueError("min_value", "must be a number.")
+				}
+			}
+		}
+
+	case constant.CHECK_TYPE_CLS_PATTERN:
+		if isNumericType(dataTypeCls) {
+			return errs.NewInvalidValueError("pattern", "pattern is for string columns.")
+		}
+		if _, err := regexp.Compile(sin.Pattern); err != nil {
+			return errs.NewInvalidValueError("pattern", "invalid regular expression.")
+		}
+
+	case constant.CHECK_TYPE_CLS_IN:
+		values := splitAllowedValues(sin.AllowedValues, isNumericType(dataTypeCls))
+		if len(values) == 0 {
+			return errs.NewInvalidValueError("allowed_values", "allowed values are required.")
+		}
+		if isNumericType(dataTypeCls) {
+			for _, v := range values {
+				if !isNumberLiteral(v) {
+					return errs.NewInvalidValueError("allowed_values", "must be numbers.")
+				}
+			}
+		}
+		sin.AllowedValues = strings.Join(values, ",")
+	}
+
+	return nil
+}
+
+
+// splitAllowedValues the allowed values of IN check without duplicates.
+// (numbers of numeric columns are compared as numbers: "1" and "1.0")
+func splitAllowedValues(allowedValues string, numeric bool) []string {
+	var ret []string
+	seen := map[string]bool{}
+	for _, v := range splitEnumValues(allowedValues) {
+		key := v
+		if f, err := strconv.ParseFloat(v, 64); numeric && err == nil {
+			key = strconv.FormatFloat(f, 'g', -1, 64)
+		}
+		if !seen[key] {
+			seen[key] = true
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
\ No newline at end of file
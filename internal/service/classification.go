@@ -0,0 +1,249 @@
+package service
+
+import (
+	"regexp"
+	"database/sql"
+
+	"goat-cg/internal/dto"
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/core/logger"
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/model"
+	"goat-cg/internal/repository"
+)
+
+
+type ClassificationService interface {
+	GetClassification(classificationId int) (model.Classification, error)
+	GetClassifications(projectId int) ([]model.Classification, error)
+	GetClassificationValues(classificationId int) ([]model.ClassificationValue, error)
+	CreateClassification(in dto.CreateClassification) error
+	UpdateClassification(in dto.CreateClassification) error
+	DeleteClassification(classificationId int) error
+}
+
+
+type classificationService struct {
+	classificationRepository repository.ClassificationRepository
+	classificationValueRepository repository.ClassificationValueRepository
+	columnRepository repository.ColumnRepository
+}
+
+
+func NewClassificationService() ClassificationService {
+	classificationRepository := repository.NewClassificationRepository()
+	classificationValueRepository := repository.NewClassificationValueRepository()
+	columnRepository := repository.NewColumnRepository()
+	return &classificationService{
+		classificationRepository,
+		classificationValueRepository,
+		columnRepository,
+	}
+}
+
+
+// GetClassification get Classification record by classificationId.
+func (srv *classificationService) GetClassification(classificationId int) (model.Classification, error) {
+	classification, err := srv.classificationRepository.GetOne(
+		&model.Classification{ClassificationId: classificationId},
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logger.Debug(err.Error())
+		} else {
+			logger.Error(err.Error())
+		}
+	}
+
+	return classification, err
+}
+
+
+// GetClassifications get Classification records by projectId.
+func (srv *classificationService) GetClassifications(projectId int) ([]model.Classification, error) {
+	classifications, err := srv.classificationRepository.Get(&model.Classification{ProjectId: projectId})
+
+	if err != nil {
+		logger.Error(err.Error())
+	}
+
+	return classifications, err
+}
+
+
+// GetClassificationValues get ClassificationValue records by classificationId.
+func (srv *classificationService) GetClassificationValues(classificationId int) ([]model.ClassificationValue, error) {
+	values, err := srv.classificationValueRepository.Get(
+		&model.ClassificationValue{ClassificationId: classificationId},
+	)
+
+	if err != nil {
+		logger.Error(err.Error())
+	}
+
+	return values, err
+}
+
+
+// CreateClassification create new Classification record with its values.
+func (srv *classificationService) CreateClassification(sin dto.CreateClassification) error {
+	_, err := srv.classificationRepository.GetOne(&model.Classification{
+		ClassificationName: sin.ClassificationName, ProjectId: sin.ProjectId,
+	})
+	if err == nil {
+		return errs.NewUniqueConstraintError("classification_name")
+	}
+
+	if err = srv.validateValues(sin.Values); err != nil {
+		return err
+	}
+
+	tx, err := db.GetDB().Begin()
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	classification := sin.ToClassification()
+	if err = srv.classificationRepository.Insert(&classification, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	if err = srv.insertValues(classification.ClassificationId, sin.Values, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	return tx.Commit()
+}
+
+
+// UpdateClassification update Classification record and replace its values.
+func (srv *classificationService) UpdateClassification(sin dto.CreateClassification) error {
+	c, err := srv.classificationRepository.GetOne(&model.Classification{
+		ClassificationName: sin.ClassificationName, ProjectId: sin.ProjectId,
+	})
+	if err == nil && c.ClassificationId != sin.ClassificationId {
+		return errs.NewUniqueConstraintError("classification_name")
+	}
+
+	if err = srv.validateValues(sin.Values); err != nil {
+		return err
+	}
+
+	tx, err := db.GetDB().Begin()
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	classification := sin.ToClassification()
+	if err = srv.classificationRepository.Update(&classification, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = srv.classificationValueRepository.Delete(
+		&model.ClassificationValue{ClassificationId: sin.ClassificationId}, tx,
+	)
+	if err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	if err = srv.insertValues(sin.ClassificationId, sin.Values, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	return tx.Commit()
+}
+
+
+// DeleteClassification delete Classification record and its values.
+// (physical delete)
+// classifications bound to columns can not be deleted.
+func (srv *classificationService) DeleteClassification(classificationId int) error {
+	columns, err := srv.columnRepository.Get(&model.Column{ClassificationId: classificationId})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+	if len(columns) > 0 {
+		return errs.NewInvalidValueError("classification_id", "classification is used by columns.")
+	}
+
+	tx, err := db.GetDB().Begin()
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = srv.classificationRepository.Delete(
+		&model.Classification{ClassificationId: classificationId}, tx,
+	)
+	if err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = srv.classificationValueRepository.Delete(
+		&model.ClassificationValue{ClassificationId: classificationId}, tx,
+	)
+	if err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	return tx.Commit()
+}
+
+
+func (srv *classificationService) insertValues(
+	classificationId int, values []model.ClassificationValue, tx *sql.Tx,
+) error {
+	for _, v := range values {
+		v.ClassificationId = classificationId
+		if err := srv.classificationValueRepository.Insert(&v, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+
+var valueNamePattern = regexp.MustCompile("^[a-z][a-z0-9_]*$")
+
+// validateValues ValueName is used for generated constant names.
+func (srv *classificationService) validateValues(values []model.ClassificationValue) error {
+	if len(values) == 0 {
+		return errs.NewInvalidValueError("values", "values are required.")
+	}
+
+	codes := map[string]bool{}
+	names := map[string]bool{}
+	for _, v := range values {
+		if v.Code == "" {
+			return errs.NewInvalidValueError("values", "code is required.")
+		}
+		if !valueNamePattern.MatchString(v.ValueName) {
+			return errs.NewInvalidValueError("values", "name must be [a-z][a-z0-9_]*: " + v.ValueName)
+		}
+		if codes[v.Code] || names[v.ValueName] {
+			return errs.NewInvalidValueError("values", "duplicated: " + v.Code + "," + v.ValueName)
+		}
+		codes[v.Code] = true
+		names[v.ValueName] = true
+	}
+
+	return nil
+}
\ No newline at end of file
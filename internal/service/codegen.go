@@ -4,43 +4,139 @@ import (
 	"os"
 	"fmt"
 	"time"
+	"regexp"
 	"strconv"
 	"strings"
 	"os/exec"
 
 	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/core/errs"
 	"goat-cg/internal/core/logger"
 	"goat-cg/internal/core/utils"
+	"goat-cg/internal/dto"
 	"goat-cg/internal/model"
 	"goat-cg/internal/repository"
 )
 
 
 type CodegenService interface {
-	GenerateGoat(rdbms string, tableIds []int) string
+	GenerateGoat(rdbms string, tableIds, viewIds []int) string
+	GenerateOpenApi(projectName string, tableIds []int) string
+	GenerateTypeScript(tableIds []int, zod bool) string
+	GenerateProto(projectName string, tableIds []int, grpc, saveFields bool) string
+	GenerateGraphql(tableIds []int) string
+	GenerateJsonSchema(tableIds []int) string
+	GenerateOrm(rdbms, orm string, tableIds []int) string
+	GenerateSeed(rdbms, format string, n int, tableIds []int) string
+	Generate(generator string, in dto.Codegen) (string, error)
 }
 
 
 type codegenService struct {
 	columnRepository repository.ColumnRepository
 	tableRepository repository.TableRepository
+	checkRepository repository.CheckRepository
+	classificationRepository repository.ClassificationRepository
+	classificationValueRepository repository.ClassificationValueRepository
+	viewRepository repository.ViewRepository
+	viewColumnRepository repository.ViewColumnRepository
+	protoFieldRepository repository.ProtoFieldRepository
+	sampleRowRepository repository.SampleRowRepository
 }
 
 
 func NewCodegenService() CodegenService {
 	columnRepository := repository.NewColumnRepository()
 	tableRepository := repository.NewTableRepository()
-	return &codegenService{columnRepository, tableRepository}
+	checkRepository := repository.NewCheckRepository()
+	classificationRepository := repository.NewClassificationRepository()
+	classificationValueRepository := repository.NewClassificationValueRepository()
+	viewRepository := repository.NewViewRepository()
+	viewColumnRepository := repository.NewViewColumnRepository()
+	protoFieldRepository := repository.NewProtoFieldRepository()
+	sampleRowRepository := repository.NewSampleRowRepository()
+	return &codegenService{
+		columnRepository, 
+		tableRepository, 
+		checkRepository, 
+		classificationRepository, 
+		classificationValueRepository,
+		viewRepository,
+		viewColumnRepository,
+		protoFieldRepository,
+		sampleRowRepository,
+	}
 }
 
 
 // Generate goat source and return zip path.
 // param rdbms: "sqlite3" or "postgresql" 
-func (srv *codegenService) GenerateGoat(rdbms string, tableIds []int) string {
-	path := "./tmp/goat-" + time.Now().Format("2006-01-02-15-04-05") + 
+func (srv *codegenService) GenerateGoat(rdbms string, tableIds, viewIds []int) string {
+	return srv.generateArchive("goat", func(path string) {
+		srv.generateSource(rdbms, tableIds, viewIds, path)
+	})
+}
+
+
+// CODEGEN_GENERATORS generators of Generate. (same as the buttons of the codegen page)
+var CODEGEN_GENERATORS = []string{
+	"goat", "openapi", "typescript", "proto", "graphql", "jsonschema", "orm", "seed",
+}
+
+
+// Generate run the generator by name and return zip path. (API and CLI)
+func (srv *codegenService) Generate(generator string, in dto.Codegen) (string, error) {
+	switch generator {
+	case "goat", "orm", "seed":
+		if in.Rdbms != "sqlite3" && in.Rdbms != "postgresql" && in.Rdbms != "mysql" {
+			return "", errs.NewInvalidValueError("rdbms", "must be sqlite3, postgresql or mysql.")
+		}
+	}
+	if generator == "seed" && (in.SeedRows < 0 || in.SeedRows > 1000) {
+		return "", errs.NewInvalidValueError("seed_rows", "must be 0-1000.")
+	}
+
+	var fpath string
+	switch generator {
+	case "goat":
+		fpath = srv.GenerateGoat(in.Rdbms, in.TableIds, in.ViewIds)
+	case "openapi":
+		fpath = srv.GenerateOpenApi(in.ProjectName, in.TableIds)
+	case "typescript":
+		fpath = srv.GenerateTypeScript(in.TableIds, in.Zod)
+	case "proto":
+		fpath = srv.GenerateProto(in.ProjectName, in.TableIds, in.Grpc, in.SaveProtoFields)
+	case "graphql":
+		fpath = srv.GenerateGraphql(in.TableIds)
+	case "jsonschema":
+		fpath = srv.GenerateJsonSchema(in.TableIds)
+	case "orm":
+		if fpath = srv.GenerateOrm(in.Rdbms, in.Orm, in.TableIds); fpath == "" {
+			return "", errs.NewInvalidValueError("orm", "unknown orm.")
+		}
+	case "seed":
+		if fpath = srv.GenerateSeed(in.Rdbms, in.SeedFormat, in.SeedRows, in.TableIds); fpath == "" {
+			return "", errs.NewInvalidValueError("seed_format", "must be sql, csv or go.")
+		}
+	default:
+		return "", errs.NewInvalidValueError("generator", "unknown generator.")
+	}
+
+	if _, err := os.Stat(fpath); err != nil {
+		logger.Error(err.Error())
+		return "", err
+	}
+	return fpath, nil
+}
+
+
+// generateArchive generate files into "./tmp/<name>-<datetime>-<random>" and zip them.
+// return zip path.
+func (srv *codegenService) generateArchive(name string, generate func(path string)) string {
+	path := "./tmp/" + name + "-" + time.Now().Format("2006-01-02-15-04-05") + 
 		"-" + utils.RandomString(7)
 
-	srv.generateSource(rdbms, tableIds, path)
+	generate(path)
 
 	if err := exec.Command("zip", "-rm", path + ".zip", path).Run(); err != nil {
 		logger.Error(err.Error())
@@ -50,73 +146,178 @@ func (srv *codegenService) GenerateGoat(rdbms string, tableIds []int) string {
 }
 
 
-func (srv *codegenService) generateSource(rdbms string, tableIds []int, rootPath string) {
+func (srv *codegenService) generateSource(rdbms string, tableIds, viewIds []int, rootPath string) {
 	path := rootPath + "/scripts"
 	if err := os.MkdirAll(path, 0777); err != nil {
 		logger.Error(err.Error())
 		return
 	}
-	srv.generateScriptsSource(rdbms, tableIds, path)
+	srv.generateScriptsSource(rdbms, tableIds, viewIds, path)
 
 	path = rootPath + "/internal"
 	if err := os.MkdirAll(path, 0777); err != nil {
 		logger.Error(err.Error())
 		return
 	}
-	srv.generateInternalSource(rdbms, tableIds, path)
+	srv.generateInternalSource(rdbms, tableIds, viewIds, path)
 }
 
 
 //dataTypeMapSqlite3 map DataTypeCls and sqlite3 data types.
 var dataTypeMapSqlite3 = map[string]string {
 	constant.DATA_TYPE_CLS_SERIAL: "INTEGER PRIMARY KEY AUTOINCREMENT",
+	constant.DATA_TYPE_CLS_BIGSERIAL: "INTEGER PRIMARY KEY AUTOINCREMENT",
 	constant.DATA_TYPE_CLS_TEXT: "TEXT",
 	constant.DATA_TYPE_CLS_VARCHAR: "TEXT",
 	constant.DATA_TYPE_CLS_CHAR: "TEXT",
+	constant.DATA_TYPE_CLS_UUID: "TEXT",
+	constant.DATA_TYPE_CLS_ENUM: "TEXT",
 	constant.DATA_TYPE_CLS_INTEGER: "INTEGER",
+	constant.DATA_TYPE_CLS_BIGINT: "INTEGER",
+	constant.DATA_TYPE_CLS_SMALLINT: "INTEGER",
 	constant.DATA_TYPE_CLS_NUMERIC: "NUMERIC",
+	constant.DATA_TYPE_CLS_REAL: "REAL",
+	constant.DATA_TYPE_CLS_DOUBLE: "REAL",
 	constant.DATA_TYPE_CLS_TIMESTAMP: "TEXT",
 	constant.DATA_TYPE_CLS_DATE: "TEXT",
+	constant.DATA_TYPE_CLS_TIME: "TEXT",
+	constant.DATA_TYPE_CLS_TIMESTAMPTZ: "TEXT",
 	constant.DATA_TYPE_CLS_BLOB: "BLOB",
+	constant.DATA_TYPE_CLS_BOOLEAN: "INTEGER",
+	constant.DATA_TYPE_CLS_JSON: "TEXT",
+	constant.DATA_TYPE_CLS_JSONB: "TEXT",
 }
 
 //dataTypeMapPostgresql map DataTypeCls and postgresql data types.
+//ENUM columns use the type created by generateDdlCreateTypes.
 var dataTypeMapPostgresql = map[string]string{
 	constant.DATA_TYPE_CLS_SERIAL: "SERIAL PRIMARY KEY",
+	constant.DATA_TYPE_CLS_BIGSERIAL: "BIGSERIAL PRIMARY KEY",
 	constant.DATA_TYPE_CLS_TEXT: "TEXT",
 	constant.DATA_TYPE_CLS_VARCHAR: "VARCHAR",
 	constant.DATA_TYPE_CLS_CHAR: "CHAR",
+	constant.DATA_TYPE_CLS_UUID: "UUID",
+	constant.DATA_TYPE_CLS_ENUM: "",
 	constant.DATA_TYPE_CLS_INTEGER: "INTEGER",
+	constant.DATA_TYPE_CLS_BIGINT: "BIGINT",
+	constant.DATA_TYPE_CLS_SMALLINT: "SMALLINT",
 	constant.DATA_TYPE_CLS_NUMERIC: "NUMERIC",
+	constant.DATA_TYPE_CLS_REAL: "REAL",
+	constant.DATA_TYPE_CLS_DOUBLE: "DOUBLE PRECISION",
 	constant.DATA_TYPE_CLS_TIMESTAMP: "TIMESTAMP",
 	constant.DATA_TYPE_CLS_DATE: "DATE",
-	constant.DATA_TYPE_CLS_BLOB: "BLOB",
+	constant.DATA_TYPE_CLS_TIME: "TIME",
+	constant.DATA_TYPE_CLS_TIMESTAMPTZ: "TIMESTAMPTZ",
+	constant.DATA_TYPE_CLS_BLOB: "BYTEA",
+	constant.DATA_TYPE_CLS_BOOLEAN: "BOOLEAN",
+	constant.DATA_TYPE_CLS_JSON: "JSON",
+	constant.DATA_TYPE_CLS_JSONB: "JSONB",
 }
 
 //dataTypeMapMysql map DataTypeCls and mysql data types.
+//ENUM columns are written as ENUM('a','b',...).
 var dataTypeMapMysql = map[string]string{
 	constant.DATA_TYPE_CLS_SERIAL: "INT PRIMARY KEY AUTO_INCREMENT",
+	constant.DATA_TYPE_CLS_BIGSERIAL: "BIGINT PRIMARY KEY AUTO_INCREMENT",
 	constant.DATA_TYPE_CLS_TEXT: "TEXT",
 	constant.DATA_TYPE_CLS_VARCHAR: "VARCHAR",
 	constant.DATA_TYPE_CLS_CHAR: "CHAR",
+	constant.DATA_TYPE_CLS_UUID: "CHAR(36)",
+	constant.DATA_TYPE_CLS_ENUM: "ENUM",
 	constant.DATA_TYPE_CLS_INTEGER: "INT",
+	constant.DATA_TYPE_CLS_BIGINT: "BIGINT",
+	constant.DATA_TYPE_CLS_SMALLINT: "SMALLINT",
 	constant.DATA_TYPE_CLS_NUMERIC: "NUMERIC",
+	constant.DATA_TYPE_CLS_REAL: "FLOAT",
+	constant.DATA_TYPE_CLS_DOUBLE: "DOUBLE",
 	constant.DATA_TYPE_CLS_TIMESTAMP: "DATETIME",
 	constant.DATA_TYPE_CLS_DATE: "DATE",
+	constant.DATA_TYPE_CLS_TIME: "TIME",
+	constant.DATA_TYPE_CLS_TIMESTAMPTZ: "TIMESTAMP",
 	constant.DATA_TYPE_CLS_BLOB: "BLOB",
+	constant.DATA_TYPE_CLS_BOOLEAN: "BOOLEAN",
+	constant.DATA_TYPE_CLS_JSON: "JSON",
+	constant.DATA_TYPE_CLS_JSONB: "JSON",
 }
 
 //dbDataTypeGoTypeMap map DataTypeCls and Golang types.
 var dbDataTypeGoTypeMap = map[string]string{
 	constant.DATA_TYPE_CLS_SERIAL: "int",
+	constant.DATA_TYPE_CLS_BIGSERIAL: "int64",
 	constant.DATA_TYPE_CLS_TEXT: "string",
 	constant.DATA_TYPE_CLS_VARCHAR: "string",
 	constant.DATA_TYPE_CLS_CHAR: "string",
+	constant.DATA_TYPE_CLS_UUID: "string",
+	constant.DATA_TYPE_CLS_ENUM: "string",
 	constant.DATA_TYPE_CLS_INTEGER: "int",
+	constant.DATA_TYPE_CLS_BIGINT: "int64",
+	constant.DATA_TYPE_CLS_SMALLINT: "int16",
 	constant.DATA_TYPE_CLS_NUMERIC: "float64",
+	constant.DATA_TYPE_CLS_REAL: "float32",
+	constant.DATA_TYPE_CLS_DOUBLE: "float64",
 	constant.DATA_TYPE_CLS_TIMESTAMP: "string",
 	constant.DATA_TYPE_CLS_DATE: "string",
-	constant.DATA_TYPE_CLS_BLOB: "string",
+	constant.DATA_TYPE_CLS_TIME: "string",
+	constant.DATA_TYPE_CLS_TIMESTAMPTZ: "string",
+	constant.DATA_TYPE_CLS_BLOB: "[]byte",
+	constant.DATA_TYPE_CLS_BOOLEAN: "bool",
+	constant.DATA_TYPE_CLS_JSON: "string",
+	constant.DATA_TYPE_CLS_JSONB: "string",
+}
+
+
+//isSerialType DataTypeCls is auto increment (SERIAL / BIGSERIAL).
+func isSerialType(dataTypeCls string) bool {
+	return dataTypeCls == constant.DATA_TYPE_CLS_SERIAL ||
+		dataTypeCls == constant.DATA_TYPE_CLS_BIGSERIAL
+}
+
+
+//isNumericType DataTypeCls default values are written without quotes.
+func isNumericType(dataTypeCls string) bool {
+	switch dataTypeCls {
+	case constant.DATA_TYPE_CLS_INTEGER,
+		constant.DATA_TYPE_CLS_BIGINT,
+		constant.DATA_TYPE_CLS_SMALLINT,
+		constant.DATA_TYPE_CLS_NUMERIC,
+		constant.DATA_TYPE_CLS_REAL,
+		constant.DATA_TYPE_CLS_DOUBLE,
+		constant.DATA_TYPE_CLS_BOOLEAN:
+		return true
+	}
+	return false
+}
+
+
+//numberLiteralPattern numbers written to DDL and Go as they are.
+var numberLiteralPattern = regexp.MustCompile(`^[-+]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][-+]?[0-9]+)?$`)
+
+
+//isNumberLiteral values of checks on numeric columns must be numbers. (other values are quoted)
+func isNumberLiteral(value string) bool {
+	return numberLiteralPattern.MatchString(value)
+}
+
+
+//splitEnumValues "a, b,c" -> ["a", "b", "c"]
+func splitEnumValues(enumValues string) []string {
+	var ret []string
+	for _, v := range strings.Split(enumValues, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+
+//quoteSqlValues ["a", "b"] -> 'a','b'
+func quoteSqlValues(values []string) string {
+	var ls []string
+	for _, v := range values {
+		ls = append(ls, "'" + strings.ReplaceAll(v, "'", "''") + "'")
+	}
+	return strings.Join(ls, ",")
 }
 
 
@@ -146,27 +347,165 @@ func (srv *codegenService) extractPrimaryKeys(columns []model.Column) []model.Co
 }
 
 
+// isNullableColumn column is not NOT NULL nor PRIMARY KEY.
+func (srv *codegenService) isNullableColumn(column model.Column) bool {
+	return column.NotNullFlg != constant.FLG_ON && column.PrimaryKeyFlg != constant.FLG_ON
+}
+
+
 // generateScriptsSource generate ddl(create table) source.
 // main processing of GenerateDdl.
-func (srv *codegenService) generateScriptsSource(rdbms string, tableIds []int, path string) {
-	s := srv.generateDdlCreateTables(rdbms, tableIds) + "\n" +
+func (srv *codegenService) generateScriptsSource(rdbms string, tableIds, viewIds []int, path string) {
+	s := srv.generateDdlCreateTables(rdbms, tableIds, true) + "\n" +
+		srv.generateDdlCreateViews(rdbms, viewIds) +
 		srv.generateDdlCreateTriggers(rdbms, tableIds)
 
 	srv.writeFile(path + "/create-table.sql", s)
 }
 
 
-func (srv *codegenService) generateDdlCreateTables(rdbms string, tableIds []int) string {
+// guardTypes: the enum types are created only if they do not exist, so that the script can be run again.
+// (sqlc reads the types only from plain "CREATE TYPE")
+func (srv *codegenService) generateDdlCreateTables(rdbms string, tableIds []int, guardTypes bool) string {
+	s := ""
+	if rdbms == "postgresql" {
+		s += srv.generateDdlCreateTypes(tableIds, guardTypes)
+	}
+	for _, tid := range tableIds {
+		s += srv.generateDdlCreateTable(rdbms, tid, tableIds) + "\n\n"
+	}
+	if rdbms != "sqlite3" {
+		s += srv.generateDdlAddForeignKeys(tableIds)
+	}
+	for _, c := range srv.getBoundClassifications(tableIds) {
+		s += srv.generateDdlCreateClassificationTable(rdbms, c) + "\n\n"
+	}
+
+	return s
+}
+
+
+// generateDdlCreateViews generate "CREATE VIEW" after the tables.
+func (srv *codegenService) generateDdlCreateViews(rdbms string, viewIds []int) string {
+	s := ""
+	for _, vid := range viewIds {
+		view, err := srv.viewRepository.GetOne(&model.View{ViewId: vid})
+		if err != nil {
+			logger.Error(err.Error())
+			continue
+		}
+		s += srv.generateDdlCreateView(rdbms, view) + "\n\n"
+	}
+
+	return s
+}
+
+
+func (srv *codegenService) generateDdlCreateView(rdbms string, view model.View) string {
+	s := "CREATE OR REPLACE VIEW "
+	if rdbms == "sqlite3" {
+		s = "CREATE VIEW IF NOT EXISTS "
+	}
+	return s + view.ViewName + " AS\n" + 
+		strings.TrimRight(strings.TrimSpace(view.ViewSql), ";") + ";"
+}
+
+
+// getBoundClassifications get classifications bound to valid columns of the tables.
+func (srv *codegenService) getBoundClassifications(tableIds []int) []model.Classification {
+	var ret []model.Classification
+	done := map[int]bool{}
+
+	for _, tid := range tableIds {
+		columns, err := srv.getValidColumns(tid)
+		if err != nil {
+			continue
+		}
+		for _, col := range columns {
+			if col.ClassificationId == 0 || done[col.ClassificationId] {
+				continue
+			}
+			done[col.ClassificationId] = true
+
+			c, err := srv.classificationRepository.GetOne(
+				&model.Classification{ClassificationId: col.ClassificationId},
+			)
+			if err != nil {
+				logger.Error(err.Error())
+				continue
+			}
+			ret = append(ret, c)
+		}
+	}
+
+	return ret
+}
+
+
+// generateDdlCreateClassificationTable generate lookup table "<classification_name>_cls" 
+// and INSERT of the values.
+func (srv *codegenService) generateDdlCreateClassificationTable(rdbms string, c model.Classification) string {
+	tn := c.ClassificationName + "_cls"
+	s := "CREATE TABLE IF NOT EXISTS " + tn + " (\n"
+	if rdbms == "sqlite3" {
+		s += "\tcode TEXT PRIMARY KEY,\n\tname TEXT NOT NULL,\n\tlabel TEXT,\n\talign_seq INTEGER\n);\n"
+	} else {
+		s += "\tcode VARCHAR(50) PRIMARY KEY,\n\tname VARCHAR(50) NOT NULL,\n" +
+			"\tlabel VARCHAR(255),\n\talign_seq INTEGER\n);\n"
+	}
+
+	for _, v := range srv.getClassificationValues(c.ClassificationId) {
+		s += "INSERT INTO " + tn + " (code, name, label, align_seq) VALUES (" +
+			quoteSqlValues([]string{v.Code, v.ValueName, v.Label}) + ", " + 
+			strconv.Itoa(v.AlignSeq) + ");\n"
+	}
+
+	return strings.TrimRight(s, "\n")
+}
+
+
+// generateDdlCreateTypes generate "CREATE TYPE ... AS ENUM" for ENUM columns.
+// (postgresql only) guard: wrapped in DO blocks ignoring the types already created.
+func (srv *codegenService) generateDdlCreateTypes(tableIds []int, guard bool) string {
 	s := ""
 	for _, tid := range tableIds {
-		s += srv.generateDdlCreateTable(rdbms, tid) + "\n\n"
+		columns, err := srv.getValidColumns(tid)
+		if err != nil {
+			logger.Error(err.Error())
+			continue
+		}
+
+		for _, c := range columns {
+			if c.DataTypeCls != constant.DATA_TYPE_CLS_ENUM {
+				continue
+			}
+			ct := "CREATE TYPE " + srv.getEnumTypeName(c) + " AS ENUM (" +
+				quoteSqlValues(srv.getEnumValues(c)) + ");"
+			if guard {
+				s += "DO $$ BEGIN\n\t" + ct + "\nEXCEPTION\n\tWHEN duplicate_object THEN NULL;\nEND $$;\n\n"
+			} else {
+				s += ct + "\n\n"
+			}
+		}
 	}
 
 	return s
 }
 
 
-func (srv *codegenService) generateDdlCreateTable(rdbms string, tid int) string {
+// getEnumTypeName return postgresql enum type name. "<table_name>_<column_name>"
+func (srv *codegenService) getEnumTypeName(column model.Column) string {
+	table, err := srv.tableRepository.GetOne(&model.Table{TableId: column.TableId})
+	if err != nil {
+		logger.Error(err.Error())
+		return column.ColumnName
+	}
+
+	return table.TableName + "_" + column.ColumnName
+}
+
+
+func (srv *codegenService) generateDdlCreateTable(rdbms string, tid int, tableIds []int) string {
 	s := ""
 	table, err := srv.tableRepository.GetOne(&model.Table{TableId: tid})
 
@@ -176,7 +515,7 @@ func (srv *codegenService) generateDdlCreateTable(rdbms string, tid int) string
 	}
 
 	s += "CREATE TABLE IF NOT EXISTS " + table.TableName + " (\n" +
-		srv.generateDdlColumns(rdbms, tid) + "\n);"
+		srv.generateDdlColumns(rdbms, &table, tableIds) + "\n);"
 
 	return s
 }
@@ -200,9 +539,9 @@ func (srv *codegenService) getValidColumns(tid int) ([]model.Column, error) {
 }
 
 
-func (srv *codegenService) generateDdlColumns(rdbms string, tid int) string {
+func (srv *codegenService) generateDdlColumns(rdbms string, table *model.Table, tableIds []int) string {
 	s := ""
-	columns, err := srv.getValidColumns(tid)
+	columns, err := srv.getValidColumns(table.TableId)
 	if err != nil {
 		logger.Error(err.Error())
 		return s
@@ -211,13 +550,104 @@ func (srv *codegenService) generateDdlColumns(rdbms string, tid int) string {
 	for _, col := range columns {
 		s += srv.generateDdlColumn(rdbms, col)
 	}
+	if table.VersionFlg == constant.FLG_ON {
+		s += srv.generateDdlVersionColumn(rdbms)
+	}
 	s += srv.generateDdlCommonColumns(rdbms)
 	s += srv.generateDdlPrymaryKey(rdbms, columns)
+	s += srv.generateDdlChecks(rdbms, table.TableId, columns)
+	if rdbms == "sqlite3" {
+		for _, fk := range srv.getForeignKeys(columns, tableIds) {
+			s += "\t" + srv.generateDdlForeignKey(fk) + ",\n"
+		}
+	}
 
 	return strings.TrimRight(s, ",\n")
 }
 
 
+// foreignKey reference from a column to a column of a generated table.
+type foreignKey struct {
+	column model.Column
+	refTable model.Table
+	refColumn model.Column
+}
+
+
+// getForeignKeys get references of the columns to the tables in tableIds.
+// references to tables not generated together are ignored.
+func (srv *codegenService) getForeignKeys(columns []model.Column, tableIds []int) []foreignKey {
+	var ret []foreignKey
+	for _, col := range columns {
+		if col.RefColumnId == 0 {
+			continue
+		}
+		ref, err := srv.columnRepository.GetOne(&model.Column{ColumnId: col.RefColumnId})
+		if err != nil || ref.DelFlg == 1 {
+			continue
+		}
+		for _, tid := range tableIds {
+			if tid != ref.TableId {
+				continue
+			}
+			refTable, err := srv.tableRepository.GetOne(&model.Table{TableId: tid})
+			if err != nil {
+				logger.Error(err.Error())
+				break
+			}
+			ret = append(ret, foreignKey{col, refTable, ref})
+			break
+		}
+	}
+
+	return ret
+}
+
+
+// generateDdlForeignKey return "FOREIGN KEY(column) REFERENCES table(column)".
+func (srv *codegenService) generateDdlForeignKey(fk foreignKey) string {
+	return "FOREIGN KEY(" + fk.column.ColumnName + ") REFERENCES " + 
+		fk.refTable.TableName + "(" + fk.refColumn.ColumnName + ")"
+}
+
+
+// generateDdlAddForeignKeys generate "ALTER TABLE ... ADD CONSTRAINT ... FOREIGN KEY" 
+// after all tables are created. (postgresql, mysql)
+func (srv *codegenService) generateDdlAddForeignKeys(tableIds []int) string {
+	s := ""
+	for _, tid := range tableIds {
+		table, err := srv.tableRepository.GetOne(&model.Table{TableId: tid})
+		if err != nil {
+			logger.Error(err.Error())
+			continue
+		}
+		columns, err := srv.getValidColumns(tid)
+		if err != nil {
+			continue
+		}
+		for _, fk := range srv.getForeignKeys(columns, tableIds) {
+			s += "ALTER TABLE " + table.TableName + " ADD CONSTRAINT fk_" + 
+				table.TableName + "_" + fk.column.ColumnName + " " + 
+				srv.generateDdlForeignKey(fk) + ";\n"
+		}
+	}
+	if s != "" {
+		s += "\n"
+	}
+
+	return s
+}
+
+
+// generateDdlVersionColumn generate optimistic locking column.
+func (srv *codegenService) generateDdlVersionColumn(rdbms string) string {
+	if rdbms == "mysql" {
+		return "\tversion INT NOT NULL DEFAULT 0,\n"
+	}
+	return "\tversion INTEGER NOT NULL DEFAULT 0,\n"
+}
+
+
 func (srv *codegenService) generateDdlCommonColumns(rdbms string) string {
 	s := ""
 	if rdbms == "sqlite3" {
@@ -242,7 +672,7 @@ func (srv *codegenService) generateDdlPrymaryKey(rdbms string, columns []model.C
 	pkcolumns := srv.extractPrimaryKeys(columns)
 
 	for i, c := range pkcolumns {
-		if c.DataTypeCls == constant.DATA_TYPE_CLS_SERIAL {
+		if isSerialType(c.DataTypeCls) {
 			return ""
 		}
 		if i == 0 {
@@ -261,6 +691,100 @@ func (srv *codegenService) generateDdlPrymaryKey(rdbms string, columns []model.C
 }
 
 
+// getValidChecks get checks of the table with their target column.
+// checks on logically deleted columns are excluded.
+// (table level checks are returned with zero value Column)
+func (srv *codegenService) getValidChecks(tid int, columns []model.Column) ([]model.Check, []model.Column) {
+	checks, err := srv.checkRepository.Get(&model.Check{TableId: tid})
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, nil
+	}
+
+	var retChecks []model.Check
+	var retColumns []model.Column
+	for _, ck := range checks {
+		if ck.ColumnId == 0 {
+			retChecks = append(retChecks, ck)
+			retColumns = append(retColumns, model.Column{})
+			continue
+		}
+		for _, c := range columns {
+			if c.ColumnId == ck.ColumnId {
+				retChecks = append(retChecks, ck)
+				retColumns = append(retColumns, c)
+				break
+			}
+		}
+	}
+
+	return retChecks, retColumns
+}
+
+
+func (srv *codegenService) generateDdlChecks(rdbms string, tid int, columns []model.Column) string {
+	s := ""
+	checks, cols := srv.getValidChecks(tid, columns)
+
+	for i, ck := range checks {
+		if expr := srv.generateDdlCheckExpression(rdbms, ck, cols[i]); expr != "" {
+			s += "\tCONSTRAINT " + ck.CheckName + " CHECK (" + expr + "),\n"
+		}
+	}
+
+	return s
+}
+
+
+// generateDdlCheckExpression return the condition of CHECK constraint.
+// PATTERN is not supported by sqlite3 and returns "".
+func (srv *codegenService) generateDdlCheckExpression(rdbms string, check model.Check, column model.Column) string {
+	cn := column.ColumnName
+
+	switch check.CheckTypeCls {
+	case constant.CHECK_TYPE_CLS_RANGE:
+		var conds []string
+		if check.MinValue != "" {
+			conds = append(conds, cn + " >= " + srv.generateDdlCheckValue(column, check.MinValue))
+		}
+		if check.MaxValue != "" {
+			conds = append(conds, cn + " <= " + srv.generateDdlCheckValue(column, check.MaxValue))
+		}
+		return strings.Join(conds, " AND ")
+
+	case constant.CHECK_TYPE_CLS_PATTERN:
+		if rdbms == "postgresql" {
+			return cn + " ~ " + quoteSqlValues([]string{check.Pattern})
+		} else if rdbms == "mysql" {
+			return "REGEXP_LIKE(" + cn + ", " + quoteSqlValues([]string{check.Pattern}) + ")"
+		}
+		return ""
+
+	case constant.CHECK_TYPE_CLS_IN:
+		var values []string
+		for _, v := range splitAllowedValues(check.AllowedValues, isNumericType(column.DataTypeCls)) {
+			values = append(values, srv.generateDdlCheckValue(column, v))
+		}
+		return cn + " IN (" + strings.Join(values, ",") + ")"
+
+	case constant.CHECK_TYPE_CLS_EXPRESSION:
+		return check.Expression
+	}
+
+	return ""
+}
+
+
+// generateDdlCheckValue numbers of numeric columns as they are, others quoted.
+// (values checked against an earlier data type of the column are quoted too)
+func (srv *codegenService) generateDdlCheckValue(column model.Column, value string) string {
+	if isNumericType(column.DataTypeCls) && isNumberLiteral(value) {
+		return value
+	}
+	return quoteSqlValues([]string{value})
+}
+
+
 func (srv *codegenService) generateDdlColumn(rdbms string, column model.Column) string {
 	s := "\t" + column.ColumnName + " " + srv.generateDdlColumnDataType(rdbms, column)
 	if cts := srv.generateDdlColumnConstraints(column); cts != "" {
@@ -280,18 +804,34 @@ func (srv *codegenService) generateDdlColumnConstraints(column model.Column) str
 		s += "NOT NULL "
 	}
 	if column.UniqueFlg == constant.FLG_ON {
-		s += "UNIQUE"
+		s += "UNIQUE "
 	} 
+	if column.ClassificationId != 0 && column.DataTypeCls != constant.DATA_TYPE_CLS_ENUM {
+		s += srv.generateDdlClassificationCheck(column)
+	}
 	
 	return strings.TrimRight(s, " ")
 }
 
 
+// generateDdlClassificationCheck return "CHECK(column IN (codes...))".
+// (ENUM columns use the codes as enum values instead)
+func (srv *codegenService) generateDdlClassificationCheck(column model.Column) string {
+	values := srv.getEnumValues(column)
+	if len(values) == 0 {
+		return ""
+	}
+	if isNumericType(column.DataTypeCls) {
+		return "CHECK(" + column.ColumnName + " IN (" + strings.Join(values, ",") + "))"
+	}
+	return "CHECK(" + column.ColumnName + " IN (" + quoteSqlValues(values) + "))"
+}
+
+
 func (srv *codegenService) generateDdlColumnDefault(column model.Column) string {
 	s := ""
 	if column.DefaultValue != "" {
-		if column.DataTypeCls == constant.DATA_TYPE_CLS_NUMERIC ||
-		column.DataTypeCls == constant.DATA_TYPE_CLS_INTEGER {
+		if isNumericType(column.DataTypeCls) {
 			s = "DEFAULT " + column.DefaultValue
 		} else {
 			s = "DEFAULT '" + column.DefaultValue + "'"
@@ -305,7 +845,7 @@ func (srv *codegenService) generateDdlColumnDefault(column model.Column) string
 func (srv *codegenService) generateDdlColumnDataType(rdbms string, column model.Column) string {
 	s := ""
 	if rdbms == "sqlite3" {
-		s = dataTypeMapSqlite3[column.DataTypeCls]
+		s = srv.generateDdlColumnDataTypeSqlite3(column)
 
 	} else if rdbms == "postgresql" {
 		s = srv.generateDdlColumnDataTypePostgresql(column)
@@ -318,9 +858,51 @@ func (srv *codegenService) generateDdlColumnDataType(rdbms string, column model.
 }
 
 
+// generateDdlColumnDataTypeSqlite3 ENUM is TEXT with CHECK constraint.
+// getEnumValues return codes of the bound classification or EnumValues.
+func (srv *codegenService) getEnumValues(column model.Column) []string {
+	if column.ClassificationId == 0 {
+		return splitEnumValues(column.EnumValues)
+	}
+
+	var ret []string
+	for _, v := range srv.getClassificationValues(column.ClassificationId) {
+		ret = append(ret, v.Code)
+	}
+	return ret
+}
+
+
+func (srv *codegenService) getClassificationValues(classificationId int) []model.ClassificationValue {
+	values, err := srv.classificationValueRepository.Get(
+		&model.ClassificationValue{ClassificationId: classificationId},
+	)
+	if err != nil {
+		logger.Error(err.Error())
+	}
+	return values
+}
+
+
+func (srv *codegenService) generateDdlColumnDataTypeSqlite3(column model.Column) string {
+	s := dataTypeMapSqlite3[column.DataTypeCls]
+
+	if column.DataTypeCls == constant.DATA_TYPE_CLS_ENUM {
+		s += " CHECK(" + column.ColumnName + " IN (" + 
+			quoteSqlValues(srv.getEnumValues(column)) + "))"
+	}
+
+	return s
+}
+
+
 func (srv *codegenService) generateDdlColumnDataTypePostgresql(column model.Column) string {
 	s := dataTypeMapPostgresql[column.DataTypeCls]
 
+	if column.DataTypeCls == constant.DATA_TYPE_CLS_ENUM {
+		return srv.getEnumTypeName(column)
+	}
+
 	if column.DataTypeCls == constant.DATA_TYPE_CLS_VARCHAR || 
 	column.DataTypeCls == constant.DATA_TYPE_CLS_CHAR {
 		if column.Precision != 0 {
@@ -340,6 +922,10 @@ func (srv *codegenService) generateDdlColumnDataTypePostgresql(column model.Colu
 func (srv *codegenService) generateDdlColumnDataTypeMysql(column model.Column) string {
 	s := dataTypeMapMysql[column.DataTypeCls]
 
+	if column.DataTypeCls == constant.DATA_TYPE_CLS_ENUM {
+		return s + "(" + quoteSqlValues(srv.getEnumValues(column)) + ")"
+	}
+
 	if column.DataTypeCls == constant.DATA_TYPE_CLS_VARCHAR || 
 	column.DataTypeCls == constant.DATA_TYPE_CLS_CHAR {
 		if column.Precision != 0 {
@@ -439,7 +1025,7 @@ func GetSnakeInitial(snake string) string {
 }
 
 
-func (srv *codegenService) generateInternalSource(rdbms string, tableIds []int, path string) {
+func (srv *codegenService) generateInternalSource(rdbms string, tableIds, viewIds []int, path string) {
 	modelPath := path + "/model"
 	if err := os.MkdirAll(modelPath, 0777); err != nil {
 		logger.Error(err.Error())
@@ -452,12 +1038,16 @@ func (srv *codegenService) generateInternalSource(rdbms string, tableIds []int,
 		return
 	}
 
+	versioned := false
 	for _, tid := range tableIds {
 		table, err := srv.tableRepository.GetOne(&model.Table{TableId: tid})
 		if err != nil {
 			logger.Error(err.Error())
 			break
 		}
+		if table.VersionFlg == constant.FLG_ON {
+			versioned = true
+		}
 
 		columns, err := srv.getValidColumns(tid)
 		if err != nil {
@@ -468,6 +1058,74 @@ func (srv *codegenService) generateInternalSource(rdbms string, tableIds []int,
 		srv.generateModelFile(&table, columns, modelPath)
 		srv.generateRepositoryFile(rdbms, &table, columns, repositoryPath)
 	}
+
+	for _, vid := range viewIds {
+		view, err := srv.viewRepository.GetOne(&model.View{ViewId: vid})
+		if err != nil {
+			logger.Error(err.Error())
+			break
+		}
+
+		columns, err := srv.getViewColumns(vid)
+		if err != nil {
+			logger.Error(err.Error())
+			break
+		}
+
+		srv.generateViewModelFile(&view, columns, modelPath)
+		srv.generateViewRepositoryFile(&view, columns, repositoryPath)
+	}
+
+	if versioned {
+		srv.writeFile(repositoryPath + "/errors.go", srv.generateRepositoryErrorsCode())
+	}
+
+	if classifications := srv.getBoundClassifications(tableIds); len(classifications) > 0 {
+		constantPath := path + "/shared/constant"
+		if err := os.MkdirAll(constantPath, 0777); err != nil {
+			logger.Error(err.Error())
+			return
+		}
+		srv.writeFile(constantPath + "/classification.go", srv.generateConstantCode(classifications))
+	}
+}
+
+
+// generateConstantCode generate classification constants. 
+// "<CLASSIFICATION_NAME>_<VALUE_NAME> = "<code>""
+func (srv *codegenService) generateConstantCode(classifications []model.Classification) string {
+	s := "package constant\n\n"
+
+	for _, c := range classifications {
+		cn := strings.ToUpper(c.ClassificationName)
+		s += "\n//" + cn
+		if c.ClassificationNameLogical != "" {
+			s += " " + c.ClassificationNameLogical
+		}
+		s += "\nconst (\n"
+		for _, v := range srv.getClassificationValues(c.ClassificationId) {
+			s += "\t" + cn + "_" + strings.ToUpper(v.ValueName) + " = " + strconv.Quote(v.Code)
+			if v.Label != "" {
+				s += " //" + v.Label
+			}
+			s += "\n"
+		}
+		s += ")\n"
+	}
+
+	return strings.TrimRight(s, "\n")
+}
+
+
+// generateRepositoryErrorsCode generate errors returned by generated repositories.
+// ErrConflict is returned by Update of tables with optimistic locking.
+func (srv *codegenService) generateRepositoryErrorsCode() string {
+	return "package repository\n\nimport (\n\t\"fmt\"\n)\n\n\n" +
+		"// ErrConflict is returned by Update when the row was changed by another writer\n" +
+		"// since it was read (version mismatch).\n" +
+		"type ErrConflict struct {\n\tTable string\n}\n\n" +
+		"func (e ErrConflict) Error() string {\n" +
+		"\treturn fmt.Sprintf(\"ErrConflict: %s\", e.Table)\n}"
 }
 
 
@@ -491,10 +1149,258 @@ func (srv *codegenService) generateModelCode(table *model.Table, columns []model
 			strings.ToLower(c.ColumnName),
 		)
 	}
+	if table.VersionFlg == constant.FLG_ON {
+		s += "\tVersion int `db:\"version\" json:\"version\"`\n"
+	}
 	s += "\tCreatedAt string `db:\"created_at\" json:\"created_at\"`\n"
 	s += "\tUpdatedAt string `db:\"updated_at\" json:\"updated_at\"`\n"
+	s += "}"
+
+	checks, cols := srv.getValidChecks(table.TableId, columns)
+	if len(checks) == 0 {
+		return s
+	}
+
+	vars, body := "", ""
+	imports := map[string]bool{}
+	for i, ck := range checks {
+		v, b := srv.generateModelValidateCheck(table, ck, cols[i])
+		vars += v
+		body += b
+		if v != "" {
+			imports["regexp"] = true
+		}
+		if strings.Contains(b, "errors.New") {
+			imports["errors"] = true
+		}
+	}
+
+	head := "package model\n\n"
+	if len(imports) > 0 {
+		head += "import (\n"
+		for _, pkg := range []string{"errors", "regexp"} {
+			if imports[pkg] {
+				head += "\t\"" + pkg + "\"\n"
+			}
+		}
+		head += ")\n\n"
+	}
+	if vars != "" {
+		head += "\nvar (\n" + vars + ")\n\n"
+	}
+	s = head + strings.TrimPrefix(s, "package model\n\n")
+
+	tni := GetSnakeInitial(table.TableName)
+	s += "\n\n\n// Validate check the rules defined as CHECK constraints.\n" +
+		fmt.Sprintf("func (%s *%s) Validate() error {\n", tni, SnakeToPascal(table.TableName)) +
+		body + "\treturn nil\n}"
+
+	return s
+}
+
+
+// generateModelValidateCheck return (package level var, statements in Validate) of a check.
+// EXPRESSION checks are left to the database.
+// NULL passes CHECK constraints, so empty string of nullable column is not validated.
+func (srv *codegenService) generateModelValidateCheck(
+	table *model.Table, check model.Check, column model.Column,
+) (string, string) {
+	if check.CheckTypeCls == constant.CHECK_TYPE_CLS_EXPRESSION {
+		return "", fmt.Sprintf("\t// %s: %s (checked by database)\n", check.CheckName, check.Expression)
+	}
+
+	goType := dbDataTypeGoTypeMap[column.DataTypeCls]
+	isNumber := strings.HasPrefix(goType, "int") || strings.HasPrefix(goType, "float")
+	if !isNumber && goType != "string" || isNumber && check.CheckTypeCls == constant.CHECK_TYPE_CLS_PATTERN {
+		return "", fmt.Sprintf("\t// %s: not validated (%s)\n", check.CheckName, goType)
+	}
+
+	// values checked against an earlier data type of the column are left to the database.
+	values := splitAllowedValues(check.AllowedValues, isNumber)
+	if isNumber {
+		literals := values
+		if check.CheckTypeCls == constant.CHECK_TYPE_CLS_RANGE {
+			literals = []string{check.MinValue, check.MaxValue}
+		}
+		for _, v := range literals {
+			if v != "" && !isNumberLiteral(v) {
+				return "", fmt.Sprintf("\t// %s: not validated (%s is not a number)\n", check.CheckName, v)
+			}
+		}
+	}
+
+	field := GetSnakeInitial(table.TableName) + "." + SnakeToPascal(column.ColumnName)
+	value := field
+	if isNumber {
+		value = "float64(" + field + ")"
+	}
+	literal := func(v string) string {
+		if isNumber {
+			return v
+		}
+		return strconv.Quote(v)
+	}
+	errorf := func(msg string) string {
+		return "\t\treturn errors.New(" + strconv.Quote(check.CheckName + ": " + column.ColumnName + " " + msg) + ")\n"
+	}
+
+	body := ""
+	varCode := ""
+	switch check.CheckTypeCls {
+	case constant.CHECK_TYPE_CLS_RANGE:
+		var conds []string
+		if check.MinValue != "" {
+			conds = append(conds, value + " < " + literal(check.MinValue))
+		}
+		if check.MaxValue != "" {
+			conds = append(conds, value + " > " + literal(check.MaxValue))
+		}
+		msg := "must be between " + check.MinValue + " and " + check.MaxValue
+		if check.MaxValue == "" {
+			msg = "must be >= " + check.MinValue
+		} else if check.MinValue == "" {
+			msg = "must be <= " + check.MaxValue
+		}
+		body = "\tif " + strings.Join(conds, " || ") + " {\n" + errorf(msg) + "\t}\n"
+
+	case constant.CHECK_TYPE_CLS_PATTERN:
+		v := SnakeToCamel(table.TableName) + SnakeToPascal(check.CheckName) + "Pattern"
+		varCode = "\t" + v + " = regexp.MustCompile(" + strconv.Quote(check.Pattern) + ")\n"
+		body = "\tif !" + v + ".MatchString(" + field + ") {\n" + errorf("must match " + check.Pattern) + "\t}\n"
+
+	case constant.CHECK_TYPE_CLS_IN:
+		var ls []string
+		for _, v := range values {
+			ls = append(ls, literal(v))
+		}
+		body = "\tswitch " + value + " {\n\tcase " + strings.Join(ls, ", ") + ":\n\tdefault:\n" +
+			errorf("must be one of " + strings.Join(values, ", ")) + "\t}\n"
+	}
+
+	if !isNumber && column.NotNullFlg != constant.FLG_ON {
+		body = "\tif " + field + " != \"\" {\n\t" +
+			strings.ReplaceAll(strings.TrimSuffix(body, "\n"), "\n", "\n\t") + "\n\t}\n"
+	}
+
+	return varCode, body
+}
+
+
+// getViewColumns get result columns of the view as model.Column
+// so that table code generators can be used.
+func (srv *codegenService) getViewColumns(vid int) ([]model.Column, error) {
+	viewColumns, err := srv.viewColumnRepository.Get(&model.ViewColumn{ViewId: vid})
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []model.Column
+	for _, vc := range viewColumns {
+		ret = append(ret, model.Column{ColumnName: vc.ColumnName, DataTypeCls: vc.DataTypeCls})
+	}
+	return ret, nil
+}
+
+
+func (srv *codegenService) generateViewModelFile(view *model.View, columns []model.Column, path string) {
+	path += "/" + srv.tableNameToFileName(view.ViewName)
+	srv.writeFile(path, srv.generateViewModelCode(view, columns))
+}
+
+
+// generateViewModelCode generate model of the view. (no created_at, updated_at)
+func (srv *codegenService) generateViewModelCode(view *model.View, columns []model.Column) string {
+	s := "package model\n\n\n"
+	if view.ViewNameLogical != "" {
+		s += "// " + SnakeToPascal(view.ViewName) + " " + view.ViewNameLogical + " (view)\n"
+	}
+
+	s += fmt.Sprintf("type %s struct {\n", SnakeToPascal(view.ViewName))
+	for _, c := range columns {
+		s += fmt.Sprintf(
+			"\t%s %s `db:\"%s\" json:\"%s\"`\n", 
+			SnakeToPascal(c.ColumnName),
+			dbDataTypeGoTypeMap[c.DataTypeCls],
+			strings.ToLower(c.ColumnName),
+			strings.ToLower(c.ColumnName),
+		)
+	}
+	s += "}"
+
+	return s
+}
+
 
-	return s + "}"
+func (srv *codegenService) generateViewRepositoryFile(view *model.View, columns []model.Column, path string) {
+	path += "/" + srv.tableNameToFileName(view.ViewName)
+	srv.writeFile(path, srv.generateViewRepositoryCode(view, columns))
+}
+
+
+// generateViewRepositoryCode generate read-only repository of the view. (Get, GetOne)
+func (srv *codegenService) generateViewRepositoryCode(view *model.View, columns []model.Column) string {
+	vn := view.ViewName
+	vnc := SnakeToCamel(vn)
+	vnp := SnakeToPascal(vn)
+	vni := GetSnakeInitial(vn)
+
+	s := "package repository\n\n\nimport (\n" + 
+		"\t\"database/sql\"\n\n\t\"xxxxx/internal/core/db\"\n\t\"xxxxx/internal/model\"\n)\n\n\n"
+
+	s += fmt.Sprintf("type %sRepository interface {\n", vnp) +
+		fmt.Sprintf("\tGet(%s *model.%s) ([]model.%s, error)\n", vni, vnp, vnp) +
+		fmt.Sprintf("\tGetOne(%s *model.%s) (model.%s, error)\n", vni, vnp, vnp) +
+		"}\n"
+
+	s += "\n\n" +
+		fmt.Sprintf("type %sRepository struct {\n\tdb *sql.DB\n}\n\n\n", vnc) +
+		fmt.Sprintf("func New%sRepository() *%sRepository {\n", vnp, vnc) +
+		fmt.Sprintf("\tdb := db.GetDB()\n\treturn &%sRepository{db}\n}\n\n\n", vnc)
+
+	selectList := ""
+	scanList := ""
+	for i, c := range columns {
+		if i == 0 {
+			selectList += "\t\t" + c.ColumnName
+		} else {
+			selectList += "\n\t\t," + c.ColumnName
+		}
+		scanList += fmt.Sprintf("\t\t&%s.%s,\n", vni, SnakeToPascal(c.ColumnName))
+	}
+
+	// Get
+	s += fmt.Sprintf(
+		"func (rep *%sRepository) Get(%s *model.%s) ([]model.%s, error) {\n", 
+		vnc, vni, vnp, vnp,
+	)
+	s += fmt.Sprintf("\twhere, binds := db.BuildWhereClause(%s)\n", vni)
+	s += "\tquery :=\n\t`SELECT\n" + selectList
+	s += fmt.Sprintf("\n\t FROM %s ` + where\n\n", vn)
+	s += "\trows, err := rep.db.Query(query, binds...)\n"
+	s += "\tdefer rows.Close()\n\n"
+	s += fmt.Sprintf("\tif err != nil {\n\t\treturn []model.%s{}, err\n\t}\n\n", vnp)
+	s += fmt.Sprintf("\tret := []model.%s{}\n", vnp)
+	s += "\tfor rows.Next() {\n"
+	s += fmt.Sprintf("\t\t%s := model.%s{}\n\t\terr = rows.Scan(\n", vni, vnp)
+	s += strings.ReplaceAll(scanList, "\t\t&", "\t\t\t&")
+	s += fmt.Sprintf("\t\t)\n\t\tif err != nil {\n\t\t\treturn []model.%s{}, err\n\t\t}\n", vnp)
+	s += fmt.Sprintf("\t\tret = append(ret, %s)\n", vni)
+	s += "\t}\n\n\treturn ret, nil\n}\n\n\n"
+
+	// GetOne
+	s += fmt.Sprintf(
+		"func (rep *%sRepository) GetOne(%s *model.%s) (model.%s, error) {\n", 
+		vnc, vni, vnp, vnp,
+	)
+	s += fmt.Sprintf("\twhere, binds := db.BuildWhereClause(%s)\n", vni)
+	s += "\tquery :=\n\t`SELECT\n" + selectList
+	s += fmt.Sprintf("\n\t FROM %s ` + where\n\n", vn)
+	s += fmt.Sprintf("\tret := model.%s{}\n", vnp)
+	s += "\terr := rep.db.QueryRow(query, binds...).Scan(\n"
+	s += strings.ReplaceAll(scanList, "&" + vni + ".", "&ret.")
+	s += "\t)\n\n\treturn ret, err\n}"
+
+	return s
 }
 
 
@@ -548,7 +1454,7 @@ func (srv *codegenService) generateRepositoryInterfaceCode(table *model.Table, c
 		fmt.Sprintf("\tUpdate(%s *model.%s, tx *sql.Tx) error\n", tni, tnp) +
 		fmt.Sprintf("\tDelete(%s *model.%s, tx *sql.Tx) error\n", tni, tnp)
 	} else {
-		s += fmt.Sprintf("\tInsert(%s *model.%s) error\n", tni, tnp,)
+		s += fmt.Sprintf("\tInsert(%s *model.%s, tx *sql.Tx) error\n", tni, tnp)
 	}
 	s += "}\n"
 	return s
@@ -580,6 +1486,9 @@ func (srv *codegenService) generateRepositoryGet(table *model.Table, columns []m
 			s += fmt.Sprintf("\n\t\t,%s", c.ColumnName)
 		}
 	}
+	if table.VersionFlg == constant.FLG_ON {
+		s += "\n\t\t,version"
+	}
 	s += "\n\t\t,created_at\n\t\t,updated_at" 
 	s += fmt.Sprintf("\n\t FROM %s ` + where\n\n", tn)
 
@@ -593,6 +1502,9 @@ func (srv *codegenService) generateRepositoryGet(table *model.Table, columns []m
 	for _, c := range columns {
 		s += fmt.Sprintf("\t\t\t&%s.%s,\n", tni, SnakeToPascal(c.ColumnName))
 	}
+	if table.VersionFlg == constant.FLG_ON {
+		s += fmt.Sprintf("\t\t\t&%s.Version,\n", tni)
+	}
 	s += fmt.Sprintf("\t\t\t&%s.CreatedAt,\n", tni) + fmt.Sprintf("\t\t\t&%s.UpdatedAt,\n", tni)
 
 	s += fmt.Sprintf("\t\t)\n\t\tif err != nil {\n\t\t\treturn []model.%s{}, err\n\t\t}\n", tnp)
@@ -630,12 +1542,18 @@ func (srv *codegenService) generateRepositoryGetOne(
 			s += fmt.Sprintf("\n\t\t,%s", c.ColumnName)
 		}
 	}
+	if table.VersionFlg == constant.FLG_ON {
+		s += "\n\t\t,version"
+	}
 	s += "\n\t\t,created_at\n\t\t,updated_at" 
 	s += fmt.Sprintf("\n\t FROM %s ` + where\n\n", tn)
 	s += "\terr := rep.db.QueryRow(query, binds...).Scan(\n"
 	for _, c := range columns {
 		s += fmt.Sprintf("\t\t&ret.%s,\n", SnakeToPascal(c.ColumnName))
 	}
+	if table.VersionFlg == constant.FLG_ON {
+		s += "\t\t&ret.Version,\n"
+	}
 	s += "\t\t&ret.CreatedAt,\n\t\t&ret.UpdatedAt,\n"
 	s += "\t)\n\n\treturn ret, err\n}"
 
@@ -680,7 +1598,7 @@ func (srv *codegenService) generateRepositoryInsert(
 
 	bindCount := 0
 	for _, c := range columns {
-		if c.DataTypeCls != constant.DATA_TYPE_CLS_SERIAL {
+		if !isSerialType(c.DataTypeCls) {
 			bindCount += 1
 			if bindCount == 1 {
 				s += fmt.Sprintf("\t\t%s", c.ColumnName)
@@ -694,7 +1612,7 @@ func (srv *codegenService) generateRepositoryInsert(
 	s += "\tbinds := []interface{}{\n"
 
 	for _, c := range columns {
-		if c.DataTypeCls != constant.DATA_TYPE_CLS_SERIAL {
+		if !isSerialType(c.DataTypeCls) {
 			s += fmt.Sprintf("\t\t%s.%s,\n", tni, SnakeToPascal(c.ColumnName))
 		}
 	}
@@ -724,7 +1642,7 @@ func (srv *codegenService) generateRepositoryUpdate(
 
 	bindCount := 0
 	for _, c := range columns {
-		if c.DataTypeCls != constant.DATA_TYPE_CLS_SERIAL && 
+		if !isSerialType(c.DataTypeCls) && 
 		c.PrimaryKeyFlg != constant.FLG_ON {
 			bindCount += 1
 			if bindCount == 1 {
@@ -734,6 +1652,10 @@ func (srv *codegenService) generateRepositoryUpdate(
 			}
 		}
 	}
+	versioned := table.VersionFlg == constant.FLG_ON
+	if versioned {
+		s += "\t\t,version = version + 1\n"
+	}
 	s += "\t WHERE "
 	isFirst := true
 	for _, c := range columns {
@@ -747,10 +1669,14 @@ func (srv *codegenService) generateRepositoryUpdate(
 			}
 		}
 	}
+	if versioned {
+		bindCount += 1
+		s += fmt.Sprintf("\n\t   AND version = %s", srv.getBindVar(rdbms, bindCount))
+	}
 	s += "`\n\tbinds := []interface{}{\n"
 
 	for _, c := range columns {
-		if c.DataTypeCls != constant.DATA_TYPE_CLS_SERIAL && 
+		if !isSerialType(c.DataTypeCls) && 
 		c.PrimaryKeyFlg != constant.FLG_ON {
 			s += fmt.Sprintf("\t\t%s.%s,\n", tni, SnakeToPascal(c.ColumnName))
 		}
@@ -760,6 +1686,11 @@ func (srv *codegenService) generateRepositoryUpdate(
 			s += fmt.Sprintf("\t\t%s.%s,\n", tni, SnakeToPascal(c.ColumnName))
 		}
 	}
+	if versioned {
+		s += fmt.Sprintf("\t\t%s.Version,\n", tni)
+		s += srv.generateRepositoryUpdateVersionedExec(table)
+		return s
+	}
 	s += "\t}\n\n\tvar err error\n\tif tx != nil {\n\t\t_, err = tx.Exec(cmd, binds...)\n"
 	s += "\t} else {\n\t\t_, err = rep.db.Exec(cmd, binds...)\n\t}\n\n"
 	s += "\treturn err\n}"
@@ -768,6 +1699,26 @@ func (srv *codegenService) generateRepositoryUpdate(
 }
 
 
+// generateRepositoryUpdateVersionedExec generate the exec part of 'Update'
+// for tables with optimistic locking.
+// returns ErrConflict when no row matched the version, otherwise increments Version.
+func (srv *codegenService) generateRepositoryUpdateVersionedExec(table *model.Table) string {
+	tni := GetSnakeInitial(table.TableName)
+
+	s := "\t}\n\n\tvar res sql.Result\n\tvar err error\n"
+	s += "\tif tx != nil {\n\t\tres, err = tx.Exec(cmd, binds...)\n"
+	s += "\t} else {\n\t\tres, err = rep.db.Exec(cmd, binds...)\n\t}\n"
+	s += "\tif err != nil {\n\t\treturn err\n\t}\n\n"
+	s += "\tn, err := res.RowsAffected()\n"
+	s += "\tif err != nil {\n\t\treturn err\n\t}\n"
+	s += fmt.Sprintf("\tif n == 0 {\n\t\treturn ErrConflict{Table: \"%s\"}\n\t}\n\n", table.TableName)
+	s += fmt.Sprintf("\t%s.Version += 1\n\n", tni)
+	s += "\treturn nil\n}"
+
+	return s
+}
+
+
 // generateRepositoryDelete generate repository function 'Delete'.
 // return "func (ur *userRepository) Delete(u *model.User) error {...}"
 func (srv *codegenService) generateRepositoryDelete(
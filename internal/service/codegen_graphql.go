@@ -0,0 +1,602 @@
+package service
+
+import (
+	"os"
+	"fmt"
+	"strings"
+
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/core/logger"
+	"goat-cg/internal/model"
+)
+
+
+// GenerateGraphql generate GraphQL schema and gqlgen resolver skeleton and return zip path.
+// resolvers call the repositories of GOAT source. (xxxxx/internal/repository)
+func (srv *codegenService) GenerateGraphql(tableIds []int) string {
+	return srv.generateArchive("graphql", func(path string) {
+		if err := os.MkdirAll(path + "/graph", 0777); err != nil {
+			logger.Error(err.Error())
+			return
+		}
+
+		tables := srv.getGraphqlTables(tableIds)
+		srv.writeFile(path + "/gqlgen.yml", gqlgenYml)
+		srv.writeFile(path + "/graph/schema.graphqls", srv.generateGraphqlSchema(tables))
+		srv.writeFile(path + "/graph/resolver.go", srv.generateGraphqlResolverCode(tables))
+		srv.writeFile(path + "/graph/schema.resolvers.go", srv.generateGraphqlResolversCode(tables))
+		srv.writeFile(path + "/graph/input.go", srv.generateGraphqlInputCode(tables))
+		srv.writeFile(path + "/graph/pagination.go", graphqlPaginationCode)
+	})
+}
+
+
+const gqlgenYml = `schema:
+  - graph/*.graphqls
+
+exec:
+  filename: graph/generated.go
+  package: graph
+
+model:
+  filename: graph/gqlmodel/models_gen.go
+  package: gqlmodel
+
+resolver:
+  layout: follow-schema
+  dir: graph
+  package: graph
+  filename_template: "{name}.resolvers.go"
+
+autobind:
+  - "xxxxx/internal/model"
+
+models:
+  Int:
+    model:
+      - github.com/99designs/gqlgen/graphql.Int
+      - github.com/99designs/gqlgen/graphql.Int32
+      - github.com/99designs/gqlgen/graphql.Int64
+`
+
+const graphqlPaginationCode = `package graph
+
+import (
+	"strconv"
+	"encoding/base64"
+)
+
+
+// pageRange return [start, end) of rows for "first" and "after" arguments.
+// cursor is base64 of the row index.
+func pageRange(total int, first *int, after *string) (int, int, error) {
+	start := 0
+	if after != nil {
+		b, err := base64.StdEncoding.DecodeString(*after)
+		if err != nil {
+			return 0, 0, err
+		}
+		n, err := strconv.Atoi(string(b))
+		if err != nil {
+			return 0, 0, err
+		}
+		start = n + 1
+	}
+	if start > total {
+		start = total
+	}
+
+	end := total
+	if first != nil && *first >= 0 && start + *first < end {
+		end = start + *first
+	}
+
+	return start, end, nil
+}
+
+
+func encodeCursor(index int) string {
+	return base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(index)))
+}`
+
+
+// graphqlTypeMap map DataTypeCls and GraphQL scalars. (BLOB is not exposed)
+var graphqlTypeMap = map[string]string{
+	constant.DATA_TYPE_CLS_SERIAL: "Int",
+	constant.DATA_TYPE_CLS_BIGSERIAL: "Int",
+	constant.DATA_TYPE_CLS_TEXT: "String",
+	constant.DATA_TYPE_CLS_VARCHAR: "String",
+	constant.DATA_TYPE_CLS_CHAR: "String",
+	constant.DATA_TYPE_CLS_UUID: "String",
+	constant.DATA_TYPE_CLS_ENUM: "String",
+	constant.DATA_TYPE_CLS_INTEGER: "Int",
+	constant.DATA_TYPE_CLS_BIGINT: "Int",
+	constant.DATA_TYPE_CLS_SMALLINT: "Int",
+	constant.DATA_TYPE_CLS_NUMERIC: "Float",
+	constant.DATA_TYPE_CLS_REAL: "Float",
+	constant.DATA_TYPE_CLS_DOUBLE: "Float",
+	constant.DATA_TYPE_CLS_TIMESTAMP: "String",
+	constant.DATA_TYPE_CLS_DATE: "String",
+	constant.DATA_TYPE_CLS_TIME: "String",
+	constant.DATA_TYPE_CLS_TIMESTAMPTZ: "String",
+	constant.DATA_TYPE_CLS_BOOLEAN: "Boolean",
+	constant.DATA_TYPE_CLS_JSON: "String",
+	constant.DATA_TYPE_CLS_JSONB: "String",
+}
+
+// graphqlGoTypeMap Go types of GraphQL scalars in gqlgen.
+var graphqlGoTypeMap = map[string]string{
+	"Int": "int",
+	"Float": "float64",
+	"String": "string",
+	"Boolean": "bool",
+}
+
+
+// graphqlTable a table and its relationships in the selection.
+type graphqlTable struct {
+	table model.Table
+	columns []model.Column
+	// references from this table
+	fks []graphqlRelation
+	// references to this table
+	refs []graphqlRelation
+}
+
+// graphqlRelation reference from column of table to refColumn of refTable.
+type graphqlRelation struct {
+	table model.Table
+	fk foreignKey
+	// field name of the referenced row (in table) and the referencing rows (in refTable)
+	field string
+	refField string
+}
+
+
+// getGraphqlTables get tables with columns exposed in GraphQL and their relationships.
+// relationships are only between columns of compatible types.
+func (srv *codegenService) getGraphqlTables(tableIds []int) []graphqlTable {
+	var ret []graphqlTable
+	index := map[int]int{}
+
+	for _, tid := range tableIds {
+		table, err := srv.tableRepository.GetOne(&model.Table{TableId: tid})
+		if err != nil {
+			logger.Error(err.Error())
+			break
+		}
+		columns, err := srv.getValidColumns(tid)
+		if err != nil {
+			logger.Error(err.Error())
+			break
+		}
+		var cols []model.Column
+		for _, c := range columns {
+			if graphqlTypeMap[c.DataTypeCls] != "" {
+				cols = append(cols, c)
+			}
+		}
+		index[tid] = len(ret)
+		ret = append(ret, graphqlTable{table: table, columns: cols})
+	}
+
+	for i := range ret {
+		names := map[string]bool{}
+		for _, c := range ret[i].columns {
+			names[strings.ToLower(c.ColumnName)] = true
+		}
+
+		for _, fk := range srv.getForeignKeys(ret[i].columns, tableIds) {
+			from := graphqlTypeMap[fk.column.DataTypeCls]
+			if from == "" || from != graphqlTypeMap[fk.refColumn.DataTypeCls] {
+				continue
+			}
+
+			cn := strings.ToLower(fk.column.ColumnName)
+			name := strings.TrimSuffix(cn, "_id")
+			if name == cn || names[name] {
+				name = cn + "_ref"
+			}
+			names[name] = true
+
+			rel := graphqlRelation{
+				table: ret[i].table,
+				fk: fk,
+				field: SnakeToCamel(name),
+				refField: SnakeToCamel(ret[i].table.TableName) + "By" + SnakeToPascal(fk.column.ColumnName),
+			}
+			ret[i].fks = append(ret[i].fks, rel)
+			j := index[fk.refTable.TableId]
+			ret[j].refs = append(ret[j].refs, rel)
+		}
+	}
+
+	return ret
+}
+
+
+// graphqlGoName Go name of GraphQL field in gqlgen. (userId -> UserID)
+func graphqlGoName(field string) string {
+	initialisms := map[string]bool{
+		"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "CSV": true, "DNS": true,
+		"EOF": true, "GUID": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true,
+		"JSON": true, "KVK": true, "LHS": true, "QPS": true, "RAM": true, "RHS": true, "RPC": true,
+		"SLA": true, "SMTP": true, "SQL": true, "SSH": true, "SVG": true, "TCP": true, "TLS": true,
+		"TTL": true, "UDP": true, "UI": true, "UID": true, "URI": true, "URL": true, "UTF8": true,
+		"UUID": true, "VM": true, "XML": true, "XMPP": true, "XSRF": true, "XSS": true, "AWS": true, "GCP": true,
+	}
+
+	var words []string
+	w := ""
+	for _, r := range field {
+		if r >= 'A' && r <= 'Z' && w != "" {
+			words = append(words, w)
+			w = ""
+		}
+		w += string(r)
+	}
+	words = append(words, w)
+
+	s := ""
+	for _, w := range words {
+		if initialisms[strings.ToUpper(w)] {
+			s += strings.ToUpper(w)
+		} else {
+			s += strings.ToUpper(w[0:1]) + w[1:]
+		}
+	}
+	return s
+}
+
+
+// graphqlZeroValue zero value literal of Go type. (numbers or string)
+func graphqlZeroValue(goType string) string {
+	if goType == "string" {
+		return "\"\""
+	}
+	return "0"
+}
+
+
+func (srv *codegenService) generateGraphqlDescription(texts []string, indent string) string {
+	var ls []string
+	for _, t := range texts {
+		if t != "" {
+			ls = append(ls, t)
+		}
+	}
+	if len(ls) == 0 {
+		return ""
+	}
+	return indent + jsonString(strings.Join(ls, "\n")) + "\n"
+}
+
+
+// isGraphqlInputRequired input field is non-null. (NOT NULL without default value)
+func (srv *codegenService) isGraphqlInputRequired(column model.Column) bool {
+	return !srv.isNullableColumn(column) && column.DefaultValue == ""
+}
+
+
+func (srv *codegenService) generateGraphqlSchema(tables []graphqlTable) string {
+	s := "type PageInfo {\n" +
+		"  hasNextPage: Boolean!\n" +
+		"  hasPreviousPage: Boolean!\n" +
+		"  startCursor: String\n" +
+		"  endCursor: String\n" +
+		"}\n\n"
+
+	query, mutation := "", ""
+	for _, t := range tables {
+		tn := t.table.TableName
+		tnp := SnakeToPascal(tn)
+		tnc := SnakeToCamel(tn)
+
+		s += srv.generateGraphqlDescription([]string{t.table.TableNameLogical}, "")
+		s += "type " + tnp + " {\n"
+		for _, c := range t.columns {
+			s += srv.generateGraphqlDescription([]string{c.ColumnNameLogical, c.Remark}, "  ")
+			s += "  " + SnakeToCamel(c.ColumnName) + ": " + graphqlTypeMap[c.DataTypeCls]
+			if !srv.isNullableColumn(c) {
+				s += "!"
+			}
+			s += "\n"
+		}
+		if t.table.VersionFlg == constant.FLG_ON {
+			s += "  version: Int!\n"
+		}
+		s += "  createdAt: String!\n" +
+			"  updatedAt: String!\n"
+		for _, r := range t.fks {
+			s += "  " + r.field + ": " + SnakeToPascal(r.fk.refTable.TableName) + "\n"
+		}
+		for _, r := range t.refs {
+			s += "  " + r.refField + ": [" + SnakeToPascal(r.table.TableName) + "!]!\n"
+		}
+		s += "}\n\n"
+
+		s += "type " + tnp + "Edge {\n" +
+			"  node: " + tnp + "!\n" +
+			"  cursor: String!\n" +
+			"}\n\n" +
+			"type " + tnp + "Connection {\n" +
+			"  edges: [" + tnp + "Edge!]!\n" +
+			"  pageInfo: PageInfo!\n" +
+			"  totalCount: Int!\n" +
+			"}\n\n"
+
+		s += "input " + tnp + "Input {\n"
+		for _, c := range t.columns {
+			if isSerialType(c.DataTypeCls) {
+				continue
+			}
+			s += "  " + SnakeToCamel(c.ColumnName) + ": " + graphqlTypeMap[c.DataTypeCls]
+			if srv.isGraphqlInputRequired(c) {
+				s += "!"
+			}
+			s += "\n"
+		}
+		if t.table.VersionFlg == constant.FLG_ON {
+			s += "  version: Int\n"
+		}
+		s += "}\n\n"
+
+		query += "  " + tnc + "(first: Int, after: String): " + tnp + "Connection!\n"
+		mutation += "  create" + tnp + "(input: " + tnp + "Input!): " + tnp + "!\n"
+
+		pks := srv.extractPrimaryKeys(t.columns)
+		if len(pks) == 0 {
+			continue
+		}
+		var args []string
+		for _, pk := range pks {
+			args = append(args, SnakeToCamel(pk.ColumnName) + ": " + graphqlTypeMap[pk.DataTypeCls] + "!")
+		}
+		query += "  " + tnc + "ByPk(" + strings.Join(args, ", ") + "): " + tnp + "\n"
+		mutation += "  update" + tnp + "(" + strings.Join(args, ", ") + ", input: " + tnp + "Input!): " + tnp + "!\n" +
+			"  delete" + tnp + "(" + strings.Join(args, ", ") + "): Boolean!\n"
+	}
+
+	if query == "" {
+		return s
+	}
+	return s + "type Query {\n" + query + "}\n\n" + "type Mutation {\n" + mutation + "}\n"
+}
+
+
+// generateGraphqlResolverCode Resolver with the repositories of the tables.
+func (srv *codegenService) generateGraphqlResolverCode(tables []graphqlTable) string {
+	s := "package graph\n\n" +
+		"import (\n\t\"xxxxx/internal/repository\"\n)\n\n\n" +
+		"// Resolver dependencies of the resolvers.\n" +
+		"type Resolver struct {\n"
+	for _, t := range tables {
+		tnp := SnakeToPascal(t.table.TableName)
+		s += fmt.Sprintf("\t%sRepository repository.%sRepository\n", tnp, tnp)
+	}
+	s += "}\n\n\n" +
+		"func NewResolver() *Resolver {\n" +
+		"\treturn &Resolver{\n"
+	for _, t := range tables {
+		tnp := SnakeToPascal(t.table.TableName)
+		s += fmt.Sprintf("\t\t%sRepository: repository.New%sRepository(),\n", tnp, tnp)
+	}
+	s += "\t}\n}"
+
+	return s
+}
+
+
+// generateGraphqlInputCode "<table>FromInput" convert gqlgen input to model.
+func (srv *codegenService) generateGraphqlInputCode(tables []graphqlTable) string {
+	s := "package graph\n\n" +
+		"import (\n\t\"xxxxx/graph/gqlmodel\"\n\t\"xxxxx/internal/model\"\n)\n"
+
+	for _, t := range tables {
+		tnp := SnakeToPascal(t.table.TableName)
+		s += "\n\n" +
+			fmt.Sprintf("func %sFromInput(in gqlmodel.%sInput) model.%s {\n", SnakeToCamel(t.table.TableName), tnp, tnp) +
+			fmt.Sprintf("\tret := model.%s{}\n", tnp)
+		for _, c := range t.columns {
+			if isSerialType(c.DataTypeCls) {
+				continue
+			}
+			field := SnakeToPascal(c.ColumnName)
+			in := "in." + graphqlGoName(SnakeToCamel(c.ColumnName))
+			goType := dbDataTypeGoTypeMap[c.DataTypeCls]
+			if srv.isGraphqlInputRequired(c) {
+				s += fmt.Sprintf("\tret.%s = %s(%s)\n", field, goType, in)
+			} else {
+				s += fmt.Sprintf("\tif %s != nil {\n\t\tret.%s = %s(*%s)\n\t}\n", in, field, goType, in)
+			}
+		}
+		if t.table.VersionFlg == constant.FLG_ON {
+			s += "\tif in.Version != nil {\n\t\tret.Version = *in.Version\n\t}\n"
+		}
+		s += "\treturn ret\n}"
+	}
+
+	return s
+}
+
+
+// generateGraphqlResolversCode resolvers of Query, Mutation and relationship fields.
+// (schema.resolvers.go of gqlgen follow-schema layout)
+func (srv *codegenService) generateGraphqlResolversCode(tables []graphqlTable) string {
+	s := "package graph\n\n" +
+		"import (\n\t\"context\"\n\n\t\"xxxxx/graph/gqlmodel\"\n\t\"xxxxx/internal/model\"\n)\n"
+
+	for _, t := range tables {
+		s += srv.generateGraphqlQueryResolvers(t)
+		s += srv.generateGraphqlMutationResolvers(t)
+	}
+	for _, t := range tables {
+		s += srv.generateGraphqlRelationResolvers(t)
+	}
+
+	if len(tables) > 0 {
+		s += "\n\n" +
+			"func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }\n\n" +
+			"func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }\n"
+	}
+	for _, t := range tables {
+		if len(t.fks) + len(t.refs) > 0 {
+			tnp := SnakeToPascal(t.table.TableName)
+			tnc := SnakeToCamel(t.table.TableName)
+			s += fmt.Sprintf("\nfunc (r *Resolver) %s() %sResolver { return &%sResolver{r} }\n", tnp, tnp, tnc)
+		}
+	}
+
+	if len(tables) > 0 {
+		s += "\ntype queryResolver struct{ *Resolver }\n" +
+			"type mutationResolver struct{ *Resolver }\n"
+	}
+	for _, t := range tables {
+		if len(t.fks) + len(t.refs) > 0 {
+			s += fmt.Sprintf("type %sResolver struct{ *Resolver }\n", SnakeToCamel(t.table.TableName))
+		}
+	}
+
+	return strings.TrimRight(s, "\n")
+}
+
+
+// generateGraphqlPkArgs ("userID int", "UserId: int(userID)") of primary keys.
+func (srv *codegenService) generateGraphqlPkArgs(pks []model.Column) (string, string) {
+	var params, fields []string
+	for _, pk := range pks {
+		arg := SnakeToCamel(pk.ColumnName)
+		params = append(params, arg + " " + graphqlGoTypeMap[graphqlTypeMap[pk.DataTypeCls]])
+		fields = append(fields, fmt.Sprintf(
+			"%s: %s(%s)", SnakeToPascal(pk.ColumnName), dbDataTypeGoTypeMap[pk.DataTypeCls], arg,
+		))
+	}
+	return strings.Join(params, ", "), strings.Join(fields, ", ")
+}
+
+
+func (srv *codegenService) generateGraphqlQueryResolvers(t graphqlTable) string {
+	tnp := SnakeToPascal(t.table.TableName)
+
+	s := "\n\n" +
+		fmt.Sprintf("// %s list %s. (connection)\n", tnp, t.table.TableName) +
+		fmt.Sprintf("func (r *queryResolver) %s(ctx context.Context, first *int, after *string) (*gqlmodel.%sConnection, error) {\n", tnp, tnp) +
+		fmt.Sprintf("\trows, err := r.%sRepository.Get(&model.%s{})\n", tnp, tnp) +
+		"\tif err != nil {\n\t\treturn nil, err\n\t}\n" +
+		"\tstart, end, err := pageRange(len(rows), first, after)\n" +
+		"\tif err != nil {\n\t\treturn nil, err\n\t}\n\n" +
+		fmt.Sprintf("\tret := &gqlmodel.%sConnection{\n", tnp) +
+		fmt.Sprintf("\t\tEdges: []*gqlmodel.%sEdge{},\n", tnp) +
+		"\t\tPageInfo: &gqlmodel.PageInfo{HasNextPage: end < len(rows), HasPreviousPage: start > 0},\n" +
+		"\t\tTotalCount: len(rows),\n" +
+		"\t}\n" +
+		"\tfor i := start; i < end; i++ {\n" +
+		fmt.Sprintf("\t\tret.Edges = append(ret.Edges, &gqlmodel.%sEdge{Node: &rows[i], Cursor: encodeCursor(i)})\n", tnp) +
+		"\t}\n" +
+		"\tif len(ret.Edges) > 0 {\n" +
+		"\t\tret.PageInfo.StartCursor = &ret.Edges[0].Cursor\n" +
+		"\t\tret.PageInfo.EndCursor = &ret.Edges[len(ret.Edges) - 1].Cursor\n" +
+		"\t}\n\n" +
+		"\treturn ret, nil\n}"
+
+	pks := srv.extractPrimaryKeys(t.columns)
+	if len(pks) == 0 {
+		return s
+	}
+	params, fields := srv.generateGraphqlPkArgs(pks)
+
+	s += "\n\n" +
+		fmt.Sprintf("// %sByPk get %s by primary key.\n", tnp, t.table.TableName) +
+		fmt.Sprintf("func (r *queryResolver) %sByPk(ctx context.Context, %s) (*model.%s, error) {\n", tnp, params, tnp) +
+		fmt.Sprintf("\tret, err := r.%sRepository.GetOne(&model.%s{%s})\n", tnp, tnp, fields) +
+		"\tif err != nil {\n\t\treturn nil, err\n\t}\n" +
+		"\treturn &ret, nil\n}"
+
+	return s
+}
+
+
+func (srv *codegenService) generateGraphqlMutationResolvers(t graphqlTable) string {
+	tnp := SnakeToPascal(t.table.TableName)
+	tnc := SnakeToCamel(t.table.TableName)
+	pks := srv.extractPrimaryKeys(t.columns)
+
+	s := "\n\n" +
+		fmt.Sprintf("// Create%s insert %s. (auto increment columns are not set)\n", tnp, t.table.TableName) +
+		fmt.Sprintf("func (r *mutationResolver) Create%s(ctx context.Context, input gqlmodel.%sInput) (*model.%s, error) {\n", tnp, tnp, tnp) +
+		fmt.Sprintf("\tret := %sFromInput(input)\n", tnc) +
+		fmt.Sprintf("\tif err := r.%sRepository.Insert(&ret, nil); err != nil {\n", tnp) +
+		"\t\treturn nil, err\n\t}\n" +
+		"\treturn &ret, nil\n}"
+
+	if len(pks) == 0 {
+		return s
+	}
+	params, fields := srv.generateGraphqlPkArgs(pks)
+
+	s += "\n\n" +
+		fmt.Sprintf("// Update%s update %s by primary key.\n", tnp, t.table.TableName) +
+		fmt.Sprintf("func (r *mutationResolver) Update%s(ctx context.Context, %s, input gqlmodel.%sInput) (*model.%s, error) {\n", tnp, params, tnp, tnp) +
+		fmt.Sprintf("\tin := %sFromInput(input)\n", tnc)
+	for _, pk := range pks {
+		arg := SnakeToCamel(pk.ColumnName)
+		s += fmt.Sprintf("\tin.%s = %s(%s)\n", SnakeToPascal(pk.ColumnName), dbDataTypeGoTypeMap[pk.DataTypeCls], arg)
+	}
+	s += fmt.Sprintf("\tif err := r.%sRepository.Update(&in, nil); err != nil {\n", tnp) +
+		"\t\treturn nil, err\n\t}\n" +
+		fmt.Sprintf("\tret, err := r.%sRepository.GetOne(&model.%s{%s})\n", tnp, tnp, fields) +
+		"\tif err != nil {\n\t\treturn nil, err\n\t}\n" +
+		"\treturn &ret, nil\n}"
+
+	s += "\n\n" +
+		fmt.Sprintf("// Delete%s delete %s by primary key.\n", tnp, t.table.TableName) +
+		fmt.Sprintf("func (r *mutationResolver) Delete%s(ctx context.Context, %s) (bool, error) {\n", tnp, params) +
+		fmt.Sprintf("\tif err := r.%sRepository.Delete(&model.%s{%s}, nil); err != nil {\n", tnp, tnp, fields) +
+		"\t\treturn false, err\n\t}\n" +
+		"\treturn true, nil\n}"
+
+	return s
+}
+
+
+// generateGraphqlRelationResolvers resolvers of the referenced row and the referencing rows.
+// zero value references nothing. (Get with zero value filter returns all rows)
+func (srv *codegenService) generateGraphqlRelationResolvers(t graphqlTable) string {
+	tnp := SnakeToPascal(t.table.TableName)
+	tnc := SnakeToCamel(t.table.TableName)
+	s := ""
+
+	for _, r := range t.fks {
+		refp := SnakeToPascal(r.fk.refTable.TableName)
+		field := SnakeToPascal(r.fk.column.ColumnName)
+		s += "\n\n" +
+			fmt.Sprintf("func (r *%sResolver) %s(ctx context.Context, obj *model.%s) (*model.%s, error) {\n",
+				tnc, graphqlGoName(r.field), tnp, refp) +
+			fmt.Sprintf("\tif obj.%s == %s {\n\t\treturn nil, nil\n\t}\n",
+				field, graphqlZeroValue(dbDataTypeGoTypeMap[r.fk.column.DataTypeCls])) +
+			fmt.Sprintf("\trows, err := r.%sRepository.Get(&model.%s{%s: %s(obj.%s)})\n",
+				refp, refp, SnakeToPascal(r.fk.refColumn.ColumnName),
+				dbDataTypeGoTypeMap[r.fk.refColumn.DataTypeCls], field) +
+			"\tif err != nil || len(rows) == 0 {\n\t\treturn nil, err\n\t}\n" +
+			"\treturn &rows[0], nil\n}"
+	}
+
+	for _, r := range t.refs {
+		fromp := SnakeToPascal(r.table.TableName)
+		field := SnakeToPascal(r.fk.refColumn.ColumnName)
+		s += "\n\n" +
+			fmt.Sprintf("func (r *%sResolver) %s(ctx context.Context, obj *model.%s) ([]*model.%s, error) {\n",
+				tnc, graphqlGoName(r.refField), tnp, fromp) +
+			fmt.Sprintf("\tret := []*model.%s{}\n", fromp) +
+			fmt.Sprintf("\tif obj.%s == %s {\n\t\treturn ret, nil\n\t}\n",
+				field, graphqlZeroValue(dbDataTypeGoTypeMap[r.fk.refColumn.DataTypeCls])) +
+			fmt.Sprintf("\trows, err := r.%sRepository.Get(&model.%s{%s: %s(obj.%s)})\n",
+				fromp, fromp, SnakeToPascal(r.fk.column.ColumnName),
+				dbDataTypeGoTypeMap[r.fk.column.DataTypeCls], field) +
+			"\tif err != nil {\n\t\treturn nil, err\n\t}\n" +
+			"\tfor i := range rows {\n\t\tret = append(ret, &rows[i])\n\t}\n" +
+			"\treturn ret, nil\n}"
+	}
+
+	return s
+}
\ No newline at end of file
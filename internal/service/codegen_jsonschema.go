@@ -0,0 +1,236 @@
+package service
+
+import (
+	"os"
+	"bytes"
+	"strings"
+	"strconv"
+	"encoding/json"
+
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/core/logger"
+	"goat-cg/internal/model"
+)
+
+
+// GenerateJsonSchema generate JSON Schema (draft 2020-12) of each table and return zip path.
+// (schemas/<table_name>.schema.json)
+func (srv *codegenService) GenerateJsonSchema(tableIds []int) string {
+	return srv.generateArchive("jsonschema", func(path string) {
+		path += "/schemas"
+		if err := os.MkdirAll(path, 0777); err != nil {
+			logger.Error(err.Error())
+			return
+		}
+
+		for _, tid := range tableIds {
+			table, err := srv.tableRepository.GetOne(&model.Table{TableId: tid})
+			if err != nil {
+				logger.Error(err.Error())
+				break
+			}
+			columns, err := srv.getValidColumns(tid)
+			if err != nil {
+				logger.Error(err.Error())
+				break
+			}
+
+			b, err := json.MarshalIndent(srv.generateJsonSchema(&table, columns), "", "  ")
+			if err != nil {
+				logger.Error(err.Error())
+				break
+			}
+			srv.writeFile(path + "/" + strings.ToLower(table.TableName) + ".schema.json", string(b) + "\n")
+		}
+	})
+}
+
+
+// jsonSchema subset of JSON Schema keywords. (fields are written in this order)
+type jsonSchema struct {
+	Schema string `json:"$schema,omitempty"`
+	Id string `json:"$id,omitempty"`
+	Title string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Type interface{} `json:"type,omitempty"`
+	Format string `json:"format,omitempty"`
+	ContentEncoding string `json:"contentEncoding,omitempty"`
+	MaxLength int `json:"maxLength,omitempty"`
+	MultipleOf json.Number `json:"multipleOf,omitempty"`
+	ExclusiveMinimum json.Number `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum json.Number `json:"exclusiveMaximum,omitempty"`
+	Enum []interface{} `json:"enum,omitempty"`
+	Default interface{} `json:"default,omitempty"`
+	ReadOnly bool `json:"readOnly,omitempty"`
+	Properties jsonSchemaProperties `json:"properties,omitempty"`
+	Required []string `json:"required,omitempty"`
+	AdditionalProperties *bool `json:"additionalProperties,omitempty"`
+}
+
+// jsonSchemaProperties "properties" in the order of the columns.
+type jsonSchemaProperties []jsonSchemaProperty
+
+type jsonSchemaProperty struct {
+	name string
+	schema jsonSchema
+}
+
+
+func (ps jsonSchemaProperties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, p := range ps {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		k, err := json.Marshal(p.name)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(p.schema)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteString(":")
+		buf.Write(v)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+
+// jsonSchemaTypeMap map DataTypeCls and JSON Schema (type, format). (JSON columns accept any value)
+var jsonSchemaTypeMap = map[string][2]string{
+	constant.DATA_TYPE_CLS_SERIAL: {"integer", ""},
+	constant.DATA_TYPE_CLS_BIGSERIAL: {"integer", ""},
+	constant.DATA_TYPE_CLS_TEXT: {"string", ""},
+	constant.DATA_TYPE_CLS_VARCHAR: {"string", ""},
+	constant.DATA_TYPE_CLS_CHAR: {"string", ""},
+	constant.DATA_TYPE_CLS_UUID: {"string", "uuid"},
+	constant.DATA_TYPE_CLS_ENUM: {"string", ""},
+	constant.DATA_TYPE_CLS_INTEGER: {"integer", ""},
+	constant.DATA_TYPE_CLS_BIGINT: {"integer", ""},
+	constant.DATA_TYPE_CLS_SMALLINT: {"integer", ""},
+	constant.DATA_TYPE_CLS_NUMERIC: {"number", ""},
+	constant.DATA_TYPE_CLS_REAL: {"number", ""},
+	constant.DATA_TYPE_CLS_DOUBLE: {"number", ""},
+	constant.DATA_TYPE_CLS_TIMESTAMP: {"string", "date-time"},
+	constant.DATA_TYPE_CLS_DATE: {"string", "date"},
+	constant.DATA_TYPE_CLS_TIME: {"string", "time"},
+	constant.DATA_TYPE_CLS_TIMESTAMPTZ: {"string", "date-time"},
+	constant.DATA_TYPE_CLS_BLOB: {"string", ""},
+	constant.DATA_TYPE_CLS_BOOLEAN: {"boolean", ""},
+	constant.DATA_TYPE_CLS_JSON: {"", ""},
+	constant.DATA_TYPE_CLS_JSONB: {"", ""},
+}
+
+
+// generateJsonSchema schema of a row of the table.
+// required: NOT NULL and primary key columns. auto increment columns and timestamps are readOnly.
+func (srv *codegenService) generateJsonSchema(table *model.Table, columns []model.Column) jsonSchema {
+	tn := strings.ToLower(table.TableName)
+	no := false
+
+	s := jsonSchema{
+		Schema: "https://json-schema.org/draft/2020-12/schema",
+		Id: tn + ".schema.json",
+		Title: tn,
+		Type: "object",
+		AdditionalProperties: &no,
+	}
+	if table.TableNameLogical != "" {
+		s.Title = table.TableNameLogical
+	}
+
+	for _, c := range columns {
+		s.Properties = append(s.Properties, jsonSchemaProperty{strings.ToLower(c.ColumnName), srv.generateJsonSchemaColumn(c)})
+		if !srv.isNullableColumn(c) {
+			s.Required = append(s.Required, strings.ToLower(c.ColumnName))
+		}
+	}
+	if table.VersionFlg == constant.FLG_ON {
+		s.Properties = append(s.Properties, jsonSchemaProperty{"version", jsonSchema{
+			Description: "optimistic locking version", Type: "integer",
+		}})
+	}
+	for _, name := range []string{"created_at", "updated_at"} {
+		s.Properties = append(s.Properties, jsonSchemaProperty{name, jsonSchema{
+			Type: "string", ReadOnly: true,
+		}})
+	}
+
+	return s
+}
+
+
+// generateJsonSchemaColumn schema of the column.
+// maxLength from Precision of strings, multipleOf and range from Precision and Scale of NUMERIC,
+// enum from EnumValues or classification, title and description from ColumnNameLogical and Remark.
+func (srv *codegenService) generateJsonSchemaColumn(column model.Column) jsonSchema {
+	t := jsonSchemaTypeMap[column.DataTypeCls]
+	s := jsonSchema{
+		Title: column.ColumnNameLogical,
+		Description: strings.ReplaceAll(column.Remark, "\r\n", "\n"),
+		Format: t[1],
+		ReadOnly: isSerialType(column.DataTypeCls),
+	}
+
+	if t[0] != "" {
+		if srv.isNullableColumn(column) {
+			s.Type = []string{t[0], "null"}
+		} else {
+			s.Type = t[0]
+		}
+	}
+	if column.DataTypeCls == constant.DATA_TYPE_CLS_BLOB {
+		s.ContentEncoding = "base64"
+	}
+
+	switch column.DataTypeCls {
+	case constant.DATA_TYPE_CLS_VARCHAR, constant.DATA_TYPE_CLS_CHAR:
+		s.MaxLength = column.Precision
+	case constant.DATA_TYPE_CLS_NUMERIC:
+		if column.Scale > 0 {
+			s.MultipleOf = json.Number("0." + strings.Repeat("0", column.Scale - 1) + "1")
+		} else if column.Precision > 0 {
+			s.MultipleOf = json.Number("1")
+		}
+		if column.Precision > column.Scale {
+			limit := "1" + strings.Repeat("0", column.Precision - column.Scale)
+			s.ExclusiveMinimum = json.Number("-" + limit)
+			s.ExclusiveMaximum = json.Number(limit)
+		}
+	}
+
+	if column.DataTypeCls == constant.DATA_TYPE_CLS_ENUM || column.ClassificationId != 0 {
+		for _, v := range srv.getEnumValues(column) {
+			s.Enum = append(s.Enum, srv.jsonSchemaValue(t[0], v))
+		}
+		if len(s.Enum) > 0 && srv.isNullableColumn(column) {
+			s.Enum = append(s.Enum, nil)
+		}
+	}
+
+	if column.DefaultValue != "" {
+		s.Default = srv.jsonSchemaValue(t[0], column.DefaultValue)
+	}
+
+	return s
+}
+
+
+// jsonSchemaValue value of the JSON Schema type. (string if not parsable)
+func (srv *codegenService) jsonSchemaValue(typ, value string) interface{} {
+	switch typ {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(value, 64); err == nil && json.Valid([]byte(value)) {
+			return json.Number(value)
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
\ No newline at end of file
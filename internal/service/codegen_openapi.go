@@ -0,0 +1,310 @@
+package service
+
+import (
+	"os"
+	"fmt"
+	"strings"
+	"encoding/json"
+
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/core/logger"
+	"goat-cg/internal/model"
+)
+
+
+// GenerateOpenApi generate OpenAPI 3 document of CRUD endpoints and return zip path.
+// (openapi.yaml)
+func (srv *codegenService) GenerateOpenApi(projectName string, tableIds []int) string {
+	return srv.generateArchive("openapi", func(path string) {
+		if err := os.MkdirAll(path, 0777); err != nil {
+			logger.Error(err.Error())
+			return
+		}
+		srv.writeFile(path + "/openapi.yaml", srv.generateOpenApiCode(projectName, tableIds))
+	})
+}
+
+
+// openApiTypeMap map DataTypeCls and OpenAPI (type, format).
+var openApiTypeMap = map[string][2]string{
+	constant.DATA_TYPE_CLS_SERIAL: {"integer", "int32"},
+	constant.DATA_TYPE_CLS_BIGSERIAL: {"integer", "int64"},
+	constant.DATA_TYPE_CLS_TEXT: {"string", ""},
+	constant.DATA_TYPE_CLS_VARCHAR: {"string", ""},
+	constant.DATA_TYPE_CLS_CHAR: {"string", ""},
+	constant.DATA_TYPE_CLS_UUID: {"string", "uuid"},
+	constant.DATA_TYPE_CLS_ENUM: {"string", ""},
+	constant.DATA_TYPE_CLS_INTEGER: {"integer", "int32"},
+	constant.DATA_TYPE_CLS_BIGINT: {"integer", "int64"},
+	constant.DATA_TYPE_CLS_SMALLINT: {"integer", "int32"},
+	constant.DATA_TYPE_CLS_NUMERIC: {"number", ""},
+	constant.DATA_TYPE_CLS_REAL: {"number", "float"},
+	constant.DATA_TYPE_CLS_DOUBLE: {"number", "double"},
+	constant.DATA_TYPE_CLS_TIMESTAMP: {"string", "date-time"},
+	constant.DATA_TYPE_CLS_DATE: {"string", "date"},
+	constant.DATA_TYPE_CLS_TIME: {"string", "time"},
+	constant.DATA_TYPE_CLS_TIMESTAMPTZ: {"string", "date-time"},
+	constant.DATA_TYPE_CLS_BLOB: {"string", "byte"},
+	constant.DATA_TYPE_CLS_BOOLEAN: {"boolean", ""},
+	constant.DATA_TYPE_CLS_JSON: {"", ""},
+	constant.DATA_TYPE_CLS_JSONB: {"", ""},
+}
+
+
+// jsonString quote s as JSON string. (valid in YAML and TypeScript)
+func jsonString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+
+func (srv *codegenService) generateOpenApiCode(projectName string, tableIds []int) string {
+	paths, schemas := "", ""
+
+	for _, tid := range tableIds {
+		table, err := srv.tableRepository.GetOne(&model.Table{TableId: tid})
+		if err != nil {
+			logger.Error(err.Error())
+			break
+		}
+		columns, err := srv.getValidColumns(tid)
+		if err != nil {
+			logger.Error(err.Error())
+			break
+		}
+
+		paths += srv.generateOpenApiPaths(&table, columns)
+		schemas += srv.generateOpenApiSchemas(&table, columns)
+	}
+
+	s := "openapi: 3.0.3\n" +
+		"info:\n" +
+		"  title: " + jsonString(projectName) + "\n" +
+		"  version: 1.0.0\n"
+	if paths == "" {
+		return s + "paths: {}\n"
+	}
+
+	return s + "paths:\n" + paths +
+		"components:\n" +
+		"  schemas:\n" + schemas
+}
+
+
+// generateOpenApiPaths "/<table>" (list, create) and "/<table>/{pk}" (get, update, delete).
+// tables without primary key have only "/<table>".
+func (srv *codegenService) generateOpenApiPaths(table *model.Table, columns []model.Column) string {
+	tn := table.TableName
+	tnp := SnakeToPascal(tn)
+	ref := "#/components/schemas/" + tnp
+	summary := tn
+	if table.TableNameLogical != "" {
+		summary = table.TableNameLogical
+	}
+
+	s := "  /" + tn + ":\n" +
+		"    get:\n" +
+		"      tags: [" + tn + "]\n" +
+		"      summary: " + jsonString("list " + summary) + "\n" +
+		"      operationId: list" + tnp + "\n" +
+		"      responses:\n" +
+		"        \"200\":\n" +
+		"          description: OK\n" +
+		"          content:\n" +
+		"            application/json:\n" +
+		"              schema:\n" +
+		"                type: array\n" +
+		"                items:\n" +
+		"                  $ref: \"" + ref + "\"\n" +
+		"    post:\n" +
+		"      tags: [" + tn + "]\n" +
+		"      summary: " + jsonString("create " + summary) + "\n" +
+		"      operationId: create" + tnp + "\n" +
+		"      requestBody:\n" +
+		"        required: true\n" +
+		"        content:\n" +
+		"          application/json:\n" +
+		"            schema:\n" +
+		"              $ref: \"" + ref + "Input\"\n" +
+		"      responses:\n" +
+		"        \"201\":\n" +
+		"          description: Created\n" +
+		"          content:\n" +
+		"            application/json:\n" +
+		"              schema:\n" +
+		"                $ref: \"" + ref + "\"\n" +
+		"        \"400\":\n" +
+		"          description: Bad Request\n"
+
+	pks := srv.extractPrimaryKeys(columns)
+	if len(pks) == 0 {
+		return s
+	}
+
+	path, params := "/" + tn, ""
+	for _, pk := range pks {
+		path += "/{" + pk.ColumnName + "}"
+		params += "      - name: " + pk.ColumnName + "\n" +
+			"        in: path\n" +
+			"        required: true\n" +
+			"        schema:\n" +
+			srv.generateOpenApiType(pk, "          ")
+	}
+	found := "        \"200\":\n" +
+		"          description: OK\n" +
+		"          content:\n" +
+		"            application/json:\n" +
+		"              schema:\n" +
+		"                $ref: \"" + ref + "\"\n"
+	notFound := "        \"404\":\n" +
+		"          description: Not Found\n"
+
+	s += "  " + path + ":\n" +
+		"    parameters:\n" + params +
+		"    get:\n" +
+		"      tags: [" + tn + "]\n" +
+		"      summary: " + jsonString("get " + summary) + "\n" +
+		"      operationId: get" + tnp + "\n" +
+		"      responses:\n" + found + notFound +
+		"    put:\n" +
+		"      tags: [" + tn + "]\n" +
+		"      summary: " + jsonString("update " + summary) + "\n" +
+		"      operationId: update" + tnp + "\n" +
+		"      requestBody:\n" +
+		"        required: true\n" +
+		"        content:\n" +
+		"          application/json:\n" +
+		"            schema:\n" +
+		"              $ref: \"" + ref + "Input\"\n" +
+		"      responses:\n" + found +
+		"        \"400\":\n" +
+		"          description: Bad Request\n" + notFound
+	if table.VersionFlg == constant.FLG_ON {
+		s += "        \"409\":\n" +
+			"          description: Conflict (version mismatch)\n"
+	}
+	s += "    delete:\n" +
+		"      tags: [" + tn + "]\n" +
+		"      summary: " + jsonString("delete " + summary) + "\n" +
+		"      operationId: delete" + tnp + "\n" +
+		"      responses:\n" +
+		"        \"204\":\n" +
+		"          description: No Content\n" + notFound
+
+	return s
+}
+
+
+// generateOpenApiSchemas "<Table>" (response) and "<Table>Input" (request body).
+// Input has no auto increment columns and timestamps, version is required for update.
+func (srv *codegenService) generateOpenApiSchemas(table *model.Table, columns []model.Column) string {
+	tnp := SnakeToPascal(table.TableName)
+
+	props, inputProps := "", ""
+	var required, inputRequired []string
+	for _, c := range columns {
+		p := "        " + c.ColumnName + ":\n" + srv.generateOpenApiType(c, "          ")
+		props += p
+		if c.NotNullFlg == constant.FLG_ON || c.PrimaryKeyFlg == constant.FLG_ON {
+			required = append(required, c.ColumnName)
+		}
+		if isSerialType(c.DataTypeCls) {
+			continue
+		}
+		inputProps += p
+		if (c.NotNullFlg == constant.FLG_ON || c.PrimaryKeyFlg == constant.FLG_ON) && c.DefaultValue == "" {
+			inputRequired = append(inputRequired, c.ColumnName)
+		}
+	}
+
+	if table.VersionFlg == constant.FLG_ON {
+		v := "        version:\n" +
+			"          type: integer\n" +
+			"          format: int32\n"
+		props += v
+		inputProps += v
+		required = append(required, "version")
+	}
+	props += "        created_at:\n" +
+		"          type: string\n" +
+		"          readOnly: true\n" +
+		"        updated_at:\n" +
+		"          type: string\n" +
+		"          readOnly: true\n"
+	required = append(required, "created_at", "updated_at")
+
+	s := "    " + tnp + ":\n" +
+		"      type: object\n"
+	if table.TableNameLogical != "" {
+		s += "      description: " + jsonString(table.TableNameLogical) + "\n"
+	}
+	s += "      required: [" + strings.Join(required, ", ") + "]\n" +
+		"      properties:\n" + props
+
+	s += "    " + tnp + "Input:\n" +
+		"      type: object\n"
+	if len(inputRequired) > 0 {
+		s += "      required: [" + strings.Join(inputRequired, ", ") + "]\n"
+	}
+	if inputProps == "" {
+		return s + "      properties: {}\n"
+	}
+	return s + "      properties:\n" + inputProps
+}
+
+
+// generateOpenApiType schema of the column.
+// nullable from NotNullFlg, maxLength from Precision of strings, enum from EnumValues or classification.
+// description from ColumnNameLogical and Remark.
+func (srv *codegenService) generateOpenApiType(column model.Column, indent string) string {
+	t := openApiTypeMap[column.DataTypeCls]
+	s := ""
+
+	if t[0] != "" {
+		s += indent + "type: " + t[0] + "\n"
+	}
+	if t[1] != "" {
+		s += indent + "format: " + t[1] + "\n"
+	}
+
+	switch column.DataTypeCls {
+	case constant.DATA_TYPE_CLS_VARCHAR, constant.DATA_TYPE_CLS_CHAR:
+		if column.Precision > 0 {
+			s += indent + fmt.Sprintf("maxLength: %d\n", column.Precision)
+		}
+	}
+
+	if column.DataTypeCls == constant.DATA_TYPE_CLS_ENUM || column.ClassificationId != 0 {
+		var values []string
+		for _, v := range srv.getEnumValues(column) {
+			if t[0] == "string" || t[0] == "" {
+				values = append(values, jsonString(v))
+			} else {
+				values = append(values, v)
+			}
+		}
+		if len(values) > 0 {
+			s += indent + "enum: [" + strings.Join(values, ", ") + "]\n"
+		}
+	}
+
+	if srv.isNullableColumn(column) {
+		s += indent + "nullable: true\n"
+	}
+
+	var desc []string
+	if column.ColumnNameLogical != "" {
+		desc = append(desc, column.ColumnNameLogical)
+	}
+	if column.Remark != "" {
+		desc = append(desc, column.Remark)
+	}
+	if len(desc) > 0 {
+		s += indent + "description: " + jsonString(strings.Join(desc, "\n")) + "\n"
+	}
+
+	if s == "" {
+		return indent + "{}\n"
+	}
+	return s
+}
\ No newline at end of file
@@ -0,0 +1,676 @@
+package service
+
+import (
+	"os"
+	"fmt"
+	"strings"
+
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/core/logger"
+	"goat-cg/internal/model"
+)
+
+
+// GenerateOrm generate models of an ORM / query builder instead of GOAT repositories
+// and return zip path. ("" for unknown orm)
+// param orm: "gorm", "sqlx", "ent", "sqlc" (Go), "sqlalchemy" (Python), "jpa" (Java), "prisma" (Node.js)
+func (srv *codegenService) GenerateOrm(rdbms, orm string, tableIds []int) string {
+	var generate func(rdbms string, tableIds []int, path string)
+	switch orm {
+	case "gorm":
+		generate = srv.generateGormSource
+	case "sqlx":
+		generate = srv.generateSqlxSource
+	case "ent":
+		generate = srv.generateEntSource
+	case "sqlc":
+		generate = srv.generateSqlcSource
+	case "sqlalchemy":
+		generate = srv.generateSqlalchemySource
+	case "jpa":
+		generate = srv.generateJpaSource
+	case "prisma":
+		generate = srv.generatePrismaSource
+	default:
+		return ""
+	}
+
+	return srv.generateArchive(orm, func(path string) {
+		generate(rdbms, tableIds, path)
+	})
+}
+
+
+// ormTable a table and its relationships in the selection.
+type ormTable struct {
+	table model.Table
+	columns []model.Column
+	// references from this table
+	fks []ormRelation
+	// references to this table
+	refs []ormRelation
+}
+
+// ormRelation reference from column of table to refColumn of refTable.
+type ormRelation struct {
+	table model.Table
+	fk foreignKey
+	// snake case names of the referenced row (in table) and the referencing rows (in refTable)
+	name string
+	refName string
+}
+
+
+// getOrmTables get tables with relationships between columns of the same Go type.
+func (srv *codegenService) getOrmTables(tableIds []int) []ormTable {
+	var ret []ormTable
+	index := map[int]int{}
+
+	for _, tid := range tableIds {
+		table, err := srv.tableRepository.GetOne(&model.Table{TableId: tid})
+		if err != nil {
+			logger.Error(err.Error())
+			break
+		}
+		columns, err := srv.getValidColumns(tid)
+		if err != nil {
+			logger.Error(err.Error())
+			break
+		}
+		index[tid] = len(ret)
+		ret = append(ret, ormTable{table: table, columns: columns})
+	}
+
+	for i := range ret {
+		names := map[string]bool{}
+		for _, c := range ret[i].columns {
+			names[strings.ToLower(c.ColumnName)] = true
+		}
+
+		for _, fk := range srv.getForeignKeys(ret[i].columns, tableIds) {
+			if dbDataTypeGoTypeMap[fk.column.DataTypeCls] != dbDataTypeGoTypeMap[fk.refColumn.DataTypeCls] {
+				continue
+			}
+
+			cn := strings.ToLower(fk.column.ColumnName)
+			name := strings.TrimSuffix(cn, "_id")
+			if name == cn || names[name] {
+				name = cn + "_ref"
+			}
+			names[name] = true
+
+			rel := ormRelation{
+				table: ret[i].table,
+				fk: fk,
+				name: name,
+				refName: strings.ToLower(ret[i].table.TableName) + "_by_" + cn,
+			}
+			ret[i].fks = append(ret[i].fks, rel)
+			j := index[fk.refTable.TableId]
+			ret[j].refs = append(ret[j].refs, rel)
+		}
+	}
+
+	return ret
+}
+
+
+// ormGoType Go type of the column. (pointer for nullable columns)
+func (srv *codegenService) ormGoType(column model.Column) string {
+	t := dbDataTypeGoTypeMap[column.DataTypeCls]
+	if srv.isNullableColumn(column) && !strings.HasPrefix(t, "[]") {
+		return "*" + t
+	}
+	return t
+}
+
+
+// generateOrmComment "// ColumnNameLogical Remark" of the struct field.
+func (srv *codegenService) generateOrmComment(column model.Column, indent string) string {
+	var ls []string
+	if column.ColumnNameLogical != "" {
+		ls = append(ls, column.ColumnNameLogical)
+	}
+	if column.Remark != "" {
+		ls = append(ls, strings.Split(strings.ReplaceAll(column.Remark, "\r\n", "\n"), "\n")...)
+	}
+
+	s := ""
+	for _, l := range ls {
+		s += indent + "// " + l + "\n"
+	}
+	return s
+}
+
+
+// generateGormSource internal/model/<table>.go with gorm tags and relationships.
+func (srv *codegenService) generateGormSource(rdbms string, tableIds []int, path string) {
+	path += "/internal/model"
+	if err := os.MkdirAll(path, 0777); err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	for _, t := range srv.getOrmTables(tableIds) {
+		srv.writeFile(path + "/" + srv.tableNameToFileName(t.table.TableName), srv.generateGormCode(t))
+	}
+}
+
+
+// generateGormCode model with "TableName()".
+// created_at and updated_at are read only. (set by the database)
+func (srv *codegenService) generateGormCode(t ormTable) string {
+	tn := strings.ToLower(t.table.TableName)
+	tnp := SnakeToPascal(tn)
+
+	s := "package model\n\n\n"
+	if t.table.TableNameLogical != "" {
+		s += "// " + tnp + " " + t.table.TableNameLogical + "\n"
+	}
+	s += fmt.Sprintf("type %s struct {\n", tnp)
+	for _, c := range t.columns {
+		s += srv.generateOrmComment(c, "\t")
+		s += fmt.Sprintf(
+			"\t%s %s `gorm:\"%s\" json:\"%s\"`\n",
+			SnakeToPascal(c.ColumnName), srv.ormGoType(c), srv.generateGormTag(c), strings.ToLower(c.ColumnName),
+		)
+	}
+	if t.table.VersionFlg == constant.FLG_ON {
+		s += "\tVersion int `gorm:\"column:version;not null;default:0\" json:\"version\"`\n"
+	}
+	s += "\tCreatedAt string `gorm:\"column:created_at;<-:false\" json:\"created_at\"`\n"
+	s += "\tUpdatedAt string `gorm:\"column:updated_at;<-:false\" json:\"updated_at\"`\n"
+
+	for _, r := range t.fks {
+		s += fmt.Sprintf(
+			"\t%s *%s `gorm:\"foreignKey:%s;references:%s\" json:\"%s,omitempty\"`\n",
+			SnakeToPascal(r.name), SnakeToPascal(r.fk.refTable.TableName),
+			SnakeToPascal(r.fk.column.ColumnName), SnakeToPascal(r.fk.refColumn.ColumnName), r.name,
+		)
+	}
+	for _, r := range t.refs {
+		s += fmt.Sprintf(
+			"\t%s []%s `gorm:\"foreignKey:%s;references:%s\" json:\"%s,omitempty\"`\n",
+			SnakeToPascal(r.refName), SnakeToPascal(r.table.TableName),
+			SnakeToPascal(r.fk.column.ColumnName), SnakeToPascal(r.fk.refColumn.ColumnName), r.refName,
+		)
+	}
+	s += "}\n\n\n"
+
+	s += fmt.Sprintf("func (%s) TableName() string {\n\treturn \"%s\"\n}", tnp, tn)
+
+	return s
+}
+
+
+// generateGormTag "column:x;primaryKey;autoIncrement;size:n;not null;unique;default:v"
+func (srv *codegenService) generateGormTag(column model.Column) string {
+	ls := []string{"column:" + strings.ToLower(column.ColumnName)}
+
+	if column.PrimaryKeyFlg == constant.FLG_ON {
+		ls = append(ls, "primaryKey")
+		if isSerialType(column.DataTypeCls) {
+			ls = append(ls, "autoIncrement")
+		} else {
+			ls = append(ls, "autoIncrement:false")
+		}
+	}
+	if column.Precision > 0 {
+		switch column.DataTypeCls {
+		case constant.DATA_TYPE_CLS_VARCHAR, constant.DATA_TYPE_CLS_CHAR:
+			ls = append(ls, fmt.Sprintf("size:%d", column.Precision))
+		case constant.DATA_TYPE_CLS_NUMERIC:
+			ls = append(ls, fmt.Sprintf("precision:%d", column.Precision), fmt.Sprintf("scale:%d", column.Scale))
+		}
+	}
+	if column.NotNullFlg == constant.FLG_ON && column.PrimaryKeyFlg != constant.FLG_ON {
+		ls = append(ls, "not null")
+	}
+	if column.UniqueFlg == constant.FLG_ON {
+		ls = append(ls, "unique")
+	}
+	if column.DefaultValue != "" {
+		if isNumericType(column.DataTypeCls) {
+			ls = append(ls, "default:" + column.DefaultValue)
+		} else {
+			ls = append(ls, "default:'" + column.DefaultValue + "'")
+		}
+	}
+
+	return strings.Join(ls, ";")
+}
+
+
+// generateSqlxSource internal/model/<table>.go with the struct and its named queries.
+func (srv *codegenService) generateSqlxSource(rdbms string, tableIds []int, path string) {
+	path += "/internal/model"
+	if err := os.MkdirAll(path, 0777); err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	for _, t := range srv.getOrmTables(tableIds) {
+		srv.writeFile(path + "/" + srv.tableNameToFileName(t.table.TableName), srv.generateSqlxCode(t))
+	}
+}
+
+
+// generateSqlxCode struct with db tags and "<Table>Select", "<Table>Insert" ... named queries.
+// ("<Table>SelectByPk", "<Table>Update", "<Table>Delete" need primary keys)
+func (srv *codegenService) generateSqlxCode(t ormTable) string {
+	tn := strings.ToLower(t.table.TableName)
+	tnp := SnakeToPascal(tn)
+	versioned := t.table.VersionFlg == constant.FLG_ON
+
+	s := "package model\n\n\n"
+	if t.table.TableNameLogical != "" {
+		s += "// " + tnp + " " + t.table.TableNameLogical + "\n"
+	}
+	s += fmt.Sprintf("type %s struct {\n", tnp)
+	for _, c := range t.columns {
+		s += srv.generateOrmComment(c, "\t")
+		s += fmt.Sprintf(
+			"\t%s %s `db:\"%s\" json:\"%s\"`\n",
+			SnakeToPascal(c.ColumnName), srv.ormGoType(c), strings.ToLower(c.ColumnName), strings.ToLower(c.ColumnName),
+		)
+	}
+	if versioned {
+		s += "\tVersion int `db:\"version\" json:\"version\"`\n"
+	}
+	s += "\tCreatedAt string `db:\"created_at\" json:\"created_at\"`\n"
+	s += "\tUpdatedAt string `db:\"updated_at\" json:\"updated_at\"`\n"
+	s += "}\n\n\n"
+
+	var cols, inserts, sets, wheres []string
+	for _, c := range t.columns {
+		cn := strings.ToLower(c.ColumnName)
+		cols = append(cols, cn)
+		if !isSerialType(c.DataTypeCls) {
+			inserts = append(inserts, cn)
+		}
+		if c.PrimaryKeyFlg == constant.FLG_ON {
+			wheres = append(wheres, cn + " = :" + cn)
+		} else if !isSerialType(c.DataTypeCls) {
+			sets = append(sets, cn + " = :" + cn)
+		}
+	}
+	if versioned {
+		cols = append(cols, "version")
+		sets = append(sets, "version = version + 1")
+	}
+	cols = append(cols, "created_at", "updated_at")
+
+	s += fmt.Sprintf("// named queries of %s. (sqlx.NamedExec, sqlx.NamedQuery)\n", tn) +
+		"const (\n" +
+		fmt.Sprintf("\t%sSelect = `SELECT\n\t\t%s\n\t FROM %s`\n\n", tnp, strings.Join(cols, "\n\t\t,"), tn)
+	if len(inserts) > 0 {
+		s += fmt.Sprintf("\t%sInsert = `INSERT INTO %s (\n\t\t%s\n\t ) VALUES (\n\t\t:%s\n\t )`\n",
+			tnp, tn, strings.Join(inserts, "\n\t\t,"), strings.Join(inserts, "\n\t\t,:"))
+	} else {
+		s += fmt.Sprintf("\t%sInsert = `INSERT INTO %s DEFAULT VALUES`\n", tnp, tn)
+	}
+
+	if len(wheres) > 0 {
+		where := strings.Join(wheres, "\n\t   AND ")
+		s += "\n" +
+			fmt.Sprintf("\t%sSelectByPk = %sSelect + `\n\t WHERE %s`\n\n", tnp, tnp, where)
+		if len(sets) > 0 {
+			updateWhere := where
+			if versioned {
+				updateWhere += "\n\t   AND version = :version"
+			}
+			s += fmt.Sprintf("\t%sUpdate = `UPDATE %s\n\t SET\n\t\t%s\n\t WHERE %s`\n\n",
+				tnp, tn, strings.Join(sets, "\n\t\t,"), updateWhere)
+		}
+		s += fmt.Sprintf("\t%sDelete = `DELETE FROM %s\n\t WHERE %s`\n", tnp, tn, where)
+	}
+	s += ")"
+
+	return s
+}
+
+
+// entFieldMap map DataTypeCls and ent field builders.
+var entFieldMap = map[string]string{
+	constant.DATA_TYPE_CLS_SERIAL: "Int",
+	constant.DATA_TYPE_CLS_BIGSERIAL: "Int64",
+	constant.DATA_TYPE_CLS_TEXT: "Text",
+	constant.DATA_TYPE_CLS_VARCHAR: "String",
+	constant.DATA_TYPE_CLS_CHAR: "String",
+	constant.DATA_TYPE_CLS_UUID: "String",
+	constant.DATA_TYPE_CLS_ENUM: "Enum",
+	constant.DATA_TYPE_CLS_INTEGER: "Int",
+	constant.DATA_TYPE_CLS_BIGINT: "Int64",
+	constant.DATA_TYPE_CLS_SMALLINT: "Int16",
+	constant.DATA_TYPE_CLS_NUMERIC: "Float",
+	constant.DATA_TYPE_CLS_REAL: "Float32",
+	constant.DATA_TYPE_CLS_DOUBLE: "Float",
+	constant.DATA_TYPE_CLS_TIMESTAMP: "Time",
+	constant.DATA_TYPE_CLS_DATE: "Time",
+	constant.DATA_TYPE_CLS_TIME: "String",
+	constant.DATA_TYPE_CLS_TIMESTAMPTZ: "Time",
+	constant.DATA_TYPE_CLS_BLOB: "Bytes",
+	constant.DATA_TYPE_CLS_BOOLEAN: "Bool",
+	constant.DATA_TYPE_CLS_JSON: "JSON",
+	constant.DATA_TYPE_CLS_JSONB: "JSON",
+}
+
+
+// generateEntSource ent/schema/<table>.go and ent/generate.go.
+func (srv *codegenService) generateEntSource(rdbms string, tableIds []int, path string) {
+	path += "/ent"
+	if err := os.MkdirAll(path + "/schema", 0777); err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	tables := srv.getOrmTables(tableIds)
+	byId := map[int]ormTable{}
+	for _, t := range tables {
+		byId[t.table.TableId] = t
+	}
+
+	srv.writeFile(path + "/generate.go",
+		"package ent\n\n//go:generate go run -mod=mod entgo.io/ent/cmd/ent generate ./schema")
+	for _, t := range tables {
+		srv.writeFile(path + "/schema/" + srv.tableNameToFileName(t.table.TableName), srv.generateEntCode(t, byId))
+	}
+}
+
+
+// getEntIdColumn the primary key mapped to the "id" field of ent.
+// nil for composite or no primary key, or types ent can't use as id.
+func (srv *codegenService) getEntIdColumn(columns []model.Column) *model.Column {
+	pks := srv.extractPrimaryKeys(columns)
+	if len(pks) != 1 {
+		return nil
+	}
+	switch entFieldMap[pks[0].DataTypeCls] {
+	case "Int", "Int64", "String", "Text":
+		return &pks[0]
+	}
+	return nil
+}
+
+
+// isEntEdge relationship is an edge of ent.
+// (reference to the "id" field from a field other than "id")
+func (srv *codegenService) isEntEdge(r ormRelation, byId map[int]ormTable) bool {
+	id := srv.getEntIdColumn(byId[r.table.TableId].columns)
+	if id != nil && id.ColumnId == r.fk.column.ColumnId {
+		return false
+	}
+	refId := srv.getEntIdColumn(byId[r.fk.refTable.TableId].columns)
+	return refId != nil && refId.ColumnId == r.fk.refColumn.ColumnId
+}
+
+
+func (srv *codegenService) generateEntCode(t ormTable, byId map[int]ormTable) string {
+	tn := strings.ToLower(t.table.TableName)
+	tnp := SnakeToPascal(tn)
+	id := srv.getEntIdColumn(t.columns)
+	pks := srv.extractPrimaryKeys(t.columns)
+
+	fields := ""
+	imports := map[string]bool{}
+	for _, c := range t.columns {
+		f := srv.generateEntField(c, id != nil && id.ColumnId == c.ColumnId)
+		if strings.Contains(f, "json.RawMessage") {
+			imports["encoding/json"] = true
+		}
+		fields += "\t\t" + f + ",\n"
+	}
+	if t.table.VersionFlg == constant.FLG_ON {
+		fields += "\t\tfield.Int(\"version\").Default(0),\n"
+	}
+	fields += "\t\tfield.Time(\"created_at\").Default(time.Now).Immutable(),\n" +
+		"\t\tfield.Time(\"updated_at\").Default(time.Now).UpdateDefault(time.Now),\n"
+
+	edges := ""
+	for _, r := range t.fks {
+		if !srv.isEntEdge(r, byId) {
+			continue
+		}
+		edges += fmt.Sprintf("\t\tedge.From(\"%s\", %s.Type).Ref(\"%s\").Field(\"%s\").Unique()",
+			r.name, SnakeToPascal(r.fk.refTable.TableName), r.refName, strings.ToLower(r.fk.column.ColumnName))
+		if !srv.isNullableColumn(r.fk.column) {
+			edges += ".Required()"
+		}
+		edges += ",\n"
+	}
+	for _, r := range t.refs {
+		if srv.isEntEdge(r, byId) {
+			edges += fmt.Sprintf("\t\tedge.To(\"%s\", %s.Type),\n", r.refName, SnakeToPascal(r.table.TableName))
+		}
+	}
+
+	s := "package schema\n\n" +
+		"import (\n"
+	if imports["encoding/json"] {
+		s += "\t\"encoding/json\"\n"
+	}
+	s += "\t\"time\"\n\n" +
+		"\t\"entgo.io/ent\"\n" +
+		"\t\"entgo.io/ent/dialect/entsql\"\n" +
+		"\t\"entgo.io/ent/schema\"\n"
+	if edges != "" {
+		s += "\t\"entgo.io/ent/schema/edge\"\n"
+	}
+	s += "\t\"entgo.io/ent/schema/field\"\n"
+	if id == nil && len(pks) > 0 {
+		s += "\t\"entgo.io/ent/schema/index\"\n"
+	}
+	s += ")\n\n\n"
+
+	s += fmt.Sprintf("// %s holds the schema definition for the %s entity.", tnp, tn)
+	if t.table.TableNameLogical != "" {
+		s += " (" + t.table.TableNameLogical + ")"
+	}
+	s += "\n"
+	if id == nil {
+		s += "// ent adds \"id\" column, because the primary key is not a single int or string column.\n"
+	}
+	s += fmt.Sprintf("type %s struct {\n\tent.Schema\n}\n\n\n", tnp)
+
+	s += fmt.Sprintf("func (%s) Annotations() []schema.Annotation {\n", tnp) +
+		fmt.Sprintf("\treturn []schema.Annotation{\n\t\tentsql.Annotation{Table: \"%s\"},\n\t}\n}\n\n\n", tn)
+
+	s += fmt.Sprintf("func (%s) Fields() []ent.Field {\n", tnp) +
+		"\treturn []ent.Field{\n" + fields + "\t}\n}\n\n\n"
+
+	s += fmt.Sprintf("func (%s) Edges() []ent.Edge {\n", tnp)
+	if edges == "" {
+		s += "\treturn nil\n}"
+	} else {
+		s += "\treturn []ent.Edge{\n" + edges + "\t}\n}"
+	}
+
+	if id == nil && len(pks) > 0 {
+		var names []string
+		for _, pk := range pks {
+			names = append(names, jsonString(strings.ToLower(pk.ColumnName)))
+		}
+		s += "\n\n\n" +
+			fmt.Sprintf("// Indexes primary key of %s.\n", tn) +
+			fmt.Sprintf("func (%s) Indexes() []ent.Index {\n", tnp) +
+			"\treturn []ent.Index{\n" +
+			fmt.Sprintf("\t\tindex.Fields(%s).Unique(),\n", strings.Join(names, ", ")) +
+			"\t}\n}"
+	}
+
+	return s
+}
+
+
+// generateEntField "field.String(\"name\").MaxLen(50).Optional().Nillable()..."
+// id: the column is the primary key mapped to "id".
+func (srv *codegenService) generateEntField(column model.Column, id bool) string {
+	cn := strings.ToLower(column.ColumnName)
+	builder := entFieldMap[column.DataTypeCls]
+
+	s := ""
+	if id {
+		s = fmt.Sprintf("field.%s(\"id\").StorageKey(\"%s\")", builder, cn)
+	} else if builder == "JSON" {
+		s = fmt.Sprintf("field.JSON(\"%s\", json.RawMessage{})", cn)
+	} else {
+		s = fmt.Sprintf("field.%s(\"%s\")", builder, cn)
+	}
+
+	if builder == "Enum" {
+		var values []string
+		for _, v := range srv.getEnumValues(column) {
+			values = append(values, jsonString(v))
+		}
+		s += ".Values(" + strings.Join(values, ", ") + ")"
+	}
+	if builder == "String" && column.Precision > 0 && column.DataTypeCls != constant.DATA_TYPE_CLS_TIME {
+		s += fmt.Sprintf(".MaxLen(%d)", column.Precision)
+	}
+
+	if column.DefaultValue != "" {
+		switch builder {
+		case "Int", "Int64", "Int16", "Float", "Float32":
+			s += ".Default(" + column.DefaultValue + ")"
+		case "Bool":
+			v := strings.ToLower(column.DefaultValue)
+			s += fmt.Sprintf(".Default(%t)", v == "1" || v == "true")
+		case "String", "Text", "Enum":
+			s += ".Default(" + jsonString(column.DefaultValue) + ")"
+		}
+	}
+	if srv.isNullableColumn(column) {
+		s += ".Optional().Nillable()"
+	}
+	if column.UniqueFlg == constant.FLG_ON && !id {
+		s += ".Unique()"
+	}
+
+	var comment []string
+	if column.ColumnNameLogical != "" {
+		comment = append(comment, column.ColumnNameLogical)
+	}
+	if column.Remark != "" {
+		comment = append(comment, column.Remark)
+	}
+	if len(comment) > 0 {
+		s += ".Comment(" + jsonString(strings.Join(comment, "\n")) + ")"
+	}
+
+	return s
+}
+
+
+// generateSqlcSource sqlc.yaml, db/schema.sql (DDL of GOAT) and db/query.sql.
+func (srv *codegenService) generateSqlcSource(rdbms string, tableIds []int, path string) {
+	if err := os.MkdirAll(path + "/db", 0777); err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	engine := rdbms
+	if engine == "sqlite3" {
+		engine = "sqlite"
+	}
+	srv.writeFile(path + "/sqlc.yaml", "version: \"2\"\n" +
+		"sql:\n" +
+		"  - engine: \"" + engine + "\"\n" +
+		"    schema: \"db/schema.sql\"\n" +
+		"    queries: \"db/query.sql\"\n" +
+		"    gen:\n" +
+		"      go:\n" +
+		"        package: \"db\"\n" +
+		"        out: \"internal/db\"\n" +
+		"        emit_json_tags: true\n")
+	srv.writeFile(path + "/db/schema.sql", srv.generateDdlCreateTables(rdbms, tableIds, false))
+
+	s := ""
+	for _, t := range srv.getOrmTables(tableIds) {
+		s += srv.generateSqlcQueries(rdbms, t)
+	}
+	srv.writeFile(path + "/db/query.sql", strings.TrimRight(s, "\n") + "\n")
+}
+
+
+// generateSqlcQueries "-- name: List<Table> :many", Get, Create, Update, Delete.
+// Update of versioned table is ":execrows" (0 rows: version conflict).
+func (srv *codegenService) generateSqlcQueries(rdbms string, t ormTable) string {
+	tn := strings.ToLower(t.table.TableName)
+	tnp := SnakeToPascal(tn)
+	versioned := t.table.VersionFlg == constant.FLG_ON
+	pks := srv.extractPrimaryKeys(t.columns)
+
+	s := fmt.Sprintf("-- name: List%s :many\nSELECT * FROM %s", tnp, tn)
+	if len(pks) > 0 {
+		var order []string
+		for _, pk := range pks {
+			order = append(order, strings.ToLower(pk.ColumnName))
+		}
+		s += "\nORDER BY " + strings.Join(order, ", ")
+	}
+	s += ";\n\n"
+
+	var inserts []string
+	for _, c := range t.columns {
+		if !isSerialType(c.DataTypeCls) {
+			inserts = append(inserts, strings.ToLower(c.ColumnName))
+		}
+	}
+	if rdbms == "mysql" {
+		s += fmt.Sprintf("-- name: Create%s :execresult\n", tnp)
+	} else {
+		s += fmt.Sprintf("-- name: Create%s :one\n", tnp)
+	}
+	if len(inserts) > 0 {
+		s += fmt.Sprintf("INSERT INTO %s (\n\t%s\n) VALUES (\n\t%s\n)", tn,
+			strings.Join(inserts, ", "), srv.concatBindVariableWithCommas(rdbms, len(inserts)))
+	} else {
+		s += fmt.Sprintf("INSERT INTO %s DEFAULT VALUES", tn)
+	}
+	if rdbms != "mysql" {
+		s += "\nRETURNING *"
+	}
+	s += ";\n\n"
+
+	if len(pks) == 0 {
+		return s
+	}
+
+	where := func(n int) string {
+		var ls []string
+		for _, pk := range pks {
+			n++
+			ls = append(ls, strings.ToLower(pk.ColumnName) + " = " + srv.getBindVar(rdbms, n))
+		}
+		return "WHERE " + strings.Join(ls, " AND ")
+	}
+
+	s += fmt.Sprintf("-- name: Get%s :one\nSELECT * FROM %s\n%s;\n\n", tnp, tn, where(0))
+
+	var sets []string
+	for _, c := range t.columns {
+		if !isSerialType(c.DataTypeCls) && c.PrimaryKeyFlg != constant.FLG_ON {
+			sets = append(sets, strings.ToLower(c.ColumnName) + " = " + srv.getBindVar(rdbms, len(sets) + 1))
+		}
+	}
+	n := len(sets)
+	if versioned {
+		sets = append(sets, "version = version + 1")
+	}
+	if len(sets) > 0 {
+		if versioned {
+			s += fmt.Sprintf("-- name: Update%s :execrows\n", tnp)
+		} else {
+			s += fmt.Sprintf("-- name: Update%s :exec\n", tnp)
+		}
+		s += fmt.Sprintf("UPDATE %s SET\n\t%s\n%s", tn, strings.Join(sets, ",\n\t"), where(n))
+		if versioned {
+			s += " AND version = " + srv.getBindVar(rdbms, n + len(pks) + 1)
+		}
+		s += ";\n\n"
+	}
+
+	s += fmt.Sprintf("-- name: Delete%s :exec\nDELETE FROM %s\n%s;\n\n", tnp, tn, where(0))
+
+	return s
+}
\ No newline at end of file
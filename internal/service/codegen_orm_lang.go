@@ -0,0 +1,717 @@
+package service
+
+import (
+	"os"
+	"fmt"
+	"sort"
+	"regexp"
+	"strings"
+
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/core/logger"
+	"goat-cg/internal/model"
+)
+
+
+// getOrmKeyColumns primary keys, or all columns for tables without primary key.
+// (SQLAlchemy and JPA need an identity)
+func (srv *codegenService) getOrmKeyColumns(columns []model.Column) []model.Column {
+	if pks := srv.extractPrimaryKeys(columns); len(pks) > 0 {
+		return pks
+	}
+	return columns
+}
+
+
+// ormDescription ColumnNameLogical and Remark.
+func (srv *codegenService) ormDescription(column model.Column) string {
+	var ls []string
+	if column.ColumnNameLogical != "" {
+		ls = append(ls, column.ColumnNameLogical)
+	}
+	if column.Remark != "" {
+		ls = append(ls, strings.ReplaceAll(column.Remark, "\r\n", "\n"))
+	}
+	return strings.Join(ls, "\n")
+}
+
+
+// sqlalchemyTypeMap map DataTypeCls and (SQLAlchemy type, Python type).
+var sqlalchemyTypeMap = map[string][2]string{
+	constant.DATA_TYPE_CLS_SERIAL: {"Integer", "int"},
+	constant.DATA_TYPE_CLS_BIGSERIAL: {"BigInteger", "int"},
+	constant.DATA_TYPE_CLS_TEXT: {"Text", "str"},
+	constant.DATA_TYPE_CLS_VARCHAR: {"String", "str"},
+	constant.DATA_TYPE_CLS_CHAR: {"CHAR", "str"},
+	constant.DATA_TYPE_CLS_UUID: {"Uuid", "str"},
+	constant.DATA_TYPE_CLS_ENUM: {"Enum", "str"},
+	constant.DATA_TYPE_CLS_INTEGER: {"Integer", "int"},
+	constant.DATA_TYPE_CLS_BIGINT: {"BigInteger", "int"},
+	constant.DATA_TYPE_CLS_SMALLINT: {"SmallInteger", "int"},
+	constant.DATA_TYPE_CLS_NUMERIC: {"Numeric", "Decimal"},
+	constant.DATA_TYPE_CLS_REAL: {"REAL", "float"},
+	constant.DATA_TYPE_CLS_DOUBLE: {"Double", "float"},
+	constant.DATA_TYPE_CLS_TIMESTAMP: {"DateTime", "datetime.datetime"},
+	constant.DATA_TYPE_CLS_DATE: {"Date", "datetime.date"},
+	constant.DATA_TYPE_CLS_TIME: {"Time", "datetime.time"},
+	constant.DATA_TYPE_CLS_TIMESTAMPTZ: {"DateTime", "datetime.datetime"},
+	constant.DATA_TYPE_CLS_BLOB: {"LargeBinary", "bytes"},
+	constant.DATA_TYPE_CLS_BOOLEAN: {"Boolean", "bool"},
+	constant.DATA_TYPE_CLS_JSON: {"JSON", "Any"},
+	constant.DATA_TYPE_CLS_JSONB: {"JSON", "Any"},
+}
+
+
+// generateSqlalchemySource models.py of SQLAlchemy 2.0 declarative mapping.
+func (srv *codegenService) generateSqlalchemySource(rdbms string, tableIds []int, path string) {
+	if err := os.MkdirAll(path, 0777); err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	imports := map[string]bool{"func": true}
+	classes := ""
+	for _, t := range srv.getOrmTables(tableIds) {
+		classes += "\n\n" + srv.generateSqlalchemyClass(t, imports)
+	}
+	var names []string
+	for name := range imports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	s := "from __future__ import annotations\n\n" +
+		"import datetime\n" +
+		"from decimal import Decimal\n" +
+		"from typing import Any, List, Optional\n\n" +
+		"from sqlalchemy import " + strings.Join(names, ", ") + "\n" +
+		"from sqlalchemy.orm import DeclarativeBase, Mapped, mapped_column, relationship\n\n\n" +
+		"class Base(DeclarativeBase):\n" +
+		"    pass\n" + classes
+
+	srv.writeFile(path + "/models.py", s)
+}
+
+
+// generateSqlalchemyClass "class <Table>(Base)" with relationships.
+// version column is "version_id_col" (optimistic locking of SQLAlchemy).
+func (srv *codegenService) generateSqlalchemyClass(t ormTable, imports map[string]bool) string {
+	tn := strings.ToLower(t.table.TableName)
+	pks := srv.extractPrimaryKeys(t.columns)
+	fks := map[int]ormRelation{}
+	for _, r := range t.fks {
+		fks[r.fk.column.ColumnId] = r
+	}
+
+	s := "class " + SnakeToPascal(tn) + "(Base):\n"
+	if t.table.TableNameLogical != "" {
+		s += "    " + jsonString(t.table.TableNameLogical) + "\n\n"
+	}
+	s += "    __tablename__ = \"" + tn + "\"\n\n"
+
+	for _, c := range t.columns {
+		cn := strings.ToLower(c.ColumnName)
+		types := sqlalchemyTypeMap[c.DataTypeCls]
+		imports[types[0]] = true
+
+		args := []string{srv.generateSqlalchemyType(tn, c)}
+		if r, ok := fks[c.ColumnId]; ok {
+			imports["ForeignKey"] = true
+			args = append(args, fmt.Sprintf("ForeignKey(\"%s.%s\")",
+				strings.ToLower(r.fk.refTable.TableName), strings.ToLower(r.fk.refColumn.ColumnName)))
+		}
+		if c.PrimaryKeyFlg == constant.FLG_ON {
+			args = append(args, "primary_key=True")
+			if isSerialType(c.DataTypeCls) {
+				args = append(args, "autoincrement=True")
+			}
+		}
+		if !srv.isNullableColumn(c) {
+			args = append(args, "nullable=False")
+		}
+		if c.UniqueFlg == constant.FLG_ON {
+			args = append(args, "unique=True")
+		}
+		if c.DefaultValue != "" {
+			args = append(args, "server_default=" + jsonString(c.DefaultValue))
+		}
+		if desc := srv.ormDescription(c); desc != "" {
+			args = append(args, "comment=" + jsonString(desc))
+		}
+
+		pyType := types[1]
+		if srv.isNullableColumn(c) {
+			pyType = "Optional[" + pyType + "]"
+		}
+		s += fmt.Sprintf("    %s: Mapped[%s] = mapped_column(%s)\n", cn, pyType, strings.Join(args, ", "))
+	}
+
+	if t.table.VersionFlg == constant.FLG_ON {
+		imports["Integer"] = true
+		s += "    version: Mapped[int] = mapped_column(Integer, nullable=False, server_default=\"0\")\n"
+	}
+	imports["DateTime"] = true
+	s += "    created_at: Mapped[datetime.datetime] = mapped_column(DateTime, nullable=False, server_default=func.now())\n" +
+		"    updated_at: Mapped[datetime.datetime] = mapped_column(DateTime, nullable=False, server_default=func.now(), onupdate=func.now())\n"
+
+	if len(t.fks) + len(t.refs) > 0 {
+		s += "\n"
+	}
+	for _, r := range t.fks {
+		ref := "\"" + SnakeToPascal(r.fk.refTable.TableName) + "\""
+		if srv.isNullableColumn(r.fk.column) {
+			ref = "Optional[" + ref + "]"
+		}
+		s += fmt.Sprintf("    %s: Mapped[%s] = relationship(foreign_keys=[%s], back_populates=\"%s\")\n",
+			r.name, ref, strings.ToLower(r.fk.column.ColumnName), r.refName)
+	}
+	for _, r := range t.refs {
+		from := SnakeToPascal(r.table.TableName)
+		s += fmt.Sprintf("    %s: Mapped[List[\"%s\"]] = relationship(foreign_keys=\"%s.%s\", back_populates=\"%s\")\n",
+			r.refName, from, from, strings.ToLower(r.fk.column.ColumnName), r.name)
+	}
+
+	var mapperArgs []string
+	if len(pks) == 0 {
+		var cols []string
+		for _, c := range srv.getOrmKeyColumns(t.columns) {
+			cols = append(cols, strings.ToLower(c.ColumnName))
+		}
+		mapperArgs = append(mapperArgs, "\"primary_key\": [" + strings.Join(cols, ", ") + "]")
+	}
+	if t.table.VersionFlg == constant.FLG_ON {
+		mapperArgs = append(mapperArgs, "\"version_id_col\": version")
+	}
+	if len(mapperArgs) > 0 {
+		s += "\n"
+		if len(pks) == 0 {
+			s += "    # no primary key: all columns are the identity of the mapper\n"
+		}
+		s += "    __mapper_args__ = {" + strings.Join(mapperArgs, ", ") + "}\n"
+	}
+
+	return s
+}
+
+
+// generateSqlalchemyType "String(50)", "Numeric(10, 2)", "Enum(\"a\", \"b\", name=...)" ...
+func (srv *codegenService) generateSqlalchemyType(tableName string, column model.Column) string {
+	t := sqlalchemyTypeMap[column.DataTypeCls][0]
+
+	switch column.DataTypeCls {
+	case constant.DATA_TYPE_CLS_VARCHAR, constant.DATA_TYPE_CLS_CHAR:
+		if column.Precision > 0 {
+			return fmt.Sprintf("%s(%d)", t, column.Precision)
+		}
+	case constant.DATA_TYPE_CLS_NUMERIC:
+		if column.Precision > 0 {
+			return fmt.Sprintf("%s(%d, %d)", t, column.Precision, column.Scale)
+		}
+	case constant.DATA_TYPE_CLS_UUID:
+		return t + "(as_uuid=False)"
+	case constant.DATA_TYPE_CLS_TIMESTAMPTZ:
+		return t + "(timezone=True)"
+	case constant.DATA_TYPE_CLS_ENUM:
+		var values []string
+		for _, v := range srv.getEnumValues(column) {
+			values = append(values, jsonString(v))
+		}
+		values = append(values, "name=\"" + tableName + "_" + strings.ToLower(column.ColumnName) + "\"")
+		return t + "(" + strings.Join(values, ", ") + ")"
+	}
+
+	return t
+}
+
+
+// jpaTypeMap map DataTypeCls and Java types.
+var jpaTypeMap = map[string]string{
+	constant.DATA_TYPE_CLS_SERIAL: "Integer",
+	constant.DATA_TYPE_CLS_BIGSERIAL: "Long",
+	constant.DATA_TYPE_CLS_TEXT: "String",
+	constant.DATA_TYPE_CLS_VARCHAR: "String",
+	constant.DATA_TYPE_CLS_CHAR: "String",
+	constant.DATA_TYPE_CLS_UUID: "UUID",
+	constant.DATA_TYPE_CLS_ENUM: "String",
+	constant.DATA_TYPE_CLS_INTEGER: "Integer",
+	constant.DATA_TYPE_CLS_BIGINT: "Long",
+	constant.DATA_TYPE_CLS_SMALLINT: "Short",
+	constant.DATA_TYPE_CLS_NUMERIC: "BigDecimal",
+	constant.DATA_TYPE_CLS_REAL: "Float",
+	constant.DATA_TYPE_CLS_DOUBLE: "Double",
+	constant.DATA_TYPE_CLS_TIMESTAMP: "LocalDateTime",
+	constant.DATA_TYPE_CLS_DATE: "LocalDate",
+	constant.DATA_TYPE_CLS_TIME: "LocalTime",
+	constant.DATA_TYPE_CLS_TIMESTAMPTZ: "OffsetDateTime",
+	constant.DATA_TYPE_CLS_BLOB: "byte[]",
+	constant.DATA_TYPE_CLS_BOOLEAN: "Boolean",
+	constant.DATA_TYPE_CLS_JSON: "String",
+	constant.DATA_TYPE_CLS_JSONB: "String",
+}
+
+
+// generateJpaSource src/main/java/xxxxx/entity/<Table>.java (jakarta.persistence)
+func (srv *codegenService) generateJpaSource(rdbms string, tableIds []int, path string) {
+	path += "/src/main/java/xxxxx/entity"
+	if err := os.MkdirAll(path, 0777); err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	for _, t := range srv.getOrmTables(tableIds) {
+		srv.writeFile(path + "/" + SnakeToPascal(t.table.TableName) + ".java", srv.generateJpaCode(t))
+	}
+}
+
+
+// jpaField a field of the entity class and its annotations.
+type jpaField struct {
+	name string
+	javaType string
+	annotations []string
+	doc string
+}
+
+
+// generateJpaCode "@Entity" class with getters and setters.
+// composite (or no) primary key uses "@IdClass(<Table>.PK.class)".
+func (srv *codegenService) generateJpaCode(t ormTable) string {
+	tn := strings.ToLower(t.table.TableName)
+	tnp := SnakeToPascal(tn)
+	keys := srv.getOrmKeyColumns(t.columns)
+	isKey := map[int]bool{}
+	for _, k := range keys {
+		isKey[k.ColumnId] = true
+	}
+
+	var fields []jpaField
+	for _, c := range t.columns {
+		f := jpaField{name: SnakeToCamel(c.ColumnName), javaType: jpaTypeMap[c.DataTypeCls], doc: srv.ormDescription(c)}
+		if c.DataTypeCls == constant.DATA_TYPE_CLS_ENUM || c.ClassificationId != 0 {
+			if values := srv.getEnumValues(c); len(values) > 0 {
+				f.doc = strings.TrimLeft(f.doc + "\nvalues: " + strings.Join(values, ", "), "\n")
+			}
+		}
+		if isKey[c.ColumnId] {
+			f.annotations = append(f.annotations, "@Id")
+		}
+		if isSerialType(c.DataTypeCls) && c.PrimaryKeyFlg == constant.FLG_ON {
+			f.annotations = append(f.annotations, "@GeneratedValue(strategy = GenerationType.IDENTITY)")
+		}
+		if c.DataTypeCls == constant.DATA_TYPE_CLS_BLOB {
+			f.annotations = append(f.annotations, "@Lob")
+		}
+		f.annotations = append(f.annotations, srv.generateJpaColumn(c))
+		fields = append(fields, f)
+	}
+	if t.table.VersionFlg == constant.FLG_ON {
+		fields = append(fields, jpaField{
+			name: "version", javaType: "Integer",
+			annotations: []string{"@Version", "@Column(name = \"version\", nullable = false)"},
+		})
+	}
+	fields = append(fields,
+		jpaField{
+			name: "createdAt", javaType: "LocalDateTime",
+			annotations: []string{"@Column(name = \"created_at\", insertable = false, updatable = false)"},
+		},
+		jpaField{
+			name: "updatedAt", javaType: "LocalDateTime",
+			annotations: []string{"@Column(name = \"updated_at\", insertable = false, updatable = false)"},
+		},
+	)
+	for _, r := range t.fks {
+		fields = append(fields, jpaField{
+			name: SnakeToCamel(r.name), javaType: SnakeToPascal(r.fk.refTable.TableName),
+			annotations: []string{
+				"@ManyToOne(fetch = FetchType.LAZY)",
+				fmt.Sprintf("@JoinColumn(name = \"%s\", referencedColumnName = \"%s\", insertable = false, updatable = false)",
+					strings.ToLower(r.fk.column.ColumnName), strings.ToLower(r.fk.refColumn.ColumnName)),
+			},
+		})
+	}
+	for _, r := range t.refs {
+		fields = append(fields, jpaField{
+			name: SnakeToCamel(r.refName), javaType: "List<" + SnakeToPascal(r.table.TableName) + ">",
+			annotations: []string{fmt.Sprintf("@OneToMany(mappedBy = \"%s\")", SnakeToCamel(r.name))},
+		})
+	}
+
+	s := "package xxxxx.entity;\n\n" +
+		"import jakarta.persistence.*;\n" +
+		"import java.io.Serializable;\n" +
+		"import java.math.BigDecimal;\n" +
+		"import java.time.*;\n" +
+		"import java.util.*;\n\n"
+	if t.table.TableNameLogical != "" {
+		s += "/** " + strings.ReplaceAll(t.table.TableNameLogical, "*/", "* /") + " */\n"
+	}
+	s += "@Entity\n" +
+		"@Table(name = \"" + tn + "\")\n"
+	if len(keys) > 1 {
+		s += "@IdClass(" + tnp + ".PK.class)\n"
+	}
+	s += "public class " + tnp + " {\n"
+
+	for _, f := range fields {
+		s += srv.generateJavaDoc(f.doc, "\t")
+		for _, a := range f.annotations {
+			s += "\t" + a + "\n"
+		}
+		s += "\tprivate " + f.javaType + " " + f.name
+		if strings.HasPrefix(f.javaType, "List<") {
+			s += " = new ArrayList<>()"
+		}
+		s += ";\n\n"
+	}
+
+	for _, f := range fields {
+		s += srv.generateJavaAccessors(f.javaType, f.name, "\t")
+	}
+
+	if len(keys) > 1 {
+		s += srv.generateJpaIdClass(keys)
+	}
+
+	return strings.TrimRight(s, "\n") + "\n}\n"
+}
+
+
+// generateJpaColumn "@Column(name = ..., nullable = false, unique = true, length = n)"
+func (srv *codegenService) generateJpaColumn(column model.Column) string {
+	args := []string{"name = \"" + strings.ToLower(column.ColumnName) + "\""}
+	if !srv.isNullableColumn(column) {
+		args = append(args, "nullable = false")
+	}
+	if column.UniqueFlg == constant.FLG_ON {
+		args = append(args, "unique = true")
+	}
+	if column.Precision > 0 {
+		switch column.DataTypeCls {
+		case constant.DATA_TYPE_CLS_VARCHAR, constant.DATA_TYPE_CLS_CHAR:
+			args = append(args, fmt.Sprintf("length = %d", column.Precision))
+		case constant.DATA_TYPE_CLS_NUMERIC:
+			args = append(args, fmt.Sprintf("precision = %d", column.Precision), fmt.Sprintf("scale = %d", column.Scale))
+		}
+	}
+	return "@Column(" + strings.Join(args, ", ") + ")"
+}
+
+
+func (srv *codegenService) generateJavaDoc(doc, indent string) string {
+	if doc == "" {
+		return ""
+	}
+	ls := strings.Split(strings.ReplaceAll(doc, "*/", "* /"), "\n")
+	if len(ls) == 1 {
+		return indent + "/** " + ls[0] + " */\n"
+	}
+	return indent + "/**\n" + indent + " * " + strings.Join(ls, "\n" + indent + " * ") + "\n" + indent + " */\n"
+}
+
+
+func (srv *codegenService) generateJavaAccessors(javaType, name, indent string) string {
+	pascal := strings.ToUpper(name[0:1]) + name[1:]
+	return fmt.Sprintf("%spublic %s get%s() {\n%s\treturn %s;\n%s}\n\n", indent, javaType, pascal, indent, name, indent) +
+		fmt.Sprintf("%spublic void set%s(%s %s) {\n%s\tthis.%s = %s;\n%s}\n\n",
+			indent, pascal, javaType, name, indent, name, name, indent)
+}
+
+
+// generateJpaIdClass "public static class PK implements Serializable" with equals and hashCode.
+func (srv *codegenService) generateJpaIdClass(keys []model.Column) string {
+	var names, params, args, equals []string
+	for _, k := range keys {
+		n := SnakeToCamel(k.ColumnName)
+		names = append(names, n)
+		params = append(params, jpaTypeMap[k.DataTypeCls] + " " + n)
+		args = append(args, "\t\t\tthis." + n + " = " + n + ";\n")
+		if jpaTypeMap[k.DataTypeCls] == "byte[]" {
+			equals = append(equals, "Arrays.equals(" + n + ", other." + n + ")")
+		} else {
+			equals = append(equals, "Objects.equals(" + n + ", other." + n + ")")
+		}
+	}
+
+	s := "\t/** primary key of @IdClass */\n" +
+		"\tpublic static class PK implements Serializable {\n"
+	for _, p := range params {
+		s += "\t\tprivate " + p + ";\n"
+	}
+	s += "\n\t\tpublic PK() {}\n\n" +
+		"\t\tpublic PK(" + strings.Join(params, ", ") + ") {\n" + strings.Join(args, "") + "\t\t}\n\n"
+	for i, k := range keys {
+		s += srv.generateJavaAccessors(jpaTypeMap[k.DataTypeCls], names[i], "\t\t")
+	}
+	s += "\t\t@Override\n" +
+		"\t\tpublic boolean equals(Object o) {\n" +
+		"\t\t\tif (this == o) return true;\n" +
+		"\t\t\tif (!(o instanceof PK)) return false;\n" +
+		"\t\t\tPK other = (PK) o;\n" +
+		"\t\t\treturn " + strings.Join(equals, "\n\t\t\t\t&& ") + ";\n" +
+		"\t\t}\n\n" +
+		"\t\t@Override\n" +
+		"\t\tpublic int hashCode() {\n" +
+		"\t\t\treturn Objects.hash(" + strings.Join(names, ", ") + ");\n" +
+		"\t\t}\n" +
+		"\t}\n"
+
+	return s
+}
+
+
+// prismaTypeMap map DataTypeCls and Prisma scalar types.
+var prismaTypeMap = map[string]string{
+	constant.DATA_TYPE_CLS_SERIAL: "Int",
+	constant.DATA_TYPE_CLS_BIGSERIAL: "BigInt",
+	constant.DATA_TYPE_CLS_TEXT: "String",
+	constant.DATA_TYPE_CLS_VARCHAR: "String",
+	constant.DATA_TYPE_CLS_CHAR: "String",
+	constant.DATA_TYPE_CLS_UUID: "String",
+	constant.DATA_TYPE_CLS_ENUM: "String",
+	constant.DATA_TYPE_CLS_INTEGER: "Int",
+	constant.DATA_TYPE_CLS_BIGINT: "BigInt",
+	constant.DATA_TYPE_CLS_SMALLINT: "Int",
+	constant.DATA_TYPE_CLS_NUMERIC: "Decimal",
+	constant.DATA_TYPE_CLS_REAL: "Float",
+	constant.DATA_TYPE_CLS_DOUBLE: "Float",
+	constant.DATA_TYPE_CLS_TIMESTAMP: "DateTime",
+	constant.DATA_TYPE_CLS_DATE: "DateTime",
+	constant.DATA_TYPE_CLS_TIME: "DateTime",
+	constant.DATA_TYPE_CLS_TIMESTAMPTZ: "DateTime",
+	constant.DATA_TYPE_CLS_BLOB: "Bytes",
+	constant.DATA_TYPE_CLS_BOOLEAN: "Boolean",
+	constant.DATA_TYPE_CLS_JSON: "Json",
+	constant.DATA_TYPE_CLS_JSONB: "Json",
+}
+
+// prismaNativeTypeMap native type attributes. ("@db.VarChar" gets the precision)
+var prismaNativeTypeMap = map[string]map[string]string{
+	"postgresql": {
+		constant.DATA_TYPE_CLS_VARCHAR: "@db.VarChar",
+		constant.DATA_TYPE_CLS_CHAR: "@db.Char",
+		constant.DATA_TYPE_CLS_UUID: "@db.Uuid",
+		constant.DATA_TYPE_CLS_SMALLINT: "@db.SmallInt",
+		constant.DATA_TYPE_CLS_NUMERIC: "@db.Decimal",
+		constant.DATA_TYPE_CLS_REAL: "@db.Real",
+		constant.DATA_TYPE_CLS_DATE: "@db.Date",
+		constant.DATA_TYPE_CLS_TIME: "@db.Time",
+		constant.DATA_TYPE_CLS_TIMESTAMPTZ: "@db.Timestamptz",
+		constant.DATA_TYPE_CLS_JSONB: "@db.JsonB",
+	},
+	"mysql": {
+		constant.DATA_TYPE_CLS_TEXT: "@db.Text",
+		constant.DATA_TYPE_CLS_VARCHAR: "@db.VarChar",
+		constant.DATA_TYPE_CLS_CHAR: "@db.Char",
+		constant.DATA_TYPE_CLS_UUID: "@db.Char(36)",
+		constant.DATA_TYPE_CLS_SMALLINT: "@db.SmallInt",
+		constant.DATA_TYPE_CLS_NUMERIC: "@db.Decimal",
+		constant.DATA_TYPE_CLS_REAL: "@db.Float",
+		constant.DATA_TYPE_CLS_DATE: "@db.Date",
+		constant.DATA_TYPE_CLS_TIME: "@db.Time",
+	},
+}
+
+
+var prismaIdentifierRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+
+// generatePrismaSource prisma/schema.prisma
+func (srv *codegenService) generatePrismaSource(rdbms string, tableIds []int, path string) {
+	path += "/prisma"
+	if err := os.MkdirAll(path, 0777); err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	provider := rdbms
+	if provider == "sqlite3" {
+		provider = "sqlite"
+	}
+	s := "datasource db {\n" +
+		"  provider = \"" + provider + "\"\n" +
+		"  url      = env(\"DATABASE_URL\")\n" +
+		"}\n\n" +
+		"generator client {\n" +
+		"  provider = \"prisma-client-js\"\n" +
+		"}\n"
+
+	tables := srv.getOrmTables(tableIds)
+	byId := map[int]ormTable{}
+	for _, t := range tables {
+		byId[t.table.TableId] = t
+	}
+	for _, t := range tables {
+		s += "\n" + srv.generatePrismaModel(rdbms, t, byId)
+	}
+
+	srv.writeFile(path + "/schema.prisma", s)
+}
+
+
+// isPrismaRelation relationship is a relation field of Prisma.
+// (reference to unique column, tables have identity)
+func (srv *codegenService) isPrismaRelation(r ormRelation, byId map[int]ormTable) bool {
+	from := srv.extractPrimaryKeys(byId[r.table.TableId].columns)
+	refPks := srv.extractPrimaryKeys(byId[r.fk.refTable.TableId].columns)
+	if len(from) == 0 || len(refPks) == 0 {
+		return false
+	}
+	return r.fk.refColumn.UniqueFlg == constant.FLG_ON ||
+		(len(refPks) == 1 && refPks[0].ColumnId == r.fk.refColumn.ColumnId)
+}
+
+
+// getPrismaEnumValues values of ENUM column, nil if Prisma enum can't be used.
+// (sqlite has no enum, values must be identifiers)
+func (srv *codegenService) getPrismaEnumValues(rdbms string, column model.Column) []string {
+	if rdbms == "sqlite3" || column.DataTypeCls != constant.DATA_TYPE_CLS_ENUM {
+		return nil
+	}
+	values := srv.getEnumValues(column)
+	for _, v := range values {
+		if !prismaIdentifierRegexp.MatchString(v) {
+			return nil
+		}
+	}
+	return values
+}
+
+
+// generatePrismaModel "model <Table>" and enums of the table.
+// tables without primary key are "@@ignore". (Prisma Client needs an identity)
+func (srv *codegenService) generatePrismaModel(rdbms string, t ormTable, byId map[int]ormTable) string {
+	tn := strings.ToLower(t.table.TableName)
+	tnp := SnakeToPascal(tn)
+	pks := srv.extractPrimaryKeys(t.columns)
+	enums := ""
+
+	s := ""
+	if t.table.TableNameLogical != "" {
+		s += "/// " + t.table.TableNameLogical + "\n"
+	}
+	s += "model " + tnp + " {\n"
+
+	for _, c := range t.columns {
+		cn := strings.ToLower(c.ColumnName)
+		name := SnakeToCamel(cn)
+		typ := prismaTypeMap[c.DataTypeCls]
+		if rdbms == "sqlite3" && typ == "Json" {
+			typ = "String"
+		}
+
+		if values := srv.getPrismaEnumValues(rdbms, c); len(values) > 0 {
+			typ = tnp + SnakeToPascal(cn)
+			enums += "\nenum " + typ + " {\n  " + strings.Join(values, "\n  ") + "\n\n" +
+				"  @@map(\"" + tn + "_" + cn + "\")\n}\n"
+		}
+
+		var attrs []string
+		if len(pks) == 1 && c.PrimaryKeyFlg == constant.FLG_ON {
+			attrs = append(attrs, "@id")
+		}
+		if isSerialType(c.DataTypeCls) {
+			attrs = append(attrs, "@default(autoincrement())")
+		} else if c.DefaultValue != "" {
+			if d := srv.generatePrismaDefault(typ, c.DefaultValue); d != "" {
+				attrs = append(attrs, d)
+			}
+		}
+		if c.UniqueFlg == constant.FLG_ON {
+			attrs = append(attrs, "@unique")
+		}
+		if name != cn {
+			attrs = append(attrs, "@map(\"" + cn + "\")")
+		}
+		if native := prismaNativeTypeMap[rdbms][c.DataTypeCls]; native != "" {
+			switch {
+			case c.DataTypeCls == constant.DATA_TYPE_CLS_NUMERIC && c.Precision > 0:
+				native += fmt.Sprintf("(%d, %d)", c.Precision, c.Scale)
+			case native == "@db.VarChar" || native == "@db.Char":
+				if c.Precision == 0 {
+					native = ""
+				} else {
+					native += fmt.Sprintf("(%d)", c.Precision)
+				}
+			}
+			if native != "" {
+				attrs = append(attrs, native)
+			}
+		}
+
+		if desc := srv.ormDescription(c); desc != "" {
+			s += "  /// " + strings.ReplaceAll(desc, "\n", " ") + "\n"
+		}
+		if srv.isNullableColumn(c) {
+			typ += "?"
+		}
+		s += strings.TrimRight("  " + name + " " + typ + " " + strings.Join(attrs, " "), " ") + "\n"
+	}
+
+	if t.table.VersionFlg == constant.FLG_ON {
+		s += "  version Int @default(0)\n"
+	}
+	s += "  createdAt DateTime @default(now()) @map(\"created_at\")\n" +
+		"  updatedAt DateTime @default(now()) @updatedAt @map(\"updated_at\")\n"
+
+	for _, r := range t.fks {
+		if !srv.isPrismaRelation(r, byId) {
+			continue
+		}
+		typ := SnakeToPascal(r.fk.refTable.TableName)
+		if srv.isNullableColumn(r.fk.column) {
+			typ += "?"
+		}
+		s += fmt.Sprintf("  %s %s @relation(\"%s_%s\", fields: [%s], references: [%s])\n",
+			SnakeToCamel(r.name), typ, tn, strings.ToLower(r.fk.column.ColumnName),
+			SnakeToCamel(r.fk.column.ColumnName), SnakeToCamel(r.fk.refColumn.ColumnName))
+	}
+	for _, r := range t.refs {
+		if !srv.isPrismaRelation(r, byId) {
+			continue
+		}
+		from := byId[r.table.TableId]
+		typ := SnakeToPascal(r.table.TableName) + "[]"
+		fromPks := srv.extractPrimaryKeys(from.columns)
+		if r.fk.column.UniqueFlg == constant.FLG_ON ||
+			(len(fromPks) == 1 && fromPks[0].ColumnId == r.fk.column.ColumnId) {
+			typ = SnakeToPascal(r.table.TableName) + "?"
+		}
+		s += fmt.Sprintf("  %s %s @relation(\"%s_%s\")\n",
+			SnakeToCamel(r.refName), typ, strings.ToLower(r.table.TableName), strings.ToLower(r.fk.column.ColumnName))
+	}
+
+	s += "\n"
+	if len(pks) > 1 {
+		var names []string
+		for _, pk := range pks {
+			names = append(names, SnakeToCamel(pk.ColumnName))
+		}
+		s += "  @@id([" + strings.Join(names, ", ") + "])\n"
+	}
+	s += "  @@map(\"" + tn + "\")\n"
+	if len(pks) == 0 {
+		s += "  @@ignore\n"
+	}
+	s += "}\n"
+
+	return s + enums
+}
+
+
+// generatePrismaDefault "@default(v)" for the Prisma type. ("" if not expressible)
+func (srv *codegenService) generatePrismaDefault(typ, value string) string {
+	switch typ {
+	case "Int", "BigInt", "Float", "Decimal":
+		return "@default(" + value + ")"
+	case "Boolean":
+		v := strings.ToLower(value)
+		return fmt.Sprintf("@default(%t)", v == "1" || v == "true")
+	case "String":
+		return "@default(" + jsonString(value) + ")"
+	case "DateTime", "Bytes", "Json":
+		return ""
+	}
+	// enum
+	return "@default(" + value + ")"
+}
\ No newline at end of file
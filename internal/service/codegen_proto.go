@@ -0,0 +1,350 @@
+package service
+
+import (
+	"os"
+	"fmt"
+	"sort"
+	"strings"
+	"strconv"
+	"database/sql"
+
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/core/logger"
+	"goat-cg/internal/model"
+)
+
+
+// GenerateProto generate .proto files (one message per table) and return zip path.
+// field numbers are kept in proto_field, so they don't change across regenerations.
+// grpc: add CRUD service definitions.
+// saveFields: keep the numbers of new fields. (editors) viewers get the same numbers without saving them.
+func (srv *codegenService) GenerateProto(projectName string, tableIds []int, grpc, saveFields bool) string {
+	return srv.generateArchive("proto", func(path string) {
+		path += "/proto"
+		if err := os.MkdirAll(path, 0777); err != nil {
+			logger.Error(err.Error())
+			return
+		}
+
+		pkg := protoPackageName(projectName)
+		for _, tid := range tableIds {
+			table, err := srv.tableRepository.GetOne(&model.Table{TableId: tid})
+			if err != nil {
+				logger.Error(err.Error())
+				break
+			}
+			columns, err := srv.getValidColumns(tid)
+			if err != nil {
+				logger.Error(err.Error())
+				break
+			}
+
+			fields, reserved, err := srv.getProtoFields(&table, columns, saveFields)
+			if err != nil {
+				break
+			}
+
+			srv.writeFile(
+				path + "/" + strings.ToLower(table.TableName) + ".proto",
+				srv.generateProtoCode(pkg, &table, columns, fields, reserved, grpc),
+			)
+		}
+	})
+}
+
+
+// protoTypeMap map DataTypeCls and protobuf scalar types.
+var protoTypeMap = map[string]string{
+	constant.DATA_TYPE_CLS_SERIAL: "int32",
+	constant.DATA_TYPE_CLS_BIGSERIAL: "int64",
+	constant.DATA_TYPE_CLS_TEXT: "string",
+	constant.DATA_TYPE_CLS_VARCHAR: "string",
+	constant.DATA_TYPE_CLS_CHAR: "string",
+	constant.DATA_TYPE_CLS_UUID: "string",
+	constant.DATA_TYPE_CLS_ENUM: "string",
+	constant.DATA_TYPE_CLS_INTEGER: "int32",
+	constant.DATA_TYPE_CLS_BIGINT: "int64",
+	constant.DATA_TYPE_CLS_SMALLINT: "int32",
+	constant.DATA_TYPE_CLS_NUMERIC: "double",
+	constant.DATA_TYPE_CLS_REAL: "float",
+	constant.DATA_TYPE_CLS_DOUBLE: "double",
+	constant.DATA_TYPE_CLS_TIMESTAMP: "google.protobuf.Timestamp",
+	constant.DATA_TYPE_CLS_DATE: "string",
+	constant.DATA_TYPE_CLS_TIME: "string",
+	constant.DATA_TYPE_CLS_TIMESTAMPTZ: "google.protobuf.Timestamp",
+	constant.DATA_TYPE_CLS_BLOB: "bytes",
+	constant.DATA_TYPE_CLS_BOOLEAN: "bool",
+	constant.DATA_TYPE_CLS_JSON: "string",
+	constant.DATA_TYPE_CLS_JSONB: "string",
+}
+
+
+// protoPackageName "My Project-1" -> "my_project_1"
+func protoPackageName(projectName string) string {
+	s := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return '_'
+	}, projectName)
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		s = "p" + s
+	}
+	return s
+}
+
+
+// protoField a field of the message. (column is nil for version, created_at, updated_at)
+type protoField struct {
+	no int
+	name string
+	column *model.Column
+}
+
+
+// getProtoFields return fields of the table ordered by field number and reserved fields.
+// new columns get the next number of the table (numbers are never reused),
+// fields of deleted columns are reserved. save: write new numbers and renamed fields to proto_field.
+func (srv *codegenService) getProtoFields(
+	table *model.Table, columns []model.Column, save bool,
+) ([]protoField, []model.ProtoField, error) {
+	saved, err := srv.protoFieldRepository.Get(&model.ProtoField{TableId: table.TableId})
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	byColumnId := map[int]model.ProtoField{}
+	byName := map[string]model.ProtoField{}
+	next := 1
+	for _, pf := range saved {
+		if pf.ColumnId != 0 {
+			byColumnId[pf.ColumnId] = pf
+		} else {
+			byName[pf.FieldName] = pf
+		}
+		if pf.FieldNo >= next {
+			next = pf.FieldNo + 1
+		}
+	}
+
+	sorted := make([]model.Column, len(columns))
+	copy(sorted, columns)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].AlignSeq < sorted[j].AlignSeq
+	})
+	common := []string{"created_at", "updated_at"}
+	if table.VersionFlg == constant.FLG_ON {
+		common = append([]string{"version"}, common...)
+	}
+
+	var tx *sql.Tx
+	if save {
+		if tx, err = db.GetDB().Begin(); err != nil {
+			logger.Error(err.Error())
+			return nil, nil, err
+		}
+	}
+
+	var ret []protoField
+	used := map[int]bool{}
+	field := func(pf model.ProtoField, found bool, column *model.Column) error {
+		if !found {
+			// 19000-19999 are reserved by protobuf.
+			if next >= 19000 && next <= 19999 {
+				next = 20000
+			}
+			pf.FieldNo = next
+			next++
+			if save {
+				if err := srv.protoFieldRepository.Insert(&pf, tx); err != nil {
+					return err
+				}
+			}
+		} else if column != nil && pf.FieldName != strings.ToLower(column.ColumnName) {
+			pf.FieldName = strings.ToLower(column.ColumnName)
+			if save {
+				if err := srv.protoFieldRepository.Update(&pf, tx); err != nil {
+					return err
+				}
+			}
+		}
+		used[pf.FieldNo] = true
+		ret = append(ret, protoField{pf.FieldNo, pf.FieldName, column})
+		return nil
+	}
+
+	for i := range sorted {
+		c := &sorted[i]
+		pf, found := byColumnId[c.ColumnId]
+		if !found {
+			pf = model.ProtoField{TableId: table.TableId, ColumnId: c.ColumnId, FieldName: strings.ToLower(c.ColumnName)}
+		}
+		if err = field(pf, found, c); err != nil {
+			if save {
+				tx.Rollback()
+			}
+			logger.Error(err.Error())
+			return nil, nil, err
+		}
+	}
+	for _, name := range common {
+		pf, found := byName[name]
+		if !found {
+			pf = model.ProtoField{TableId: table.TableId, FieldName: name}
+		}
+		if err = field(pf, found, nil); err != nil {
+			if save {
+				tx.Rollback()
+			}
+			logger.Error(err.Error())
+			return nil, nil, err
+		}
+	}
+
+	if save {
+		if err = tx.Commit(); err != nil {
+			logger.Error(err.Error())
+			return nil, nil, err
+		}
+	}
+
+	var reserved []model.ProtoField
+	for _, pf := range saved {
+		if !used[pf.FieldNo] {
+			reserved = append(reserved, pf)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].no < ret[j].no
+	})
+
+	return ret, reserved, nil
+}
+
+
+func (srv *codegenService) generateProtoCode(
+	pkg string, table *model.Table, columns []model.Column,
+	fields []protoField, reserved []model.ProtoField, grpc bool,
+) string {
+	tnp := SnakeToPascal(table.TableName)
+
+	s := "syntax = \"proto3\";\n\n" +
+		"package " + pkg + ";\n\n" +
+		"option go_package = \"xxxxx/internal/pb\";\n\n" +
+		"import \"google/protobuf/timestamp.proto\";\n"
+	if grpc {
+		s += "import \"google/protobuf/empty.proto\";\n"
+	}
+	s += "\n\n"
+
+	if table.TableNameLogical != "" {
+		s += "// " + tnp + " " + table.TableNameLogical + "\n"
+	}
+	s += "message " + tnp + " {\n"
+	if len(reserved) > 0 {
+		var nos, names []string
+		for _, pf := range reserved {
+			nos = append(nos, strconv.Itoa(pf.FieldNo))
+			names = append(names, jsonString(pf.FieldName))
+		}
+		s += "\treserved " + strings.Join(nos, ", ") + ";\n"
+		s += "\treserved " + strings.Join(names, ", ") + ";\n"
+	}
+	for _, f := range fields {
+		if f.column == nil {
+			t := "google.protobuf.Timestamp"
+			if f.name == "version" {
+				t = "int32"
+			}
+			s += fmt.Sprintf("\t%s %s = %d;\n", t, f.name, f.no)
+			continue
+		}
+		s += srv.generateProtoComment(*f.column)
+		s += fmt.Sprintf("\t%s%s %s = %d;\n", srv.generateProtoLabel(*f.column),
+			protoTypeMap[f.column.DataTypeCls], f.name, f.no)
+	}
+	s += "}\n"
+
+	if grpc {
+		s += "\n" + srv.generateProtoService(table, columns)
+	}
+
+	return s
+}
+
+
+// generateProtoComment "// ColumnNameLogical Remark" of the field.
+func (srv *codegenService) generateProtoComment(column model.Column) string {
+	var ls []string
+	if column.ColumnNameLogical != "" {
+		ls = append(ls, column.ColumnNameLogical)
+	}
+	if column.Remark != "" {
+		ls = append(ls, strings.Split(strings.ReplaceAll(column.Remark, "\r\n", "\n"), "\n")...)
+	}
+	if column.DataTypeCls == constant.DATA_TYPE_CLS_ENUM || column.ClassificationId != 0 {
+		if values := srv.getEnumValues(column); len(values) > 0 {
+			ls = append(ls, "values: " + strings.Join(values, ", "))
+		}
+	}
+
+	s := ""
+	for _, l := range ls {
+		s += "\t// " + l + "\n"
+	}
+	return s
+}
+
+
+// generateProtoLabel "optional " for nullable scalar fields. (messages have presence)
+func (srv *codegenService) generateProtoLabel(column model.Column) string {
+	if !srv.isNullableColumn(column) || strings.HasPrefix(protoTypeMap[column.DataTypeCls], "google.") {
+		return ""
+	}
+	return "optional "
+}
+
+
+// generateProtoService "service <Table>Service" with List, Get, Create, Update, Delete
+// and their request messages. (Get, Update, Delete need primary keys)
+func (srv *codegenService) generateProtoService(table *model.Table, columns []model.Column) string {
+	tnp := SnakeToPascal(table.TableName)
+	pks := srv.extractPrimaryKeys(columns)
+
+	s := "message List" + tnp + "Request {\n" +
+		"\tint32 page_size = 1;\n" +
+		"\tstring page_token = 2;\n" +
+		"}\n\n" +
+		"message List" + tnp + "Response {\n" +
+		"\trepeated " + tnp + " items = 1;\n" +
+		"\tstring next_page_token = 2;\n" +
+		"}\n\n"
+
+	if len(pks) > 0 {
+		keys := ""
+		for i, pk := range pks {
+			keys += fmt.Sprintf("\t%s %s = %d;\n", protoTypeMap[pk.DataTypeCls], strings.ToLower(pk.ColumnName), i + 1)
+		}
+		s += "message Get" + tnp + "Request {\n" + keys + "}\n\n" +
+			"message Delete" + tnp + "Request {\n" + keys + "}\n\n"
+	}
+
+	s += "service " + tnp + "Service {\n" +
+		"\trpc List" + tnp + "(List" + tnp + "Request) returns (List" + tnp + "Response);\n"
+	if len(pks) > 0 {
+		s += "\trpc Get" + tnp + "(Get" + tnp + "Request) returns (" + tnp + ");\n"
+	}
+	s += "\trpc Create" + tnp + "(" + tnp + ") returns (" + tnp + ");\n"
+	if len(pks) > 0 {
+		s += "\trpc Update" + tnp + "(" + tnp + ") returns (" + tnp + ");\n" +
+			"\trpc Delete" + tnp + "(Delete" + tnp + "Request) returns (google.protobuf.Empty);\n"
+	}
+	s += "}\n"
+
+	return s
+}
\ No newline at end of file
@@ -0,0 +1,770 @@
+package service
+
+import (
+	"os"
+	"fmt"
+	"time"
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"math/rand"
+	"encoding/csv"
+	"encoding/hex"
+
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/core/logger"
+	"goat-cg/internal/model"
+)
+
+
+// GenerateSeed generate the sample rows and n fake rows of each table and return zip path.
+// param format: "sql" (scripts/seed.sql), "csv" (seeds/<table_name>.csv)
+// or "go" (internal/fixture/<table_name>.go). return "" if the format is unknown.
+func (srv *codegenService) GenerateSeed(rdbms, format string, n int, tableIds []int) string {
+	if format != "sql" && format != "csv" && format != "go" {
+		return ""
+	}
+
+	return srv.generateArchive("seed", func(path string) {
+		tables := srv.generateSeedTables(rdbms, n, tableIds)
+
+		switch format {
+		case "sql":
+			path += "/scripts"
+		case "csv":
+			path += "/seeds"
+		case "go":
+			path += "/internal/fixture"
+		}
+		if err := os.MkdirAll(path, 0777); err != nil {
+			logger.Error(err.Error())
+			return
+		}
+
+		switch format {
+		case "sql":
+			s := ""
+			for _, st := range tables {
+				s += srv.generateSeedSql(rdbms, st)
+			}
+			srv.writeFile(path + "/seed.sql", s)
+		case "csv":
+			for _, st := range tables {
+				srv.writeFile(path + "/" + strings.ToLower(st.table.TableName) + ".csv", srv.generateSeedCsv(st))
+			}
+		case "go":
+			for _, st := range tables {
+				srv.writeFile(path + "/" + srv.tableNameToFileName(st.table.TableName), srv.generateSeedGo(st))
+			}
+		}
+	})
+}
+
+
+// seedTable rows of a table. values are in the order of columns. (nil is NULL)
+type seedTable struct {
+	table model.Table
+	columns []model.Column
+	rows [][]*string
+}
+
+
+// seedGenerator state shared by the tables generated together.
+type seedGenerator struct {
+	rdbms string
+	rand *rand.Rand
+	// values of each column. (referenced by foreign keys)
+	values map[int][]string
+	// used values of unique columns.
+	used map[int]map[string]bool
+	// last value of auto increment columns.
+	seq map[int]int
+	enums map[int][]string
+	// RANGE and PATTERN checks of each column.
+	ranges map[int]model.Check
+	patterns map[int]*regexp.Regexp
+}
+
+
+// generateSeedTables generate rows of the tables in the order of foreign keys.
+// the rows start with the sample rows of the table. (missing columns are generated, stale rows are skipped)
+func (srv *codegenService) generateSeedTables(rdbms string, n int, tableIds []int) []seedTable {
+	var tables []seedTable
+	for _, tid := range tableIds {
+		table, err := srv.tableRepository.GetOne(&model.Table{TableId: tid})
+		if err != nil {
+			logger.Error(err.Error())
+			continue
+		}
+		columns, err := srv.getValidColumns(tid)
+		if err != nil {
+			continue
+		}
+		tables = append(tables, seedTable{table: table, columns: columns})
+	}
+	tables = srv.sortSeedTables(tables, tableIds)
+
+	g := &seedGenerator{
+		rdbms: rdbms,
+		values: map[int][]string{},
+		used: map[int]map[string]bool{},
+		seq: map[int]int{},
+		enums: map[int][]string{},
+		ranges: map[int]model.Check{},
+		patterns: map[int]*regexp.Regexp{},
+	}
+
+	for i, st := range tables {
+		// same rows are generated for the same table
+		g.rand = rand.New(rand.NewSource(int64(st.table.TableId)))
+
+		fks := map[int]foreignKey{}
+		for _, fk := range srv.getForeignKeys(st.columns, tableIds) {
+			fks[fk.column.ColumnId] = fk
+		}
+		for _, c := range st.columns {
+			if c.DataTypeCls == constant.DATA_TYPE_CLS_ENUM || c.ClassificationId != 0 {
+				g.enums[c.ColumnId] = srv.getEnumValues(c)
+			}
+		}
+		checks, cols := srv.getValidChecks(st.table.TableId, st.columns)
+		for j, ck := range checks {
+			switch ck.CheckTypeCls {
+			case constant.CHECK_TYPE_CLS_IN:
+				g.enums[cols[j].ColumnId] = splitEnumValues(ck.AllowedValues)
+			case constant.CHECK_TYPE_CLS_RANGE:
+				g.ranges[cols[j].ColumnId] = ck
+			case constant.CHECK_TYPE_CLS_PATTERN:
+				if re, err := regexp.Compile(ck.Pattern); err == nil {
+					g.patterns[cols[j].ColumnId] = re
+				}
+			}
+		}
+
+		samples, err := srv.sampleRowRepository.Get(&model.SampleRow{TableId: st.table.TableId})
+		if err != nil {
+			logger.Error(err.Error())
+		}
+		for _, sr := range samples {
+			if sr.StaleFlg == constant.FLG_ON {
+				continue
+			}
+			data, err := decodeSampleRow(sr.RowData)
+			if err != nil {
+				logger.Error(err.Error())
+				continue
+			}
+			if row := g.generateRow(srv, st.columns, fks, len(tables[i].rows), data); row != nil {
+				tables[i].rows = append(tables[i].rows, row)
+			}
+		}
+		for j := 0; j < n; j++ {
+			if row := g.generateRow(srv, st.columns, fks, len(tables[i].rows), nil); row != nil {
+				tables[i].rows = append(tables[i].rows, row)
+			}
+		}
+	}
+
+	return tables
+}
+
+
+// sortSeedTables order the tables so that referenced tables are inserted first.
+// (self references and cycles keep the selected order)
+func (srv *codegenService) sortSeedTables(tables []seedTable, tableIds []int) []seedTable {
+	var ret []seedTable
+	done := map[int]bool{}
+
+	for len(ret) < len(tables) {
+		added := false
+		for _, st := range tables {
+			if done[st.table.TableId] {
+				continue
+			}
+			ready := true
+			for _, fk := range srv.getForeignKeys(st.columns, tableIds) {
+				if fk.refTable.TableId != st.table.TableId && !done[fk.refTable.TableId] {
+					ready = false
+				}
+			}
+			if ready {
+				ret = append(ret, st)
+				done[st.table.TableId] = true
+				added = true
+			}
+		}
+		if !added {
+			for _, st := range tables {
+				if !done[st.table.TableId] {
+					ret = append(ret, st)
+					done[st.table.TableId] = true
+				}
+			}
+		}
+	}
+
+	return ret
+}
+
+
+// generateRow generate a row which does not violate primary key, unique, RANGE and PATTERN constraints.
+// values in sample are used as they are. return nil if no valid row is generated.
+func (g *seedGenerator) generateRow(
+	srv *codegenService, columns []model.Column, fks map[int]foreignKey, index int, sample map[string]*string,
+) []*string {
+	pks := srv.extractPrimaryKeys(columns)
+
+	for try := 0; try < 20; try++ {
+		row := make([]*string, len(columns))
+		ok := true
+		for i, c := range columns {
+			if v, found := sample[strings.ToLower(c.ColumnName)]; found {
+				row[i] = v
+			} else {
+				row[i] = g.generateValue(srv, c, fks, index, g.isUnique(c, pks))
+				if row[i] != nil && !g.isValid(c, *row[i]) {
+					ok = false
+				}
+			}
+			if row[i] != nil && g.isUnique(c, pks) && g.used[c.ColumnId][*row[i]] {
+				ok = false
+			}
+		}
+
+		// composite primary key is kept with the id of the first column
+		key := ""
+		if len(pks) > 1 {
+			key = strconv.Itoa(pks[0].ColumnId) + "\x00"
+			for i, c := range columns {
+				if c.PrimaryKeyFlg == constant.FLG_ON && row[i] != nil {
+					key += *row[i] + "\x00"
+				}
+			}
+			if g.used[0][key] {
+				ok = false
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		for i, c := range columns {
+			if row[i] == nil {
+				continue
+			}
+			g.values[c.ColumnId] = append(g.values[c.ColumnId], *row[i])
+			if g.isUnique(c, pks) {
+				if g.used[c.ColumnId] == nil {
+					g.used[c.ColumnId] = map[string]bool{}
+				}
+				g.used[c.ColumnId][*row[i]] = true
+			}
+			if isSerialType(c.DataTypeCls) {
+				if x, err := strconv.Atoi(*row[i]); err == nil && x > g.seq[c.ColumnId] {
+					g.seq[c.ColumnId] = x
+				}
+			}
+		}
+		if len(pks) > 1 {
+			if g.used[0] == nil {
+				g.used[0] = map[string]bool{}
+			}
+			g.used[0][key] = true
+		}
+		return row
+	}
+
+	return nil
+}
+
+
+// isValid value satisfies RANGE and PATTERN checks of the column.
+// (RANGE of not numbers is compared as strings)
+func (g *seedGenerator) isValid(column model.Column, value string) bool {
+	if re := g.patterns[column.ColumnId]; re != nil && !re.MatchString(value) {
+		return false
+	}
+	ck, ok := g.ranges[column.ColumnId]
+	if !ok {
+		return true
+	}
+	if isNumericType(column.DataTypeCls) && column.DataTypeCls != constant.DATA_TYPE_CLS_BOOLEAN {
+		x, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return true
+		}
+		if min, err := strconv.ParseFloat(ck.MinValue, 64); err == nil && x < min {
+			return false
+		}
+		if max, err := strconv.ParseFloat(ck.MaxValue, 64); err == nil && x > max {
+			return false
+		}
+		return true
+	}
+	return (ck.MinValue == "" || value >= ck.MinValue) && (ck.MaxValue == "" || value <= ck.MaxValue)
+}
+
+
+// isUnique column is UNIQUE or the single primary key.
+func (g *seedGenerator) isUnique(column model.Column, pks []model.Column) bool {
+	return column.UniqueFlg == constant.FLG_ON ||
+		(len(pks) == 1 && pks[0].ColumnId == column.ColumnId)
+}
+
+
+// generateValue fake value of the column.
+// auto increment columns are numbered, foreign keys pick values of the referenced column,
+// nullable columns are NULL and columns with default value are the default at times.
+// values are in classification, ENUM, IN check and RANGE check of numbers. (EXPRESSION is not considered)
+func (g *seedGenerator) generateValue(
+	srv *codegenService, column model.Column, fks map[int]foreignKey, index int, unique bool,
+) *string {
+	if isSerialType(column.DataTypeCls) {
+		g.seq[column.ColumnId]++
+		v := strconv.Itoa(g.seq[column.ColumnId])
+		return &v
+	}
+
+	if fk, ok := fks[column.ColumnId]; ok && len(g.values[fk.refColumn.ColumnId]) > 0 {
+		refs := g.values[fk.refColumn.ColumnId]
+		v := refs[g.rand.Intn(len(refs))]
+		return &v
+	}
+	if srv.isNullableColumn(column) && (g.rand.Intn(10) == 0 || column.RefColumnId != 0) {
+		return nil
+	}
+	if column.DefaultValue != "" && !unique && g.rand.Intn(3) == 0 {
+		v := column.DefaultValue
+		return &v
+	}
+	if enums := g.enums[column.ColumnId]; len(enums) > 0 {
+		v := enums[g.rand.Intn(len(enums))]
+		return &v
+	}
+
+	v := g.generateTypeValue(column, index)
+	if ck, ok := g.ranges[column.ColumnId]; ok {
+		v = g.generateRangeValue(column, ck, v)
+	}
+	if unique && g.used[column.ColumnId][v] {
+		switch {
+		case isNumericType(column.DataTypeCls) && column.DataTypeCls != constant.DATA_TYPE_CLS_BOOLEAN:
+			v = strconv.Itoa(index + 1)
+		case column.DataTypeCls == constant.DATA_TYPE_CLS_VARCHAR ||
+			column.DataTypeCls == constant.DATA_TYPE_CLS_CHAR ||
+			column.DataTypeCls == constant.DATA_TYPE_CLS_TEXT:
+			v = g.truncate(column, strconv.Itoa(index + 1) + v)
+		}
+	}
+	return &v
+}
+
+
+var seedFirstNames = []string{
+	"James", "Mary", "John", "Patricia", "Robert", "Jennifer", "Michael", "Linda",
+	"Hiroshi", "Yuki", "Haruto", "Sakura", "Wei", "Mei", "Lucas", "Emma",
+}
+
+var seedLastNames = []string{
+	"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis",
+	"Sato", "Suzuki", "Takahashi", "Tanaka", "Wang", "Li", "Martin", "Bernard",
+}
+
+var seedCities = []string{
+	"Tokyo", "Osaka", "New York", "London", "Paris", "Berlin", "Sydney", "Toronto",
+}
+
+var seedCountries = []string{
+	"Japan", "United States", "United Kingdom", "France", "Germany", "Australia", "Canada",
+}
+
+var seedWords = []string{
+	"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit",
+	"sed", "do", "eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore",
+	"magna", "aliqua", "enim", "ad", "minim", "veniam", "quis", "nostrud",
+}
+
+
+// generateTypeValue fake value by the data type and the column name.
+func (g *seedGenerator) generateTypeValue(column model.Column, index int) string {
+	r := g.rand
+	name := strings.ToLower(column.ColumnName)
+
+	switch column.DataTypeCls {
+	case constant.DATA_TYPE_CLS_INTEGER, constant.DATA_TYPE_CLS_BIGINT:
+		if strings.Contains(name, "age") {
+			return strconv.Itoa(18 + r.Intn(62))
+		}
+		if strings.Contains(name, "year") {
+			return strconv.Itoa(2000 + r.Intn(30))
+		}
+		return strconv.Itoa(1 + r.Intn(1000))
+	case constant.DATA_TYPE_CLS_SMALLINT:
+		return strconv.Itoa(1 + r.Intn(100))
+	case constant.DATA_TYPE_CLS_NUMERIC:
+		return g.generateNumeric(column)
+	case constant.DATA_TYPE_CLS_REAL, constant.DATA_TYPE_CLS_DOUBLE:
+		return strconv.FormatFloat(float64(r.Intn(100000)) / 100, 'f', 2, 64)
+	case constant.DATA_TYPE_CLS_BOOLEAN:
+		return strconv.FormatBool(r.Intn(2) == 0)
+	case constant.DATA_TYPE_CLS_UUID:
+		b := make([]byte, 16)
+		r.Read(b)
+		b[6] = b[6] & 0x0f | 0x40
+		b[8] = b[8] & 0x3f | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+	case constant.DATA_TYPE_CLS_DATE:
+		return g.generateTime().Format("2006-01-02")
+	case constant.DATA_TYPE_CLS_TIME:
+		return g.generateTime().Format("15:04:05")
+	case constant.DATA_TYPE_CLS_TIMESTAMP:
+		return g.generateTime().Format("2006-01-02 15:04:05")
+	case constant.DATA_TYPE_CLS_TIMESTAMPTZ:
+		if g.rdbms == "mysql" {
+			return g.generateTime().Format("2006-01-02 15:04:05")
+		}
+		return g.generateTime().Format("2006-01-02 15:04:05-07:00")
+	case constant.DATA_TYPE_CLS_BLOB:
+		b := make([]byte, 8)
+		r.Read(b)
+		return hex.EncodeToString(b)
+	case constant.DATA_TYPE_CLS_JSON, constant.DATA_TYPE_CLS_JSONB:
+		return fmt.Sprintf(`{"no": %d, "tag": "%s"}`, index + 1, seedWords[r.Intn(len(seedWords))])
+	}
+
+	return g.truncate(column, g.generateText(column, name, index))
+}
+
+
+// generateText fake string guessed from the column name.
+func (g *seedGenerator) generateText(column model.Column, name string, index int) string {
+	r := g.rand
+	first := seedFirstNames[r.Intn(len(seedFirstNames))]
+	last := seedLastNames[r.Intn(len(seedLastNames))]
+
+	switch {
+	case strings.Contains(name, "email") || strings.Contains(name, "mail"):
+		return strings.ToLower(first + "." + last) + strconv.Itoa(index + 1) + "@example.com"
+	case strings.Contains(name, "first_name"):
+		return first
+	case strings.Contains(name, "last_name"):
+		return last
+	case strings.Contains(name, "user") && strings.Contains(name, "name") || strings.Contains(name, "login"):
+		return strings.ToLower(first) + strconv.Itoa(index + 1)
+	case strings.HasSuffix(name, "name"):
+		if column.DataTypeCls == constant.DATA_TYPE_CLS_CHAR {
+			break
+		}
+		return first + " " + last
+	case strings.Contains(name, "phone") || strings.Contains(name, "tel"):
+		return fmt.Sprintf("090-%04d-%04d", r.Intn(10000), r.Intn(10000))
+	case strings.Contains(name, "url") || strings.Contains(name, "website"):
+		return "https://example.com/" + seedWords[r.Intn(len(seedWords))] + "/" + strconv.Itoa(index + 1)
+	case strings.Contains(name, "city"):
+		return seedCities[r.Intn(len(seedCities))]
+	case strings.Contains(name, "country"):
+		return seedCountries[r.Intn(len(seedCountries))]
+	case strings.Contains(name, "address"):
+		return fmt.Sprintf("%d %s St, %s", 1 + r.Intn(999), last, seedCities[r.Intn(len(seedCities))])
+	case strings.Contains(name, "zip") || strings.Contains(name, "postal"):
+		return fmt.Sprintf("%03d-%04d", r.Intn(1000), r.Intn(10000))
+	case strings.Contains(name, "password") || strings.Contains(name, "hash"):
+		b := make([]byte, 32)
+		r.Read(b)
+		return hex.EncodeToString(b)
+	case strings.Contains(name, "title") || strings.Contains(name, "subject"):
+		return g.generateSentence(3 + r.Intn(3))
+	case strings.Contains(name, "description") || strings.Contains(name, "comment") ||
+		strings.Contains(name, "remark") || strings.Contains(name, "note") ||
+		strings.Contains(name, "body") || strings.Contains(name, "content"):
+		return g.generateSentence(8 + r.Intn(12))
+	}
+
+	if column.DataTypeCls == constant.DATA_TYPE_CLS_CHAR && column.Precision > 0 {
+		const chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+		b := make([]byte, column.Precision)
+		for i := range b {
+			b[i] = chars[r.Intn(len(chars))]
+		}
+		return string(b)
+	}
+	return seedWords[r.Intn(len(seedWords))] + "_" + strconv.Itoa(index + 1)
+}
+
+
+func (g *seedGenerator) generateSentence(words int) string {
+	var ls []string
+	for i := 0; i < words; i++ {
+		ls = append(ls, seedWords[g.rand.Intn(len(seedWords))])
+	}
+	s := strings.Join(ls, " ")
+	return strings.ToUpper(s[:1]) + s[1:] + "."
+}
+
+
+// generateRangeValue number between MinValue and MaxValue of the RANGE check.
+// value is returned if the column is not a number or the range is not a number.
+func (g *seedGenerator) generateRangeValue(column model.Column, check model.Check, value string) string {
+	if !isNumericType(column.DataTypeCls) || column.DataTypeCls == constant.DATA_TYPE_CLS_BOOLEAN {
+		return value
+	}
+	x, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+	min, max := x, x
+	if check.MinValue != "" {
+		if min, err = strconv.ParseFloat(check.MinValue, 64); err != nil {
+			return value
+		}
+	}
+	if check.MaxValue != "" {
+		if max, err = strconv.ParseFloat(check.MaxValue, 64); err != nil {
+			return value
+		}
+	}
+	if check.MinValue == "" {
+		min = max - 1000
+	}
+	if check.MaxValue == "" {
+		max = min + 1000
+	}
+	if x >= min && x <= max {
+		return value
+	}
+
+	switch column.DataTypeCls {
+	case constant.DATA_TYPE_CLS_INTEGER, constant.DATA_TYPE_CLS_BIGINT, constant.DATA_TYPE_CLS_SMALLINT:
+		lo, hi := int64(min), int64(max)
+		if float64(lo) < min {
+			lo++
+		}
+		if hi < lo {
+			return value
+		}
+		return strconv.FormatInt(lo + g.rand.Int63n(hi - lo + 1), 10)
+	case constant.DATA_TYPE_CLS_NUMERIC:
+		scale := column.Scale
+		if column.Precision == 0 {
+			scale = 2
+		}
+		v := strconv.FormatFloat(min + g.rand.Float64() * (max - min), 'f', scale, 64)
+		if y, _ := strconv.ParseFloat(v, 64); y < min || y > max {
+			return check.MinValue
+		}
+		return v
+	}
+	return strconv.FormatFloat(min + g.rand.Float64() * (max - min), 'f', 2, 64)
+}
+
+
+// generateNumeric value within NUMERIC(Precision, Scale). (NUMERIC without precision has 2 decimals)
+func (g *seedGenerator) generateNumeric(column model.Column) string {
+	p, s := column.Precision, column.Scale
+	if p == 0 {
+		p, s = 8, 2
+	}
+	digits := p - s
+	if digits > 5 {
+		digits = 5
+	}
+
+	v := "0"
+	if digits > 0 {
+		limit := 1
+		for i := 0; i < digits; i++ {
+			limit *= 10
+		}
+		v = strconv.Itoa(g.rand.Intn(limit))
+	}
+	if s > 0 {
+		v += "."
+		for i := 0; i < s; i++ {
+			v += strconv.Itoa(g.rand.Intn(10))
+		}
+	}
+	return v
+}
+
+
+// generateTime time in 2024 and 2025.
+func (g *seedGenerator) generateTime() time.Time {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return base.Add(time.Duration(g.rand.Int63n(2 * 365 * 24 * 60 * 60)) * time.Second)
+}
+
+
+// truncate string to Precision of VARCHAR and CHAR. (in runes)
+func (g *seedGenerator) truncate(column model.Column, s string) string {
+	if column.DataTypeCls == constant.DATA_TYPE_CLS_TEXT || column.Precision <= 0 {
+		return s
+	}
+	if rs := []rune(s); len(rs) > column.Precision {
+		return string(rs[:column.Precision])
+	}
+	return s
+}
+
+
+// generateSeedSql generate INSERT statements of the table. (100 rows per statement)
+// postgresql sequences of auto increment columns are set to the max value.
+func (srv *codegenService) generateSeedSql(rdbms string, st seedTable) string {
+	if len(st.rows) == 0 {
+		return ""
+	}
+
+	var names []string
+	for _, c := range st.columns {
+		names = append(names, c.ColumnName)
+	}
+
+	s := ""
+	for i, row := range st.rows {
+		if i % 100 == 0 {
+			s += "INSERT INTO " + st.table.TableName + " (" + strings.Join(names, ", ") + ") VALUES\n"
+		}
+		var values []string
+		for j, c := range st.columns {
+			values = append(values, srv.generateSeedSqlValue(rdbms, c, row[j]))
+		}
+		s += "\t(" + strings.Join(values, ", ") + ")"
+		if i % 100 == 99 || i == len(st.rows) - 1 {
+			s += ";\n"
+		} else {
+			s += ",\n"
+		}
+	}
+
+	if rdbms == "postgresql" {
+		for _, c := range st.columns {
+			if isSerialType(c.DataTypeCls) {
+				s += fmt.Sprintf(
+					"SELECT setval(pg_get_serial_sequence('%s', '%s'), (SELECT MAX(%s) FROM %s));\n",
+					st.table.TableName, c.ColumnName, c.ColumnName, st.table.TableName,
+				)
+			}
+		}
+	}
+
+	return s + "\n"
+}
+
+
+// generateSeedSqlValue literal of the value in the rdbms.
+func (srv *codegenService) generateSeedSqlValue(rdbms string, column model.Column, value *string) string {
+	if value == nil {
+		return "NULL"
+	}
+	v := *value
+
+	switch {
+	case column.DataTypeCls == constant.DATA_TYPE_CLS_BOOLEAN:
+		b, _ := strconv.ParseBool(v)
+		if rdbms == "sqlite3" {
+			if b {
+				return "1"
+			}
+			return "0"
+		}
+		return strings.ToUpper(strconv.FormatBool(b))
+	case isSerialType(column.DataTypeCls) || isNumericType(column.DataTypeCls):
+		if _, err := strconv.ParseFloat(v, 64); err == nil {
+			return v
+		}
+	case column.DataTypeCls == constant.DATA_TYPE_CLS_BLOB:
+		if _, err := hex.DecodeString(v); err == nil {
+			if rdbms == "postgresql" {
+				return "decode('" + v + "', 'hex')"
+			}
+			return "X'" + v + "'"
+		}
+	}
+
+	if rdbms == "mysql" {
+		v = strings.ReplaceAll(v, "\\", "\\\\")
+	}
+	return quoteSqlValues([]string{v})
+}
+
+
+// generateSeedCsv CSV with the header of column names. NULL is an empty field.
+func (srv *codegenService) generateSeedCsv(st seedTable) string {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	var names []string
+	for _, c := range st.columns {
+		names = append(names, strings.ToLower(c.ColumnName))
+	}
+	w.Write(names)
+
+	for _, row := range st.rows {
+		var record []string
+		for _, v := range row {
+			if v != nil {
+				record = append(record, *v)
+			} else {
+				record = append(record, "")
+			}
+		}
+		w.Write(record)
+	}
+	w.Flush()
+
+	return buf.String()
+}
+
+
+// generateSeedGo slice of the generated model. NULL is the zero value.
+func (srv *codegenService) generateSeedGo(st seedTable) string {
+	tn := SnakeToPascal(st.table.TableName)
+	s := "package fixture\n\n" +
+		"import (\n\t\"xxxxx/internal/model\"\n)\n\n\n" +
+		fmt.Sprintf("// %s rows of %s.\n", tn, strings.ToLower(st.table.TableName)) +
+		fmt.Sprintf("var %s = []model.%s{\n", tn, tn)
+
+	for _, row := range st.rows {
+		var fields []string
+		for i, c := range st.columns {
+			if row[i] == nil {
+				continue
+			}
+			fields = append(fields, SnakeToPascal(c.ColumnName) + ": " + srv.generateSeedGoValue(c, *row[i]))
+		}
+		s += "\t{" + strings.Join(fields, ", ") + "},\n"
+	}
+
+	return s + "}\n"
+}
+
+
+// generateSeedGoValue literal of the value in the type of dbDataTypeGoTypeMap.
+func (srv *codegenService) generateSeedGoValue(column model.Column, value string) string {
+	switch dbDataTypeGoTypeMap[column.DataTypeCls] {
+	case "int", "int64", "int16":
+		if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return value
+		}
+		return "0"
+	case "float64", "float32":
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return value
+		}
+		return "0"
+	case "bool":
+		b, _ := strconv.ParseBool(value)
+		return strconv.FormatBool(b)
+	case "[]byte":
+		b, err := hex.DecodeString(value)
+		if err != nil {
+			return "[]byte(" + strconv.Quote(value) + ")"
+		}
+		var ls []string
+		for _, x := range b {
+			ls = append(ls, fmt.Sprintf("0x%02x", x))
+		}
+		return "[]byte{" + strings.Join(ls, ", ") + "}"
+	}
+	return strconv.Quote(value)
+}
\ No newline at end of file
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"os"
+	"fmt"
+	"strings"
+
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/core/logger"
+	"goat-cg/internal/model"
+)
+
+
+// GenerateTypeScript generate TypeScript interfaces (and Zod schemas) and return zip path.
+// (types/<table_name>.ts, types/index.ts)
+func (srv *codegenService) GenerateTypeScript(tableIds []int, zod bool) string {
+	return srv.generateArchive("typescript", func(path string) {
+		path += "/types"
+		if err := os.MkdirAll(path, 0777); err != nil {
+			logger.Error(err.Error())
+			return
+		}
+
+		index := ""
+		for _, tid := range tableIds {
+			table, err := srv.tableRepository.GetOne(&model.Table{TableId: tid})
+			if err != nil {
+				logger.Error(err.Error())
+				break
+			}
+			columns, err := srv.getValidColumns(tid)
+			if err != nil {
+				logger.Error(err.Error())
+				break
+			}
+
+			name := strings.ToLower(table.TableName)
+			srv.writeFile(path + "/" + name + ".ts", srv.generateTypeScriptCode(&table, columns, zod))
+			index += "export * from \"./" + name + "\";\n"
+		}
+		srv.writeFile(path + "/index.ts", index)
+	})
+}
+
+
+// tsTypeMap map DataTypeCls and TypeScript types.
+var tsTypeMap = map[string]string{
+	constant.DATA_TYPE_CLS_SERIAL: "number",
+	constant.DATA_TYPE_CLS_BIGSERIAL: "number",
+	constant.DATA_TYPE_CLS_TEXT: "string",
+	constant.DATA_TYPE_CLS_VARCHAR: "string",
+	constant.DATA_TYPE_CLS_CHAR: "string",
+	constant.DATA_TYPE_CLS_UUID: "string",
+	constant.DATA_TYPE_CLS_ENUM: "string",
+	constant.DATA_TYPE_CLS_INTEGER: "number",
+	constant.DATA_TYPE_CLS_BIGINT: "number",
+	constant.DATA_TYPE_CLS_SMALLINT: "number",
+	constant.DATA_TYPE_CLS_NUMERIC: "number",
+	constant.DATA_TYPE_CLS_REAL: "number",
+	constant.DATA_TYPE_CLS_DOUBLE: "number",
+	constant.DATA_TYPE_CLS_TIMESTAMP: "string",
+	constant.DATA_TYPE_CLS_DATE: "string",
+	constant.DATA_TYPE_CLS_TIME: "string",
+	constant.DATA_TYPE_CLS_TIMESTAMPTZ: "string",
+	constant.DATA_TYPE_CLS_BLOB: "string",
+	constant.DATA_TYPE_CLS_BOOLEAN: "boolean",
+	constant.DATA_TYPE_CLS_JSON: "unknown",
+	constant.DATA_TYPE_CLS_JSONB: "unknown",
+}
+
+// zodTypeMap map DataTypeCls and Zod schemas.
+var zodTypeMap = map[string]string{
+	constant.DATA_TYPE_CLS_SERIAL: "z.number().int()",
+	constant.DATA_TYPE_CLS_BIGSERIAL: "z.number().int()",
+	constant.DATA_TYPE_CLS_TEXT: "z.string()",
+	constant.DATA_TYPE_CLS_VARCHAR: "z.string()",
+	constant.DATA_TYPE_CLS_CHAR: "z.string()",
+	constant.DATA_TYPE_CLS_UUID: "z.string().uuid()",
+	constant.DATA_TYPE_CLS_ENUM: "z.string()",
+	constant.DATA_TYPE_CLS_INTEGER: "z.number().int()",
+	constant.DATA_TYPE_CLS_BIGINT: "z.number().int()",
+	constant.DATA_TYPE_CLS_SMALLINT: "z.number().int()",
+	constant.DATA_TYPE_CLS_NUMERIC: "z.number()",
+	constant.DATA_TYPE_CLS_REAL: "z.number()",
+	constant.DATA_TYPE_CLS_DOUBLE: "z.number()",
+	constant.DATA_TYPE_CLS_TIMESTAMP: "z.string()",
+	constant.DATA_TYPE_CLS_DATE: "z.string()",
+	constant.DATA_TYPE_CLS_TIME: "z.string()",
+	constant.DATA_TYPE_CLS_TIMESTAMPTZ: "z.string()",
+	constant.DATA_TYPE_CLS_BLOB: "z.string()",
+	constant.DATA_TYPE_CLS_BOOLEAN: "z.boolean()",
+	constant.DATA_TYPE_CLS_JSON: "z.unknown()",
+	constant.DATA_TYPE_CLS_JSONB: "z.unknown()",
+}
+
+
+// generateTypeScriptCode "export interface <Table>" and "export const <table>Schema".
+// properties are named by SnakeToCamel.
+func (srv *codegenService) generateTypeScriptCode(table *model.Table, columns []model.Column, zod bool) string {
+	tnp := SnakeToPascal(table.TableName)
+	s := ""
+	if zod {
+		s += "import { z } from \"zod\";\n\n"
+	}
+
+	if table.TableNameLogical != "" {
+		s += "/** " + table.TableNameLogical + " */\n"
+	}
+	s += "export interface " + tnp + " {\n"
+	for _, c := range columns {
+		s += srv.generateTypeScriptDoc(c)
+		s += fmt.Sprintf("\t%s: %s;\n", SnakeToCamel(c.ColumnName), srv.generateTypeScriptType(c))
+	}
+	if table.VersionFlg == constant.FLG_ON {
+		s += "\tversion: number;\n"
+	}
+	s += "\tcreatedAt: string;\n"
+	s += "\tupdatedAt: string;\n"
+	s += "}\n"
+
+	if !zod {
+		return s
+	}
+
+	s += "\nexport const " + SnakeToCamel(table.TableName) + "Schema = z.object({\n"
+	for _, c := range columns {
+		s += fmt.Sprintf("\t%s: %s,\n", SnakeToCamel(c.ColumnName), srv.generateZodType(c))
+	}
+	if table.VersionFlg == constant.FLG_ON {
+		s += "\tversion: z.number().int(),\n"
+	}
+	s += "\tcreatedAt: z.string(),\n"
+	s += "\tupdatedAt: z.string(),\n"
+	s += "});\n"
+
+	return s
+}
+
+
+// generateTypeScriptDoc "/** ColumnNameLogical Remark */" of the property.
+func (srv *codegenService) generateTypeScriptDoc(column model.Column) string {
+	var ls []string
+	if column.ColumnNameLogical != "" {
+		ls = append(ls, column.ColumnNameLogical)
+	}
+	if column.Remark != "" {
+		ls = append(ls, strings.Split(strings.ReplaceAll(column.Remark, "\r\n", "\n"), "\n")...)
+	}
+	if len(ls) == 0 {
+		return ""
+	}
+	for i, l := range ls {
+		ls[i] = strings.ReplaceAll(l, "*/", "* /")
+	}
+	if len(ls) == 1 {
+		return "\t/** " + ls[0] + " */\n"
+	}
+	return "\t/**\n\t * " + strings.Join(ls, "\n\t * ") + "\n\t */\n"
+}
+
+
+// tsEnumLiterals enum values as TypeScript literals. (numbers for numeric columns)
+func (srv *codegenService) tsEnumLiterals(column model.Column) []string {
+	if column.DataTypeCls != constant.DATA_TYPE_CLS_ENUM && column.ClassificationId == 0 {
+		return nil
+	}
+
+	var ret []string
+	for _, v := range srv.getEnumValues(column) {
+		if tsTypeMap[column.DataTypeCls] == "number" {
+			ret = append(ret, v)
+		} else {
+			ret = append(ret, jsonString(v))
+		}
+	}
+	return ret
+}
+
+
+// generateTypeScriptType "string", "\"a\" | \"b\"", "number | null" ...
+func (srv *codegenService) generateTypeScriptType(column model.Column) string {
+	s := tsTypeMap[column.DataTypeCls]
+	if values := srv.tsEnumLiterals(column); len(values) > 0 {
+		s = strings.Join(values, " | ")
+	}
+	if srv.isNullableColumn(column) {
+		s += " | null"
+	}
+	return s
+}
+
+
+// generateZodType "z.string().max(n)", "z.enum([...])", "z.number().int().nullable()" ...
+func (srv *codegenService) generateZodType(column model.Column) string {
+	s := zodTypeMap[column.DataTypeCls]
+
+	if values := srv.tsEnumLiterals(column); len(values) > 0 {
+		if tsTypeMap[column.DataTypeCls] == "number" {
+			var ls []string
+			for _, v := range values {
+				ls = append(ls, "z.literal(" + v + ")")
+			}
+			if len(ls) == 1 {
+				s = ls[0]
+			} else {
+				s = "z.union([" + strings.Join(ls, ", ") + "])"
+			}
+		} else {
+			s = "z.enum([" + strings.Join(values, ", ") + "])"
+		}
+	} else if column.Precision > 0 {
+		switch column.DataTypeCls {
+		case constant.DATA_TYPE_CLS_VARCHAR, constant.DATA_TYPE_CLS_CHAR:
+			s += fmt.Sprintf(".max(%d)", column.Precision)
+		}
+	}
+
+	if srv.isNullableColumn(column) {
+		s += ".nullable()"
+	}
+	return s
+}
\ No newline at end of file
@@ -1,9 +1,11 @@
 package service
 
 import (
+	"strconv"
 	"database/sql"
 
 	"goat-cg/internal/dto"
+	"goat-cg/internal/core/db"
 	"goat-cg/internal/core/logger"
 	"goat-cg/internal/core/errs"
 	"goat-cg/internal/model"
@@ -15,9 +17,12 @@ import (
 type ColumnService interface {
 	GetColumn(columnId int) (model.Column, error)
 	GetColumns(tableId int) ([]model.Column, error)
+	GetProjectColumns(projectId int) ([]dto.ProjectColumn, error)
 	CreateColumn(in dto.CreateColumn) error
 	UpdateColumn(sin dto.CreateColumn) error
 	DeleteColumn(columnId int) error
+	ValidateImportColumns(tableId int, columns []dto.CreateColumn) []error
+	ImportColumns(tableId int, columns []dto.CreateColumn) error
 	GetColumnLog(columnId int) ([]dto.ColumnLog, error)
 }
 
@@ -25,15 +30,31 @@ type ColumnService interface {
 type columnService struct {
 	columnRepository repository.ColumnRepository
 	tableRepository repository.TableRepository
+	checkRepository repository.CheckRepository
+	classificationRepository repository.ClassificationRepository
+	classificationValueRepository repository.ClassificationValueRepository
 	columnQuery query.ColumnQuery
+	sampleRowService SampleRowService
 }
 
 
 func NewColumnService() ColumnService {
 	columnRepository := repository.NewColumnRepository()
 	tableRepository := repository.NewTableRepository()
+	checkRepository := repository.NewCheckRepository()
+	classificationRepository := repository.NewClassificationRepository()
+	classificationValueRepository := repository.NewClassificationValueRepository()
 	columnQuery := query.NewColumnQuery()
-	return &columnService{columnRepository, tableRepository, columnQuery}
+	sampleRowService := NewSampleRowService()
+	return &columnService{
+		columnRepository, 
+		tableRepository, 
+		checkRepository, 
+		classificationRepository, 
+		classificationValueRepository,
+		columnQuery,
+		sampleRowService,
+	}
 }
 
 
@@ -65,20 +86,42 @@ func (srv *columnService) GetColumns(tableId int) ([]model.Column, error) {
 }
 
 
+// GetProjectColumns get columns of all tables in the project.
+func (srv *columnService) GetProjectColumns(projectId int) ([]dto.ProjectColumn, error) {
+	columns, err := srv.columnQuery.GetProjectColumns(projectId)
+
+	if err != nil {
+		logger.Error(err.Error())
+	}
+
+	return columns, err
+}
+
+
 // CreateColumn create new Column record.
 func (srv *columnService) CreateColumn(sin dto.CreateColumn) error {
 	_, err := srv.columnRepository.GetOne(&model.Column{ColumnName: sin.ColumnName, TableId: sin.TableId})
 	if err == nil {
 		return errs.NewUniqueConstraintError("column_name")
 	}
+
+	if err = srv.validateClassification(sin); err != nil {
+		return err
+	}
+
+	if err = srv.validateRefColumn(sin); err != nil {
+		return err
+	}
 	
 	column := sin.ToColumn()
 	
 	if err = srv.columnRepository.Insert(&column, nil); err != nil {
 		logger.Error(err.Error())
+		return err
 	}
+	srv.sampleRowService.ValidateSampleRows(column.TableId)
 
-	return err
+	return nil
 }
 
 
@@ -89,6 +132,18 @@ func (srv *columnService) UpdateColumn(sin dto.CreateColumn) error {
 	if err == nil && col.ColumnId != sin.ColumnId {
 		return errs.NewUniqueConstraintError("column_name")
 	}
+
+	if err = srv.validateClassification(sin); err != nil {
+		return err
+	}
+
+	if err = srv.validateRefColumn(sin); err != nil {
+		return err
+	}
+
+	if err = srv.validateChecks(sin); err != nil {
+		return err
+	}
 	
 	column := sin.ToColumn()
 
@@ -96,6 +151,120 @@ func (srv *columnService) UpdateColumn(sin dto.CreateColumn) error {
 		logger.Error(err.Error())
 		return err
 	}
+	srv.sampleRowService.ValidateSampleRows(column.TableId)
+
+	return nil
+}
+
+
+// validateClassification bound classification must belong to the project of the table,
+// and its codes must be numbers for numeric columns.
+func (srv *columnService) validateClassification(sin dto.CreateColumn) error {
+	if sin.ClassificationId == 0 {
+		return nil
+	}
+
+	table, err := srv.tableRepository.GetOne(&model.Table{TableId: sin.TableId})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	classification, err := srv.classificationRepository.GetOne(
+		&model.Classification{ClassificationId: sin.ClassificationId},
+	)
+	if err != nil || classification.ProjectId != table.ProjectId {
+		return errs.NewInvalidValueError("classification_id", "classification not found.")
+	}
+
+	if isNumericType(sin.DataTypeCls) {
+		values, err := srv.classificationValueRepository.Get(
+			&model.ClassificationValue{ClassificationId: sin.ClassificationId},
+		)
+		if err != nil {
+			logger.Error(err.Error())
+			return err
+		}
+		for _, v := range values {
+			if _, err := strconv.ParseFloat(v.Code, 64); err != nil {
+				return errs.NewInvalidValueError("classification_id", "codes must be numbers.")
+			}
+		}
+	}
+
+	return nil
+}
+
+
+// validateChecks the checks of the column must fit the data type. (it can be changed after the checks)
+func (srv *columnService) validateChecks(sin dto.CreateColumn) error {
+	if sin.ColumnId == 0 {
+		return nil
+	}
+
+	checks, err := srv.checkRepository.Get(&model.Check{ColumnId: sin.ColumnId})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+	for _, ck := range checks {
+		in := dto.CreateCheck{
+			CheckTypeCls: ck.CheckTypeCls,
+			MinValue: ck.MinValue,
+			MaxValue: ck.MaxValue,
+			Pattern: ck.Pattern,
+			AllowedValues: ck.AllowedValues,
+		}
+		if err = validateCheckValues(&in, sin.DataTypeCls); err != nil {
+			return errs.NewInvalidValueError("data_type_cls", "check " + ck.CheckName + " does not fit the data type.")
+		}
+	}
+
+	return nil
+}
+
+
+// validateRefColumn referenced column must be another column in the same project.
+func (srv *columnService) validateRefColumn(sin dto.CreateColumn) error {
+	if sin.RefColumnId == 0 {
+		return nil
+	}
+	if sin.RefColumnId == sin.ColumnId {
+		return errs.NewInvalidValueError("ref_column_id", "column can not reference itself.")
+	}
+
+	table, err := srv.tableRepository.GetOne(&model.Table{TableId: sin.TableId})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	ref, err := srv.columnRepository.GetOne(&model.Column{ColumnId: sin.RefColumnId})
+	if err != nil {
+		return errs.NewInvalidValueError("ref_column_id", "column not found.")
+	}
+	refTable, err := srv.tableRepository.GetOne(&model.Table{TableId: ref.TableId})
+	if err != nil || refTable.ProjectId != table.ProjectId {
+		return errs.NewInvalidValueError("ref_column_id", "column not found.")
+	}
+
+	return nil
+}
+
+
+// clearColumnReferences remove references to the column from other columns.
+func clearColumnReferences(rep repository.ColumnRepository, columnId int, tx *sql.Tx) error {
+	columns, err := rep.Get(&model.Column{RefColumnId: columnId})
+	if err != nil {
+		return err
+	}
+
+	for _, c := range columns {
+		c.RefColumnId = 0
+		if err = rep.Update(&c, tx); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -104,11 +273,139 @@ func (srv *columnService) UpdateColumn(sin dto.CreateColumn) error {
 // DeleteColumn delete Column record by columnId.
 // (physical delete)
 func (srv *columnService) DeleteColumn(columnId int) error {
-	if err := srv.columnRepository.Delete(&model.Column{ColumnId: columnId}, nil); err != nil {
+	column, err := srv.columnRepository.GetOne(&model.Column{ColumnId: columnId})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	tx, err := db.GetDB().Begin()
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	if err = srv.columnRepository.Delete(&model.Column{ColumnId: columnId}, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	if err = srv.checkRepository.Delete(&model.Check{ColumnId: columnId}, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	if err = clearColumnReferences(srv.columnRepository, columnId, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	srv.sampleRowService.ValidateSampleRows(column.TableId)
+
+	return nil
+}
+
+
+// ValidateImportColumns validate imported columns with the rules of CreateColumn and UpdateColumn.
+// returns an error (or nil) for each column.
+func (srv *columnService) ValidateImportColumns(tableId int, columns []dto.CreateColumn) []error {
+	ret := make([]error, len(columns))
+
+	// tableId 0: columns of a table not created yet. (import from CLI)
+	current := []model.Column{}
+	var err error
+	if tableId != 0 {
+		current, err = srv.columnRepository.Get(&model.Column{TableId: tableId})
+		if err != nil {
+			logger.Error(err.Error())
+			for i := range ret {
+				ret[i] = err
+			}
+			return ret
+		}
+	}
+	idByName := map[string]int{}
+	ids := map[int]bool{}
+	for _, c := range current {
+		idByName[c.ColumnName] = c.ColumnId
+		ids[c.ColumnId] = true
+	}
+
+	names := map[string]bool{}
+	for i, sin := range columns {
+		if names[sin.ColumnName] {
+			ret[i] = errs.NewUniqueConstraintError("column_name")
+			continue
+		}
+		names[sin.ColumnName] = true
+
+		if sin.ColumnId != 0 && !ids[sin.ColumnId] {
+			ret[i] = errs.NewInvalidValueError("column_id", "column not found.")
+			continue
+		}
+		if id, ok := idByName[sin.ColumnName]; ok && id != sin.ColumnId {
+			ret[i] = errs.NewUniqueConstraintError("column_name")
+			continue
+		}
+		if err = srv.validateClassification(sin); err != nil {
+			ret[i] = err
+			continue
+		}
+		if err = srv.validateRefColumn(sin); err != nil {
+			ret[i] = err
+			continue
+		}
+		if err = srv.validateChecks(sin); err != nil {
+			ret[i] = err
+		}
+	}
+
+	return ret
+}
+
+
+// ImportColumns create or update the columns in one transaction.
+// (changes are recorded in column_def_log by the triggers)
+func (srv *columnService) ImportColumns(tableId int, columns []dto.CreateColumn) error {
+	for _, err := range srv.ValidateImportColumns(tableId, columns) {
+		if err != nil {
+			return err
+		}
+	}
+
+	tx, err := db.GetDB().Begin()
+	if err != nil {
 		logger.Error(err.Error())
 		return err
 	}
 
+	for _, sin := range columns {
+		sin.TableId = tableId
+		column := sin.ToColumn()
+
+		if column.ColumnId == 0 {
+			err = srv.columnRepository.Insert(&column, tx)
+		} else {
+			err = srv.columnRepository.Update(&column, tx)
+		}
+		if err != nil {
+			tx.Rollback()
+			logger.Error(err.Error())
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	srv.sampleRowService.ValidateSampleRows(tableId)
+
 	return nil
 }
 
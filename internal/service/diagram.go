@@ -0,0 +1,449 @@
+package service
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strings"
+
+	"goat-cg/internal/dto"
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/core/logger"
+	"goat-cg/internal/model"
+	"goat-cg/internal/repository"
+)
+
+
+type DiagramService interface {
+	GetDiagram(projectId int, tableIds []int) (dto.Diagram, error)
+	ToMermaid(d dto.Diagram) string
+	ToPlantUml(d dto.Diagram) string
+	ToDot(d dto.Diagram) string
+	ToSvg(d dto.Diagram) string
+}
+
+
+type diagramService struct {
+	tableRepository repository.TableRepository
+	columnRepository repository.ColumnRepository
+}
+
+
+func NewDiagramService() DiagramService {
+	tableRepository := repository.NewTableRepository()
+	columnRepository := repository.NewColumnRepository()
+	return &diagramService{tableRepository, columnRepository}
+}
+
+
+// GetDiagram get tables, columns and relationships of the project.
+// tableIds: tables drawn in the diagram (empty: all tables)
+// relationships to tables not drawn are excluded.
+func (srv *diagramService) GetDiagram(projectId int, tableIds []int) (dto.Diagram, error) {
+	ret := dto.Diagram{}
+
+	tables, err := srv.tableRepository.Get(&model.Table{ProjectId: projectId})
+	if err != nil {
+		logger.Error(err.Error())
+		return ret, err
+	}
+
+	selected := map[int]bool{}
+	for _, id := range tableIds {
+		selected[id] = true
+	}
+
+	type columnRef struct {
+		tableName string
+		column model.Column
+		singlePk bool
+	}
+	refs := map[int]columnRef{}
+	var drawn []columnRef
+
+	for _, t := range tables {
+		if t.DelFlg == constant.FLG_ON || (len(tableIds) > 0 && !selected[t.TableId]) {
+			continue
+		}
+		columns, err := srv.columnRepository.Get(&model.Column{TableId: t.TableId})
+		if err != nil {
+			logger.Error(err.Error())
+			return ret, err
+		}
+
+		sort.SliceStable(columns, func(i, j int) bool {
+			return columns[i].AlignSeq < columns[j].AlignSeq
+		})
+		pkCount := 0
+		for _, c := range columns {
+			if c.DelFlg != constant.FLG_ON && c.PrimaryKeyFlg == constant.FLG_ON {
+				pkCount++
+			}
+		}
+
+		dt := dto.DiagramTable{TableName: t.TableName, TableNameLogical: t.TableNameLogical}
+		for _, c := range columns {
+			if c.DelFlg == constant.FLG_ON {
+				continue
+			}
+			dt.Columns = append(dt.Columns, dto.DiagramColumn{
+				ColumnName: c.ColumnName,
+				ColumnNameLogical: c.ColumnNameLogical,
+				DataType: dataTypeName(c.DataTypeCls),
+				PrimaryKey: c.PrimaryKeyFlg == constant.FLG_ON,
+				ForeignKey: c.RefColumnId != 0,
+				Unique: c.UniqueFlg == constant.FLG_ON,
+				NotNull: c.NotNullFlg == constant.FLG_ON,
+			})
+			refs[c.ColumnId] = columnRef{t.TableName, c, pkCount == 1}
+			drawn = append(drawn, columnRef{t.TableName, c, pkCount == 1})
+		}
+		ret.Tables = append(ret.Tables, dt)
+	}
+
+	for _, x := range drawn {
+		to, ok := refs[x.column.RefColumnId]
+		if x.column.RefColumnId == 0 || !ok {
+			continue
+		}
+		ret.Relations = append(ret.Relations, dto.DiagramRelation{
+			FromTable: x.tableName,
+			FromColumn: x.column.ColumnName,
+			ToTable: to.tableName,
+			ToColumn: to.column.ColumnName,
+			Unique: x.column.UniqueFlg == constant.FLG_ON || 
+				(x.singlePk && x.column.PrimaryKeyFlg == constant.FLG_ON),
+			NotNull: x.column.NotNullFlg == constant.FLG_ON || x.column.PrimaryKeyFlg == constant.FLG_ON,
+		})
+	}
+
+	return ret, nil
+}
+
+
+// dataTypeName DataTypeCls -> "VARCHAR"
+func dataTypeName(dataTypeCls string) string {
+	for name, cls := range constant.DATA_TYPE_CLS_BY_NAME {
+		if cls == dataTypeCls {
+			return name
+		}
+	}
+	return "UNKNOWN"
+}
+
+
+// columnKeys return key markers of the column. ("PK", "FK", "UK")
+func columnKeys(c dto.DiagramColumn) []string {
+	var ret []string
+	if c.PrimaryKey {
+		ret = append(ret, "PK")
+	}
+	if c.ForeignKey {
+		ret = append(ret, "FK")
+	}
+	if c.Unique {
+		ret = append(ret, "UK")
+	}
+	return ret
+}
+
+
+// relationNotation return crow's foot notation of the relation. ("||--o{")
+// left side is the referenced table.
+func relationNotation(r dto.DiagramRelation) string {
+	s := "|o--"
+	if r.NotNull {
+		s = "||--"
+	}
+	if r.Unique {
+		return s + "o|"
+	}
+	return s + "o{"
+}
+
+
+// ToMermaid return Mermaid erDiagram text.
+func (srv *diagramService) ToMermaid(d dto.Diagram) string {
+	s := "erDiagram\n"
+
+	for _, t := range d.Tables {
+		s += "\t" + t.TableName + " {\n"
+		for _, c := range t.Columns {
+			s += "\t\t" + c.DataType + " " + c.ColumnName
+			if keys := columnKeys(c); len(keys) > 0 {
+				s += " " + strings.Join(keys, ", ")
+			}
+			if c.ColumnNameLogical != "" {
+				s += " \"" + strings.ReplaceAll(c.ColumnNameLogical, "\"", "'") + "\""
+			}
+			s += "\n"
+		}
+		s += "\t}\n"
+	}
+
+	for _, r := range d.Relations {
+		s += fmt.Sprintf(
+			"\t%s %s %s : \"%s\"\n", r.ToTable, relationNotation(r), r.FromTable, r.FromColumn,
+		)
+	}
+
+	return s
+}
+
+
+// ToPlantUml return PlantUML entity relationship diagram text.
+func (srv *diagramService) ToPlantUml(d dto.Diagram) string {
+	s := "@startuml\nhide circle\nskinparam linetype ortho\n\n"
+
+	for _, t := range d.Tables {
+		label := t.TableName
+		if t.TableNameLogical != "" {
+			label += " (" + t.TableNameLogical + ")"
+		}
+		s += "entity \"" + strings.ReplaceAll(label, "\"", "'") + "\" as " + t.TableName + " {\n"
+
+		var pks, others string
+		for _, c := range t.Columns {
+			line := "\t"
+			if c.NotNull || c.PrimaryKey {
+				line += "* "
+			}
+			line += c.ColumnName + " : " + c.DataType
+			for _, k := range columnKeys(c) {
+				line += " <<" + k + ">>"
+			}
+			if c.PrimaryKey {
+				pks += line + "\n"
+			} else {
+				others += line + "\n"
+			}
+		}
+		s += pks
+		if pks != "" {
+			s += "\t--\n"
+		}
+		s += others + "}\n\n"
+	}
+
+	for _, r := range d.Relations {
+		s += fmt.Sprintf("%s %s %s : %s\n", r.ToTable, relationNotation(r), r.FromTable, r.FromColumn)
+	}
+
+	return s + "@enduml\n"
+}
+
+
+// ToDot return Graphviz DOT text. (tables are drawn as HTML-like labels)
+func (srv *diagramService) ToDot(d dto.Diagram) string {
+	s := "digraph er {\n\tgraph [rankdir=LR];\n\tnode [shape=plaintext, fontname=\"Helvetica\"];\n" +
+		"\tedge [arrowhead=normal];\n\n"
+
+	for _, t := range d.Tables {
+		header := html.EscapeString(t.TableName)
+		if t.TableNameLogical != "" {
+			header += "<br/>" + html.EscapeString(t.TableNameLogical)
+		}
+		s += fmt.Sprintf("\t\"%s\" [label=<\n", t.TableName) +
+			"\t\t<table border=\"0\" cellborder=\"1\" cellspacing=\"0\" cellpadding=\"4\">\n" +
+			fmt.Sprintf("\t\t<tr><td bgcolor=\"#eeeeee\" colspan=\"2\"><b>%s</b></td></tr>\n", header)
+		for _, c := range t.Columns {
+			name := html.EscapeString(c.ColumnName)
+			if c.PrimaryKey {
+				name = "<u>" + name + "</u>"
+			}
+			if keys := columnKeys(c); len(keys) > 0 {
+				name += " (" + strings.Join(keys, ", ") + ")"
+			}
+			s += fmt.Sprintf(
+				"\t\t<tr><td port=\"%s\" align=\"left\">%s</td><td align=\"left\">%s</td></tr>\n",
+				c.ColumnName, name, c.DataType,
+			)
+		}
+		s += "\t\t</table>\n\t>];\n"
+	}
+
+	if len(d.Relations) > 0 {
+		s += "\n"
+	}
+	for _, r := range d.Relations {
+		style := "solid"
+		if !r.NotNull {
+			style = "dashed"
+		}
+		s += fmt.Sprintf(
+			"\t\"%s\":\"%s\" -> \"%s\":\"%s\" [style=%s];\n",
+			r.FromTable, r.FromColumn, r.ToTable, r.ToColumn, style,
+		)
+	}
+
+	return s + "}\n"
+}
+
+
+const (
+	svgCharWidth = 7.2
+	svgRowHeight = 20
+	svgHeaderHeight = 26
+	svgGapX = 80
+	svgGapY = 50
+	svgMargin = 20
+)
+
+
+// svgTextWidth approximate width of text in monospace 12px. (wide characters count as 2)
+func svgTextWidth(s string) float64 {
+	n := 0
+	for _, r := range s {
+		if r >= 0x2E80 {
+			n += 2
+		} else {
+			n += 1
+		}
+	}
+	return float64(n) * svgCharWidth
+}
+
+
+type svgBox struct {
+	x, y, w, h float64
+}
+
+
+// ToSvg return SVG image of the diagram.
+// tables are placed on a grid, relationships are drawn as curves between the columns.
+func (srv *diagramService) ToSvg(d dto.Diagram) string {
+	if len(d.Tables) == 0 {
+		return "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"200\" height=\"40\">" +
+			"<text x=\"10\" y=\"25\" font-family=\"monospace\" font-size=\"12\">no tables</text></svg>"
+	}
+
+	headers := make([]string, len(d.Tables))
+	boxes := make([]svgBox, len(d.Tables))
+	for i, t := range d.Tables {
+		headers[i] = t.TableName
+		if t.TableNameLogical != "" {
+			headers[i] += " (" + t.TableNameLogical + ")"
+		}
+		w := svgTextWidth(headers[i])
+		for _, c := range t.Columns {
+			w = math.Max(w, svgTextWidth("PK " + c.ColumnName + "  " + c.DataType))
+		}
+		rows := math.Max(float64(len(t.Columns)), 1)
+		boxes[i] = svgBox{w: w + 20, h: svgHeaderHeight + rows * svgRowHeight}
+	}
+
+	cols := int(math.Ceil(math.Sqrt(float64(len(d.Tables)))))
+	colWidths := make([]float64, cols)
+	rowHeights := make([]float64, (len(d.Tables) + cols - 1) / cols)
+	for i, b := range boxes {
+		colWidths[i % cols] = math.Max(colWidths[i % cols], b.w)
+		rowHeights[i / cols] = math.Max(rowHeights[i / cols], b.h)
+	}
+	for i := range boxes {
+		x, y := float64(svgMargin), float64(svgMargin)
+		for c := 0; c < i % cols; c++ {
+			x += colWidths[c] + svgGapX
+		}
+		for r := 0; r < i / cols; r++ {
+			y += rowHeights[r] + svgGapY
+		}
+		boxes[i].x, boxes[i].y = x, y
+	}
+
+	width, height := float64(svgMargin * 2), float64(svgMargin * 2)
+	for _, w := range colWidths {
+		width += w + svgGapX
+	}
+	for _, h := range rowHeights {
+		height += h + svgGapY
+	}
+
+	s := fmt.Sprintf(
+		"<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%.0f\" height=\"%.0f\" viewBox=\"0 0 %.0f %.0f\" " +
+		"font-family=\"monospace\" font-size=\"12\">\n", width, height, width, height,
+	)
+	s += "<defs><marker id=\"arrow\" viewBox=\"0 0 10 10\" refX=\"10\" refY=\"5\" " +
+		"markerWidth=\"8\" markerHeight=\"8\" orient=\"auto-start-reverse\">" +
+		"<path d=\"M 0 0 L 10 5 L 0 10 z\" fill=\"#555\"/></marker></defs>\n"
+
+	tableIndex := map[string]int{}
+	for i, t := range d.Tables {
+		tableIndex[t.TableName] = i
+		b := boxes[i]
+		s += fmt.Sprintf("<g id=\"table-%s\">\n", html.EscapeString(t.TableName))
+		s += fmt.Sprintf(
+			"\t<rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%.1f\" fill=\"#ffffff\" stroke=\"#333333\"/>\n",
+			b.x, b.y, b.w, b.h,
+		)
+		s += fmt.Sprintf(
+			"\t<rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%d\" fill=\"#eeeeee\" stroke=\"#333333\"/>\n",
+			b.x, b.y, b.w, svgHeaderHeight,
+		)
+		s += fmt.Sprintf(
+			"\t<text x=\"%.1f\" y=\"%.1f\" font-weight=\"bold\">%s</text>\n",
+			b.x + 10, b.y + 17, html.EscapeString(headers[i]),
+		)
+		for j, c := range t.Columns {
+			y := b.y + svgHeaderHeight + float64(j * svgRowHeight) + 14
+			marker := ""
+			if c.PrimaryKey {
+				marker = "PK"
+			} else if c.ForeignKey {
+				marker = "FK"
+			}
+			weight := ""
+			if c.PrimaryKey {
+				weight = " font-weight=\"bold\""
+			}
+			s += fmt.Sprintf("\t<text x=\"%.1f\" y=\"%.1f\" fill=\"#888888\">%s</text>\n", b.x + 6, y, marker)
+			s += fmt.Sprintf(
+				"\t<text x=\"%.1f\" y=\"%.1f\"%s>%s</text>\n",
+				b.x + 6 + 3 * svgCharWidth, y, weight, html.EscapeString(c.ColumnName),
+			)
+			s += fmt.Sprintf(
+				"\t<text x=\"%.1f\" y=\"%.1f\" text-anchor=\"end\" fill=\"#3273dc\">%s</text>\n",
+				b.x + b.w - 8, y, c.DataType,
+			)
+		}
+		s += "</g>\n"
+	}
+
+	rowY := func(ti int, columnName string) float64 {
+		for j, c := range d.Tables[ti].Columns {
+			if c.ColumnName == columnName {
+				return boxes[ti].y + svgHeaderHeight + float64(j * svgRowHeight) + svgRowHeight / 2
+			}
+		}
+		return boxes[ti].y + svgHeaderHeight / 2
+	}
+
+	for _, r := range d.Relations {
+		fi, ti := tableIndex[r.FromTable], tableIndex[r.ToTable]
+		from, to := boxes[fi], boxes[ti]
+		y1, y2 := rowY(fi, r.FromColumn), rowY(ti, r.ToColumn)
+
+		var x1, x2, c1, c2 float64
+		if to.x > from.x + from.w {
+			x1, x2, c1, c2 = from.x + from.w, to.x, from.x + from.w + 40, to.x - 40
+		} else if to.x + to.w < from.x {
+			x1, x2, c1, c2 = from.x, to.x + to.w, from.x - 40, to.x + to.w + 40
+		} else {
+			x1, x2 = from.x + from.w, to.x + to.w
+			c1, c2 = math.Max(x1, x2) + 50, math.Max(x1, x2) + 50
+		}
+
+		dash := ""
+		if !r.NotNull {
+			dash = " stroke-dasharray=\"5,3\""
+		}
+		s += fmt.Sprintf(
+			"<path d=\"M %.1f %.1f C %.1f %.1f, %.1f %.1f, %.1f %.1f\" fill=\"none\" stroke=\"#555555\"%s " +
+			"marker-end=\"url(#arrow)\"><title>%s.%s -&gt; %s.%s</title></path>\n",
+			x1, y1, c1, y1, c2, y2, x2, y2, dash,
+			r.FromTable, r.FromColumn, r.ToTable, r.ToColumn,
+		)
+	}
+
+	return s + "</svg>"
+}
\ No newline at end of file
@@ -0,0 +1,365 @@
+package service
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+	"archive/zip"
+	"encoding/csv"
+
+	"goat-cg/internal/dto"
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/core/logger"
+	"goat-cg/internal/core/xlsx"
+	"goat-cg/internal/model"
+	"goat-cg/internal/repository"
+)
+
+
+type DictionaryService interface {
+	GetDictionary(project model.Project, tableIds []int) (dto.Dictionary, error)
+	ToMarkdown(d dto.Dictionary) string
+	ToHtmlSite(d dto.Dictionary) ([]byte, error)
+	ToCsv(d dto.Dictionary) ([]byte, error)
+	ToXlsx(d dto.Dictionary) ([]byte, error)
+}
+
+
+type dictionaryService struct {
+	tableRepository repository.TableRepository
+	columnRepository repository.ColumnRepository
+	sampleRowService SampleRowService
+}
+
+
+func NewDictionaryService() DictionaryService {
+	tableRepository := repository.NewTableRepository()
+	columnRepository := repository.NewColumnRepository()
+	sampleRowService := NewSampleRowService()
+	return &dictionaryService{tableRepository, columnRepository, sampleRowService}
+}
+
+
+// GetDictionary get tables and columns of the project as shown in the column list.
+// tableIds: tables in the document (empty: all tables)
+func (srv *dictionaryService) GetDictionary(project model.Project, tableIds []int) (dto.Dictionary, error) {
+	ret := dto.Dictionary{ProjectName: project.ProjectName}
+
+	tables, err := srv.tableRepository.Get(&model.Table{ProjectId: project.ProjectId})
+	if err != nil {
+		logger.Error(err.Error())
+		return ret, err
+	}
+
+	selected := map[int]bool{}
+	for _, id := range tableIds {
+		selected[id] = true
+	}
+
+	for _, t := range tables {
+		if t.DelFlg == constant.FLG_ON || (len(tableIds) > 0 && !selected[t.TableId]) {
+			continue
+		}
+		columns, err := srv.columnRepository.Get(&model.Column{TableId: t.TableId})
+		if err != nil {
+			logger.Error(err.Error())
+			return ret, err
+		}
+		sort.SliceStable(columns, func(i, j int) bool {
+			return columns[i].AlignSeq < columns[j].AlignSeq
+		})
+
+		dt := dto.DictionaryTable{
+			TableName: t.TableName,
+			TableNameLogical: t.TableNameLogical,
+			UpdatedAt: t.UpdatedAt,
+		}
+		for _, c := range columns {
+			if c.DelFlg == constant.FLG_ON {
+				continue
+			}
+			dt.Columns = append(dt.Columns, dto.DictionaryColumn{
+				No: strconv.Itoa(c.AlignSeq),
+				ColumnName: c.ColumnName,
+				ColumnNameLogical: c.ColumnNameLogical,
+				DataType: dataTypeName(c.DataTypeCls),
+				Precision: c.Precision,
+				Scale: c.Scale,
+				PrimaryKey: c.PrimaryKeyFlg == constant.FLG_ON,
+				NotNull: c.NotNullFlg == constant.FLG_ON,
+				Unique: c.UniqueFlg == constant.FLG_ON,
+				DefaultValue: c.DefaultValue,
+				Remark: c.Remark,
+				UpdatedAt: c.UpdatedAt,
+			})
+		}
+
+		if t.VersionFlg == constant.FLG_ON {
+			dt.Columns = append(dt.Columns, dto.DictionaryColumn{
+				No: "-", ColumnName: "version", ColumnNameLogical: "バージョン",
+				DataType: "INTEGER", NotNull: true, DefaultValue: "0",
+			})
+		}
+		dt.Columns = append(dt.Columns,
+			dto.DictionaryColumn{
+				No: "-", ColumnName: "created_at", ColumnNameLogical: "登録日時",
+				DataType: "TIMESTAMP", NotNull: true,
+			},
+			dto.DictionaryColumn{
+				No: "-", ColumnName: "updated_at", ColumnNameLogical: "更新日時",
+				DataType: "TIMESTAMP", NotNull: true,
+			},
+		)
+
+		if dt.Samples, err = srv.sampleRowService.GetSampleRows(t.TableId, false); err != nil {
+			return ret, err
+		}
+
+		ret.Tables = append(ret.Tables, dt)
+	}
+
+	return ret, nil
+}
+
+
+var dictionaryTableHeader = []string{"No", "Table Name", "Table Name（JP）", "UpdatedAt"}
+
+var dictionaryColumnHeader = []string{
+	"No", "Column Name", "Column Name（JP）", "Type", "Precision", "Scale",
+	"PK", "NN", "UQ", "Default", "Remark", "UpdatedAt",
+}
+
+
+// dictionaryColumnRow values of a column in the order of dictionaryColumnHeader.
+func dictionaryColumnRow(c dto.DictionaryColumn) []string {
+	mark := func(b bool) string {
+		if b {
+			return "○"
+		}
+		return ""
+	}
+	number := func(n int) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.Itoa(n)
+	}
+
+	return []string{
+		c.No, c.ColumnName, c.ColumnNameLogical, c.DataType, number(c.Precision), number(c.Scale),
+		mark(c.PrimaryKey), mark(c.NotNull), mark(c.Unique), c.DefaultValue, c.Remark, c.UpdatedAt,
+	}
+}
+
+
+// ToMarkdown return the document as one Markdown file.
+func (srv *dictionaryService) ToMarkdown(d dto.Dictionary) string {
+	cell := func(v string) string {
+		v = strings.ReplaceAll(v, "|", "\\|")
+		return strings.ReplaceAll(strings.ReplaceAll(v, "\r\n", "\n"), "\n", "<br>")
+	}
+	row := func(values []string) string {
+		var ls []string
+		for _, v := range values {
+			ls = append(ls, cell(v))
+		}
+		return "| " + strings.Join(ls, " | ") + " |\n"
+	}
+	separator := func(n int) string {
+		return "|" + strings.Repeat(" --- |", n) + "\n"
+	}
+
+	s := "# " + d.ProjectName + " Table Definitions\n\n"
+
+	s += "## Tables\n\n" + row(dictionaryTableHeader) + separator(len(dictionaryTableHeader))
+	for i, t := range d.Tables {
+		link := "[" + t.TableName + "](#" + t.TableName + ")"
+		s += row([]string{strconv.Itoa(i + 1), link, t.TableNameLogical, t.UpdatedAt})
+	}
+
+	for _, t := range d.Tables {
+		s += "\n<a id=\"" + t.TableName + "\"></a>\n\n## " + t.TableName
+		if t.TableNameLogical != "" {
+			s += " (" + t.TableNameLogical + ")"
+		}
+		s += "\n\nUpdatedAt: " + t.UpdatedAt + "\n\n"
+		s += row(dictionaryColumnHeader) + separator(len(dictionaryColumnHeader))
+		for _, c := range t.Columns {
+			s += row(dictionaryColumnRow(c))
+		}
+
+		if len(t.Samples.Rows) > 0 {
+			s += "\n### Sample Data\n\n" + row(t.Samples.Columns) + separator(len(t.Samples.Columns))
+			for _, r := range t.Samples.Rows {
+				s += row(r.Values)
+			}
+		}
+	}
+
+	return s
+}
+
+
+const dictionaryHtmlStyle = `<style>
+body { font-family: sans-serif; margin: 2em; color: #333; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #aaa; padding: 4px 8px; font-size: 0.9em; }
+th { background: #eee; }
+td.remark { white-space: pre-wrap; }
+</style>`
+
+
+// ToHtmlSite return the document as zip of standalone HTML pages.
+// (index.html and tables/<table_name>.html)
+func (srv *dictionaryService) ToHtmlSite(d dto.Dictionary) ([]byte, error) {
+	page := func(title, root, body string) string {
+		return "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>" +
+			html.EscapeString(title) + "</title>\n" + dictionaryHtmlStyle + "\n</head>\n<body>\n" +
+			"<p><a href=\"" + root + "index.html\">" + html.EscapeString(d.ProjectName) + "</a></p>\n" +
+			body + "</body>\n</html>\n"
+	}
+	thead := func(header []string) string {
+		s := "<tr>"
+		for _, h := range header {
+			s += "<th>" + html.EscapeString(h) + "</th>"
+		}
+		return s + "</tr>\n"
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	now := time.Now()
+	write := func(name, content string) error {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return err
+		}
+		_, err = w.Write([]byte(content))
+		return err
+	}
+
+	body := "<h1>" + html.EscapeString(d.ProjectName) + " Table Definitions</h1>\n" +
+		"<table>\n" + thead(dictionaryTableHeader)
+	for i, t := range d.Tables {
+		body += fmt.Sprintf(
+			"<tr><td>%d</td><td><a href=\"tables/%s.html\">%s</a></td><td>%s</td><td>%s</td></tr>\n",
+			i + 1, t.TableName, html.EscapeString(t.TableName),
+			html.EscapeString(t.TableNameLogical), html.EscapeString(t.UpdatedAt),
+		)
+	}
+	body += "</table>\n"
+	if err := write("index.html", page(d.ProjectName, "", body)); err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	for _, t := range d.Tables {
+		body := "<h1>" + html.EscapeString(t.TableName)
+		if t.TableNameLogical != "" {
+			body += " (" + html.EscapeString(t.TableNameLogical) + ")"
+		}
+		body += "</h1>\n<p>UpdatedAt: " + html.EscapeString(t.UpdatedAt) + "</p>\n" +
+			"<table>\n" + thead(dictionaryColumnHeader)
+		for _, c := range t.Columns {
+			body += "<tr>"
+			for i, v := range dictionaryColumnRow(c) {
+				if dictionaryColumnHeader[i] == "Remark" {
+					body += "<td class=\"remark\">" + html.EscapeString(v) + "</td>"
+				} else {
+					body += "<td>" + html.EscapeString(v) + "</td>"
+				}
+			}
+			body += "</tr>\n"
+		}
+		body += "</table>\n"
+
+		if len(t.Samples.Rows) > 0 {
+			body += "<h2>Sample Data</h2>\n<table>\n" + thead(t.Samples.Columns)
+			for _, r := range t.Samples.Rows {
+				body += "<tr>"
+				for _, v := range r.Values {
+					body += "<td>" + html.EscapeString(v) + "</td>"
+				}
+				body += "</tr>\n"
+			}
+			body += "</table>\n"
+		}
+
+		if err := write("tables/" + t.TableName + ".html", page(t.TableName, "../", body)); err != nil {
+			logger.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+
+// ToCsv return all columns of the document in one CSV. (UTF-8 with BOM for spreadsheets)
+// sample data is not included to keep the layout of column import.
+func (srv *dictionaryService) ToCsv(d dto.Dictionary) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\uFEFF")
+	w := csv.NewWriter(&buf)
+
+	w.Write(append([]string{"Table Name", "Table Name（JP）"}, dictionaryColumnHeader...))
+	for _, t := range d.Tables {
+		for _, c := range t.Columns {
+			w.Write(append([]string{t.TableName, t.TableNameLogical}, dictionaryColumnRow(c)...))
+		}
+	}
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+
+// ToXlsx return the document as workbook.
+// (table list sheet, one sheet per table and sample data sheet of all tables)
+func (srv *dictionaryService) ToXlsx(d dto.Dictionary) ([]byte, error) {
+	index := xlsx.Sheet{Name: "Tables", Rows: [][]string{dictionaryTableHeader}, HeaderRows: 1}
+	sheets := []xlsx.Sheet{}
+	samples := xlsx.Sheet{Name: "Sample Data"}
+
+	for i, t := range d.Tables {
+		index.Rows = append(index.Rows, []string{
+			strconv.Itoa(i + 1), t.TableName, t.TableNameLogical, t.UpdatedAt,
+		})
+
+		sh := xlsx.Sheet{Name: t.TableName, Rows: [][]string{dictionaryColumnHeader}, HeaderRows: 1}
+		for _, c := range t.Columns {
+			sh.Rows = append(sh.Rows, dictionaryColumnRow(c))
+		}
+		sheets = append(sheets, sh)
+
+		if len(t.Samples.Rows) > 0 {
+			samples.Rows = append(samples.Rows, []string{t.TableName}, t.Samples.Columns)
+			for _, r := range t.Samples.Rows {
+				samples.Rows = append(samples.Rows, r.Values)
+			}
+			samples.Rows = append(samples.Rows, []string{})
+		}
+	}
+	if len(samples.Rows) > 0 {
+		sheets = append(sheets, samples)
+	}
+
+	var buf bytes.Buffer
+	if err := xlsx.Write(&buf, append([]xlsx.Sheet{index}, sheets...)); err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
\ No newline at end of file
@@ -1,6 +1,13 @@
 package service
 
 import (
+	"time"
+	"strconv"
+	"database/sql"
+
+	"goat-cg/config"
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/shared/roles"
 	"goat-cg/internal/core/errs"
 	"goat-cg/internal/core/logger"
 	"goat-cg/internal/model"
@@ -11,45 +18,144 @@ import (
 
 
 type MemberService interface {
-	Invite(projectId int, email string) error
+	Invite(projectId, actorId int, actorRole, email, userRole string) error
 	GetMembers(projectId int) ([]dto.ProjectMember, error)
 	GetMember(projectId, userId int) (dto.ProjectMember, error)
-	DeleteMember(projectId, userId int) error
+	GetRole(project model.Project, userId int) (string, error)
+	UpdateRole(projectId int, actorRole string, userId int, userRole string) error
+	DeleteMember(projectId int, actorRole string, userId int) error
+
+	GetInvitations(userId int) ([]dto.Invitation, error)
+	GetEmailInvitations(projectId int) ([]dto.Invitation, error)
+	AcceptInvitation(projectId, userId int) error
+	DeclineInvitation(projectId, userId int) error
+	RevokeInvitation(projectId, actorId int, actorRole string, userId int) error
+	RevokeEmailInvitation(projectId, actorId int, actorRole string, invitationId int) error
+
+	RequestJoin(project model.Project, userId int) error
+	CancelJoin(projectId, userId int) error
+	ApproveJoin(projectId int, actorRole string, userId int, userRole string) error
+	RejectJoin(projectId int, actorRole string, userId int) error
+	BlockMember(projectId int, actorRole string, userId int) error
 }
 
 
 type memberService struct {
 	memberRepository repository.MemberRepository
+	invitationRepository repository.InvitationRepository
 	userRepository repository.UserRepository
+	orgMemberRepository repository.OrgMemberRepository
 	projectMemberQuery query.ProjectMemberQuery
+	organizationQuery query.OrganizationQuery
 }
 
 
 func NewMemberService() MemberService {
 	memberRepository := repository.NewMemberRepository()
+	invitationRepository := repository.NewInvitationRepository()
 	userRepository := repository.NewUserRepository()
+	orgMemberRepository := repository.NewOrgMemberRepository()
 	projectMemberQuery := query.NewProjectMemberQuery()
-	return &memberService{memberRepository, userRepository, projectMemberQuery}
+	organizationQuery := query.NewOrganizationQuery()
+	return &memberService{
+		memberRepository,
+		invitationRepository,
+		userRepository,
+		orgMemberRepository,
+		projectMemberQuery,
+		organizationQuery,
+	}
 }
 
-func (srv *memberService) Invite(projectId int, email string) error {
+
+// stateClsName names of USER_PROJECTS.STATE_CLS for display.
+var stateClsName = map[string]string{
+	constant.STATE_CLS_NOMAL: "Active",
+	constant.STATE_CLS_JOIN: "Requested",
+	constant.STATE_CLS_REQUEST: "Invited",
+	constant.STATE_CLS_BLOCK: "Blocked",
+}
+
+
+// invitationExpiresAt expiry of invitations made now. (INVITATION_EXPIRES_DAYS, 7 days by default)
+func invitationExpiresAt() string {
+	days, err := strconv.Atoi(config.GetConfig().InvitationExpiresDays)
+	if err != nil || days <= 0 {
+		days = 7
+	}
+	return time.Now().AddDate(0, 0, days).Format(datetimeFormat)
+}
+
+
+func isExpired(expiresAt string) bool {
+	return expiresAt != "" && expiresAt < time.Now().Format(datetimeFormat)
+}
+
+
+// Invite invite the user of the email to the project. userRole: editor if empty.
+// the invitation is pending (STATE_CLS_REQUEST) until the user accepts it.
+// emails not signed up yet are kept in project_invitation and claimed at the signup.
+// inviting the user requesting to join approves the request. blocked users cannot be invited.
+func (srv *memberService) Invite(projectId, actorId int, actorRole, email, userRole string) error {
+	if userRole == "" {
+		userRole = constant.ROLE_CLS_NOMAL
+	}
+	if err := roles.ValidateChange(actorRole, "", userRole); err != nil {
+		return err
+	}
+
 	user, err := srv.userRepository.GetOne(&model.User{Email: email})
 	if err != nil {
-		return errs.NewNotFoundError()
+		if err != sql.ErrNoRows {
+			logger.Error(err.Error())
+			return err
+		}
+		return srv.inviteEmail(projectId, actorId, email, userRole)
 	}
 
-	_, err = srv.memberRepository.GetOne(&model.Member{ProjectId: projectId, UserId: user.UserId})
+	m, err := srv.memberRepository.GetOne(&model.Member{ProjectId: projectId, UserId: user.UserId})
 	if err == nil {
-		return errs.NewAlreadyRegisteredError()
+		switch {
+		case m.UserStatus == constant.STATE_CLS_BLOCK:
+			return errs.NewInvalidValueError("email", "the user is blocked.")
+		case m.UserStatus == constant.STATE_CLS_JOIN:
+			return srv.ApproveJoin(projectId, actorRole, user.UserId, userRole)
+		case !(m.UserStatus == constant.STATE_CLS_REQUEST && isExpired(m.ExpiresAt)):
+			return errs.NewAlreadyRegisteredError()
+		}
 	}
 
-	var m model.Member
+	m = model.Member{}
 	m.ProjectId = projectId
 	m.UserId = user.UserId
-	m.UserStatus = "0"
-	m.UserRole = "0"
+	m.UserStatus = constant.STATE_CLS_REQUEST
+	m.UserRole = userRole
+	m.InvitedBy = actorId
+	m.ExpiresAt = invitationExpiresAt()
+
+	if err = srv.memberRepository.Upsert(&m, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
 
-	if err = srv.memberRepository.Insert(&m, nil); err != nil {
+func (srv *memberService) inviteEmail(projectId, actorId int, email, userRole string) error {
+	i, err := srv.invitationRepository.GetOne(&model.Invitation{ProjectId: projectId, Email: email})
+	if err == nil && !isExpired(i.ExpiresAt) {
+		return errs.NewAlreadyRegisteredError()
+	}
+
+	i = model.Invitation{}
+	i.ProjectId = projectId
+	i.Email = email
+	i.UserRole = userRole
+	i.InvitedBy = actorId
+	i.ExpiresAt = invitationExpiresAt()
+
+	if err = srv.invitationRepository.Upsert(&i, nil); err != nil {
 		logger.Error(err.Error())
 		return err
 	}
@@ -57,20 +163,369 @@ func (srv *memberService) Invite(projectId int, email string) error {
 	return nil
 }
 
+
 func (srv *memberService) GetMembers(projectId int) ([]dto.ProjectMember, error) {
-	return srv.projectMemberQuery.GetProjectMembers(projectId)
+	members, err := srv.projectMemberQuery.GetProjectMembers(projectId)
+	if err != nil {
+		logger.Error(err.Error())
+		return []dto.ProjectMember{}, err
+	}
+
+	for i := range members {
+		setMemberNames(&members[i])
+	}
+	return members, nil
 }
 
+
 func (srv *memberService) GetMember(projectId, userId int) (dto.ProjectMember, error) {
-	return srv.projectMemberQuery.GetProjectMember(projectId, userId)
+	member, err := srv.projectMemberQuery.GetProjectMember(projectId, userId)
+	if err != nil {
+		return member, err
+	}
+
+	setMemberNames(&member)
+	return member, nil
 }
 
-func (srv *memberService) DeleteMember(projectId, userId int) error {
-	var m model.Member
-	m.ProjectId = projectId
+
+func setMemberNames(m *dto.ProjectMember) {
+	m.UserRole = roles.Normalize(m.UserRole)
+	m.RoleName = constant.ROLE_CLS_NAME[m.UserRole]
+	m.StatusName = stateClsName[m.UserStatus]
+	m.Expired = m.UserStatus == constant.STATE_CLS_REQUEST && isExpired(m.ExpiresAt)
+	if m.Expired {
+		m.StatusName = "Expired"
+	}
+}
+
+
+// GetRole role of the user in the project. NotFoundError if the user is not a member.
+// (pending invitations are not memberships)
+// the owners of the organization are the owners of its projects, and the roles granted to the teams
+// of the user count. the highest role is taken. (none if blocked)
+func (srv *memberService) GetRole(project model.Project, userId int) (string, error) {
+	if project.UserId != 0 && project.UserId == userId {
+		return constant.ROLE_CLS_OWNER, nil
+	}
+
+	var teamRoles []string
+	if project.OrgId != 0 {
+		om, err := srv.orgMemberRepository.GetOne(&model.OrgMember{OrgId: project.OrgId, UserId: userId})
+		if err == nil && om.OrgRole == constant.ORG_ROLE_CLS_OWNER {
+			return constant.ROLE_CLS_OWNER, nil
+		}
+
+		teamRoles, err = srv.organizationQuery.GetTeamRoles(project.ProjectId, userId)
+		if err != nil {
+			logger.Error(err.Error())
+		}
+	}
+
+	m, err := srv.memberRepository.GetOne(&model.Member{ProjectId: project.ProjectId, UserId: userId})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			logger.Error(err.Error())
+		}
+		m = model.Member{}
+	}
+
+	return roles.Highest(m, teamRoles)
+}
+
+
+
+func (srv *memberService) UpdateRole(projectId int, actorRole string, userId int, userRole string) error {
+	m, err := srv.memberRepository.GetOne(&model.Member{ProjectId: projectId, UserId: userId})
+	if err != nil {
+		return errs.NewNotFoundError()
+	}
+
+	if m.UserStatus != constant.STATE_CLS_NOMAL && m.UserStatus != constant.STATE_CLS_REQUEST {
+		return errs.NewInvalidValueError("user_status", "only the roles of members and invitations can be changed.")
+	}
+	if err = roles.ValidateChange(actorRole, roles.Normalize(m.UserRole), userRole); err != nil {
+		return err
+	}
+
+	m.UserRole = userRole
+	if err = srv.memberRepository.Update(&m, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+// DeleteMember remove the member. actors can only remove the members of the roles lower than their own.
+// (removing a blocked user unblocks them)
+func (srv *memberService) DeleteMember(projectId int, actorRole string, userId int) error {
+	m, err := srv.memberRepository.GetOne(&model.Member{ProjectId: projectId, UserId: userId})
+	if err != nil {
+		return errs.NewNotFoundError()
+	}
+
+	if !roles.Has(actorRole, constant.ROLE_CLS_ADMIN) ||
+		constant.ROLE_CLS_RANK[roles.Normalize(m.UserRole)] >= constant.ROLE_CLS_RANK[actorRole] {
+		return errs.NewForbiddenError()
+	}
+
+	if err = srv.memberRepository.Delete(&m, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+// GetInvitations pending invitations to the user. (not expired)
+func (srv *memberService) GetInvitations(userId int) ([]dto.Invitation, error) {
+	invitations, err := srv.projectMemberQuery.GetUserInvitations(userId)
+	if err != nil {
+		logger.Error(err.Error())
+		return []dto.Invitation{}, err
+	}
+
+	ret := []dto.Invitation{}
+	for _, i := range invitations {
+		if isExpired(i.ExpiresAt) {
+			continue
+		}
+		i.RoleName = constant.ROLE_CLS_NAME[i.UserRole]
+		ret = append(ret, i)
+	}
+	return ret, nil
+}
+
+
+// GetEmailInvitations invitations of the project to the emails not signed up yet.
+func (srv *memberService) GetEmailInvitations(projectId int) ([]dto.Invitation, error) {
+	invitations, err := srv.projectMemberQuery.GetEmailInvitations(projectId)
+	if err != nil {
+		logger.Error(err.Error())
+		return []dto.Invitation{}, err
+	}
+
+	for i := range invitations {
+		invitations[i].RoleName = constant.ROLE_CLS_NAME[invitations[i].UserRole]
+		invitations[i].Expired = isExpired(invitations[i].ExpiresAt)
+	}
+	return invitations, nil
+}
+
+
+// getInvitation pending invitation to the user. NotFoundError if there is not.
+func (srv *memberService) getInvitation(projectId, userId int) (model.Member, error) {
+	m, err := srv.memberRepository.GetOne(&model.Member{ProjectId: projectId, UserId: userId})
+	if err != nil || m.UserStatus != constant.STATE_CLS_REQUEST {
+		return model.Member{}, errs.NewNotFoundError()
+	}
+	return m, nil
+}
+
+
+func (srv *memberService) AcceptInvitation(projectId, userId int) error {
+	m, err := srv.getInvitation(projectId, userId)
+	if err != nil {
+		return err
+	}
+	if isExpired(m.ExpiresAt) {
+		return errs.NewInvalidValueError("invitation", "expired.")
+	}
+
+	m.UserStatus = constant.STATE_CLS_NOMAL
+	m.ExpiresAt = ""
+	if err = srv.memberRepository.Update(&m, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+func (srv *memberService) DeclineInvitation(projectId, userId int) error {
+	m, err := srv.getInvitation(projectId, userId)
+	if err != nil {
+		return err
+	}
+
+	if err = srv.memberRepository.Delete(&m, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+// canRevoke the inviter, or the members who can manage the role of the invitation.
+func canRevoke(actorId int, actorRole string, invitedBy int, userRole string) bool {
+	if actorId == invitedBy {
+		return true
+	}
+	return roles.Has(actorRole, constant.ROLE_CLS_ADMIN) &&
+		constant.ROLE_CLS_RANK[roles.Normalize(userRole)] < constant.ROLE_CLS_RANK[actorRole]
+}
+
+
+func (srv *memberService) RevokeInvitation(projectId, actorId int, actorRole string, userId int) error {
+	m, err := srv.getInvitation(projectId, userId)
+	if err != nil {
+		return err
+	}
+	if !canRevoke(actorId, actorRole, m.InvitedBy, m.UserRole) {
+		return errs.NewForbiddenError()
+	}
+
+	if err = srv.memberRepository.Delete(&m, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+func (srv *memberService) RevokeEmailInvitation(projectId, actorId int, actorRole string, invitationId int) error {
+	i, err := srv.invitationRepository.GetOne(&model.Invitation{InvitationId: invitationId})
+	if err != nil || i.ProjectId != projectId {
+		return errs.NewNotFoundError()
+	}
+	if !canRevoke(actorId, actorRole, i.InvitedBy, i.UserRole) {
+		return errs.NewForbiddenError()
+	}
+
+	if err = srv.invitationRepository.Delete(&model.Invitation{InvitationId: invitationId}, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+// RequestJoin request to join the project. (STATE_CLS_JOIN, as a viewer until approved)
+// blocked users cannot request.
+func (srv *memberService) RequestJoin(project model.Project, userId int) error {
+	if project.UserId == userId {
+		return errs.NewAlreadyRegisteredError()
+	}
+
+	m, err := srv.memberRepository.GetOne(&model.Member{ProjectId: project.ProjectId, UserId: userId})
+	if err == nil {
+		if m.UserStatus == constant.STATE_CLS_BLOCK {
+			return errs.NewForbiddenError()
+		}
+		if !(m.UserStatus == constant.STATE_CLS_REQUEST && isExpired(m.ExpiresAt)) {
+			return errs.NewAlreadyRegisteredError()
+		}
+	}
+
+	m = model.Member{}
+	m.ProjectId = project.ProjectId
 	m.UserId = userId
+	m.UserStatus = constant.STATE_CLS_JOIN
+	m.UserRole = constant.ROLE_CLS_VIEWER
+
+	if err = srv.memberRepository.Upsert(&m, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+// getJoinRequest request of the user to join the project. NotFoundError if there is not.
+func (srv *memberService) getJoinRequest(projectId, userId int) (model.Member, error) {
+	m, err := srv.memberRepository.GetOne(&model.Member{ProjectId: projectId, UserId: userId})
+	if err != nil || m.UserStatus != constant.STATE_CLS_JOIN {
+		return model.Member{}, errs.NewNotFoundError()
+	}
+	return m, nil
+}
+
+
+func (srv *memberService) CancelJoin(projectId, userId int) error {
+	m, err := srv.getJoinRequest(projectId, userId)
+	if err != nil {
+		return err
+	}
+
+	if err = srv.memberRepository.Delete(&m, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+// ApproveJoin make the user requesting to join a member of the role. userRole: viewer if empty.
+func (srv *memberService) ApproveJoin(projectId int, actorRole string, userId int, userRole string) error {
+	m, err := srv.getJoinRequest(projectId, userId)
+	if err != nil {
+		return err
+	}
+
+	if userRole == "" {
+		userRole = constant.ROLE_CLS_VIEWER
+	}
+	if err = roles.ValidateChange(actorRole, "", userRole); err != nil {
+		return err
+	}
+
+	m.UserStatus = constant.STATE_CLS_NOMAL
+	m.UserRole = userRole
+	if err = srv.memberRepository.Update(&m, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+func (srv *memberService) RejectJoin(projectId int, actorRole string, userId int) error {
+	m, err := srv.getJoinRequest(projectId, userId)
+	if err != nil {
+		return err
+	}
+	if !roles.Has(actorRole, constant.ROLE_CLS_ADMIN) {
+		return errs.NewForbiddenError()
+	}
+
+	if err = srv.memberRepository.Delete(&m, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+// BlockMember block the member, the invited or the requesting user.
+// blocked users cannot request to join nor be invited until removed. (DeleteMember)
+func (srv *memberService) BlockMember(projectId int, actorRole string, userId int) error {
+	m, err := srv.memberRepository.GetOne(&model.Member{ProjectId: projectId, UserId: userId})
+	if err != nil {
+		return errs.NewNotFoundError()
+	}
+
+	if !roles.Has(actorRole, constant.ROLE_CLS_ADMIN) ||
+		constant.ROLE_CLS_RANK[roles.Normalize(m.UserRole)] >= constant.ROLE_CLS_RANK[actorRole] {
+		return errs.NewForbiddenError()
+	}
+	if m.UserStatus == constant.STATE_CLS_BLOCK {
+		return nil
+	}
 
-	if err := srv.memberRepository.Delete(&m, nil); err != nil {
+	m.UserStatus = constant.STATE_CLS_BLOCK
+	m.ExpiresAt = ""
+	if err = srv.memberRepository.Update(&m, nil); err != nil {
 		logger.Error(err.Error())
 		return err
 	}
@@ -0,0 +1,474 @@
+package service
+
+import (
+	"database/sql"
+
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/shared/roles"
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/core/logger"
+	"goat-cg/internal/model"
+	"goat-cg/internal/dto"
+	"goat-cg/internal/repository"
+	"goat-cg/internal/query"
+)
+
+
+type OrganizationService interface {
+	GetOrganization(orgName string) (model.Organization, error)
+	GetOrganizations(userId int) ([]dto.Organization, error)
+	CreateOrganization(userId int, orgName, orgMemo string) error
+	GetOrgRole(orgId, userId int) (string, error)
+	GetProjects(orgId, userId int) ([]model.Project, error)
+	GetUserProjects(userId int) ([]model.Project, error)
+
+	GetMembers(orgId int) ([]dto.OrgMember, error)
+	AddMember(orgId int, name, orgRole string) error
+	UpdateMemberRole(orgId, userId int, orgRole string) error
+	DeleteMember(orgId, actorId int, actorRole string, userId int) error
+
+	GetTeams(orgId int) ([]model.Team, error)
+	GetTeam(orgId, teamId int) (model.Team, error)
+	CreateTeam(orgId int, teamName, teamMemo string) error
+	DeleteTeam(orgId, teamId int) error
+	GetTeamMembers(teamId int) ([]dto.OrgMember, error)
+	AddTeamMember(orgId, teamId, userId int) error
+	DeleteTeamMember(teamId, userId int) error
+	GetTeamProjects(teamId int) ([]dto.TeamProject, error)
+	GrantTeamProject(orgId, teamId, projectId int, userRole string) error
+	RevokeTeamProject(teamId, projectId int) error
+}
+
+
+type organizationService struct {
+	organizationRepository repository.OrganizationRepository
+	orgMemberRepository repository.OrgMemberRepository
+	teamRepository repository.TeamRepository
+	teamMemberRepository repository.TeamMemberRepository
+	teamProjectRepository repository.TeamProjectRepository
+	userRepository repository.UserRepository
+	projectRepository repository.ProjectRepository
+	organizationQuery query.OrganizationQuery
+	memberService MemberService
+}
+
+
+func NewOrganizationService() OrganizationService {
+	organizationRepository := repository.NewOrganizationRepository()
+	orgMemberRepository := repository.NewOrgMemberRepository()
+	teamRepository := repository.NewTeamRepository()
+	teamMemberRepository := repository.NewTeamMemberRepository()
+	teamProjectRepository := repository.NewTeamProjectRepository()
+	userRepository := repository.NewUserRepository()
+	projectRepository := repository.NewProjectRepository()
+	organizationQuery := query.NewOrganizationQuery()
+	memberService := NewMemberService()
+	return &organizationService{
+		organizationRepository,
+		orgMemberRepository,
+		teamRepository,
+		teamMemberRepository,
+		teamProjectRepository,
+		userRepository,
+		projectRepository,
+		organizationQuery,
+		memberService,
+	}
+}
+
+
+func (srv *organizationService) GetOrganization(orgName string) (model.Organization, error) {
+	if orgName == "" {
+		return model.Organization{}, errs.NewNotFoundError()
+	}
+
+	org, err := srv.organizationRepository.GetOne(&model.Organization{OrgName: orgName})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			logger.Error(err.Error())
+		}
+		return org, errs.NewNotFoundError()
+	}
+
+	return org, nil
+}
+
+
+// GetOrganizations organizations the user is a member of.
+func (srv *organizationService) GetOrganizations(userId int) ([]dto.Organization, error) {
+	orgs, err := srv.organizationQuery.GetUserOrganizations(userId)
+	if err != nil {
+		logger.Error(err.Error())
+		return []dto.Organization{}, err
+	}
+
+	for i := range orgs {
+		orgs[i].RoleName = constant.ORG_ROLE_CLS_NAME[orgs[i].OrgRole]
+	}
+	return orgs, nil
+}
+
+
+// CreateOrganization create the organization owned by the user.
+// organization names share the first segment of the URLs with usernames.
+func (srv *organizationService) CreateOrganization(userId int, orgName, orgMemo string) error {
+	if !isValidName(orgName) {
+		return errs.NewInvalidValueError("org_name", "1-50 letters, digits, '_', '.' or '-', not reserved.")
+	}
+	if isNameTaken(srv.userRepository, srv.organizationRepository, orgName, 0) {
+		return errs.NewUniqueConstraintError("org_name")
+	}
+
+	org := model.Organization{OrgName: orgName, OrgMemo: orgMemo}
+	if err := srv.organizationRepository.Insert(&org, nil); err != nil {
+		logger.Error(err.Error())
+		if isUniqueViolation(err) {
+			return errs.NewUniqueConstraintError("org_name")
+		}
+		return err
+	}
+
+	org, err := srv.organizationRepository.GetOne(&model.Organization{OrgName: orgName})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	m := model.OrgMember{OrgId: org.OrgId, UserId: userId, OrgRole: constant.ORG_ROLE_CLS_OWNER}
+	if err = srv.orgMemberRepository.Upsert(&m, nil); err != nil {
+		logger.Error(err.Error())
+		srv.organizationRepository.Delete(&model.Organization{OrgId: org.OrgId}, nil)
+		return err
+	}
+
+	return nil
+}
+
+
+// GetOrgRole ORG_ROLE_CLS of the user. NotFoundError if the user is not a member.
+func (srv *organizationService) GetOrgRole(orgId, userId int) (string, error) {
+	if orgId == 0 || userId == 0 {
+		return "", errs.NewNotFoundError()
+	}
+
+	m, err := srv.orgMemberRepository.GetOne(&model.OrgMember{OrgId: orgId, UserId: userId})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			logger.Error(err.Error())
+		}
+		return "", errs.NewNotFoundError()
+	}
+
+	return m.OrgRole, nil
+}
+
+
+// GetProjects projects of the organization the user has a role in.
+func (srv *organizationService) GetProjects(orgId, userId int) ([]model.Project, error) {
+	if orgId == 0 {
+		return []model.Project{}, nil
+	}
+
+	projects, err := srv.projectRepository.Get(&model.Project{OrgId: orgId})
+	if err != nil {
+		logger.Error(err.Error())
+		return []model.Project{}, err
+	}
+
+	ret := []model.Project{}
+	for _, p := range projects {
+		if _, err := srv.memberService.GetRole(p, userId); err == nil {
+			ret = append(ret, p)
+		}
+	}
+	return ret, nil
+}
+
+
+// GetUserProjects projects of the organizations of the user the user has a role in.
+func (srv *organizationService) GetUserProjects(userId int) ([]model.Project, error) {
+	orgs, err := srv.organizationQuery.GetUserOrganizations(userId)
+	if err != nil {
+		logger.Error(err.Error())
+		return []model.Project{}, err
+	}
+
+	ret := []model.Project{}
+	for _, org := range orgs {
+		projects, err := srv.GetProjects(org.OrgId, userId)
+		if err != nil {
+			return []model.Project{}, err
+		}
+		ret = append(ret, projects...)
+	}
+	return ret, nil
+}
+
+
+func (srv *organizationService) GetMembers(orgId int) ([]dto.OrgMember, error) {
+	members, err := srv.organizationQuery.GetOrgMembers(orgId)
+	if err != nil {
+		logger.Error(err.Error())
+		return []dto.OrgMember{}, err
+	}
+
+	for i := range members {
+		members[i].RoleName = constant.ORG_ROLE_CLS_NAME[members[i].OrgRole]
+	}
+	return members, nil
+}
+
+
+// AddMember add the user of the name or the email to the organization. orgRole: member if empty.
+func (srv *organizationService) AddMember(orgId int, name, orgRole string) error {
+	if orgRole == "" {
+		orgRole = constant.ORG_ROLE_CLS_MEMBER
+	}
+	if _, ok := constant.ORG_ROLE_CLS_NAME[orgRole]; !ok {
+		return errs.NewInvalidValueError("org_role", "unknown role.")
+	}
+
+	if name == "" {
+		return errs.NewInvalidValueError("username", "the user is not found.")
+	}
+
+	user, err := srv.userRepository.GetOne(&model.User{Username: name})
+	if err != nil {
+		user, err = srv.userRepository.GetOne(&model.User{Email: name})
+	}
+	if err != nil {
+		return errs.NewInvalidValueError("username", "the user is not found.")
+	}
+
+	if _, err = srv.orgMemberRepository.GetOne(&model.OrgMember{OrgId: orgId, UserId: user.UserId}); err == nil {
+		return errs.NewAlreadyRegisteredError()
+	}
+
+	m := model.OrgMember{OrgId: orgId, UserId: user.UserId, OrgRole: orgRole}
+	if err = srv.orgMemberRepository.Upsert(&m, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+// UpdateMemberRole the last owner cannot be a member.
+func (srv *organizationService) UpdateMemberRole(orgId, userId int, orgRole string) error {
+	if _, ok := constant.ORG_ROLE_CLS_NAME[orgRole]; !ok {
+		return errs.NewInvalidValueError("org_role", "unknown role.")
+	}
+
+	m, err := srv.orgMemberRepository.GetOne(&model.OrgMember{OrgId: orgId, UserId: userId})
+	if err != nil || userId == 0 {
+		return errs.NewNotFoundError()
+	}
+	if m.OrgRole == constant.ORG_ROLE_CLS_OWNER && orgRole != constant.ORG_ROLE_CLS_OWNER && srv.isLastOwner(orgId) {
+		return errs.NewInvalidValueError("org_role", "the organization needs an owner.")
+	}
+
+	m.OrgRole = orgRole
+	if err = srv.orgMemberRepository.Upsert(&m, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+// DeleteMember remove the user from the organization and its teams.
+// owners can remove members, and members can leave. the last owner cannot.
+func (srv *organizationService) DeleteMember(orgId, actorId int, actorRole string, userId int) error {
+	if actorRole != constant.ORG_ROLE_CLS_OWNER && actorId != userId {
+		return errs.NewForbiddenError()
+	}
+
+	m, err := srv.orgMemberRepository.GetOne(&model.OrgMember{OrgId: orgId, UserId: userId})
+	if err != nil || userId == 0 {
+		return errs.NewNotFoundError()
+	}
+	if m.OrgRole == constant.ORG_ROLE_CLS_OWNER && srv.isLastOwner(orgId) {
+		return errs.NewInvalidValueError("org_role", "the organization needs an owner.")
+	}
+
+	if err = srv.orgMemberRepository.Delete(&m, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+func (srv *organizationService) isLastOwner(orgId int) bool {
+	owners, err := srv.orgMemberRepository.Get(
+		&model.OrgMember{OrgId: orgId, OrgRole: constant.ORG_ROLE_CLS_OWNER},
+	)
+	if err != nil {
+		logger.Error(err.Error())
+		return true
+	}
+	return len(owners) <= 1
+}
+
+
+func (srv *organizationService) GetTeams(orgId int) ([]model.Team, error) {
+	if orgId == 0 {
+		return []model.Team{}, nil
+	}
+
+	teams, err := srv.teamRepository.Get(&model.Team{OrgId: orgId})
+	if err != nil {
+		logger.Error(err.Error())
+		return []model.Team{}, err
+	}
+	return teams, nil
+}
+
+
+// GetTeam NotFoundError if the team is not of the organization.
+func (srv *organizationService) GetTeam(orgId, teamId int) (model.Team, error) {
+	if orgId == 0 || teamId == 0 {
+		return model.Team{}, errs.NewNotFoundError()
+	}
+
+	t, err := srv.teamRepository.GetOne(&model.Team{OrgId: orgId, TeamId: teamId})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			logger.Error(err.Error())
+		}
+		return t, errs.NewNotFoundError()
+	}
+	return t, nil
+}
+
+
+func (srv *organizationService) CreateTeam(orgId int, teamName, teamMemo string) error {
+	if teamName == "" {
+		return errs.NewInvalidValueError("team_name", "required.")
+	}
+	if _, err := srv.teamRepository.GetOne(&model.Team{OrgId: orgId, TeamName: teamName}); err == nil {
+		return errs.NewUniqueConstraintError("team_name")
+	}
+
+	t := model.Team{OrgId: orgId, TeamName: teamName, TeamMemo: teamMemo}
+	if err := srv.teamRepository.Insert(&t, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+// DeleteTeam the members and the grants of the team are deleted. (trg_team_del)
+func (srv *organizationService) DeleteTeam(orgId, teamId int) error {
+	t, err := srv.GetTeam(orgId, teamId)
+	if err != nil {
+		return err
+	}
+
+	if err = srv.teamRepository.Delete(&model.Team{TeamId: t.TeamId}, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+func (srv *organizationService) GetTeamMembers(teamId int) ([]dto.OrgMember, error) {
+	members, err := srv.organizationQuery.GetTeamMembers(teamId)
+	if err != nil {
+		logger.Error(err.Error())
+		return []dto.OrgMember{}, err
+	}
+
+	for i := range members {
+		members[i].RoleName = constant.ORG_ROLE_CLS_NAME[members[i].OrgRole]
+	}
+	return members, nil
+}
+
+
+// AddTeamMember only the members of the organization can be in its teams.
+func (srv *organizationService) AddTeamMember(orgId, teamId, userId int) error {
+	if _, err := srv.GetOrgRole(orgId, userId); err != nil {
+		return errs.NewInvalidValueError("user_id", "must be a member of the organization.")
+	}
+
+	m := model.TeamMember{TeamId: teamId, UserId: userId}
+	if err := srv.teamMemberRepository.Upsert(&m, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+func (srv *organizationService) DeleteTeamMember(teamId, userId int) error {
+	if teamId == 0 || userId == 0 {
+		return errs.NewNotFoundError()
+	}
+
+	if err := srv.teamMemberRepository.Delete(&model.TeamMember{TeamId: teamId, UserId: userId}, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+func (srv *organizationService) GetTeamProjects(teamId int) ([]dto.TeamProject, error) {
+	projects, err := srv.organizationQuery.GetTeamProjects(teamId)
+	if err != nil {
+		logger.Error(err.Error())
+		return []dto.TeamProject{}, err
+	}
+
+	for i := range projects {
+		projects[i].UserRole = roles.Normalize(projects[i].UserRole)
+		projects[i].RoleName = constant.ROLE_CLS_NAME[projects[i].UserRole]
+	}
+	return projects, nil
+}
+
+
+// GrantTeamProject give the role on the project of the organization to the members of the team.
+// (viewer, editor or admin. replaces the role granted before)
+func (srv *organizationService) GrantTeamProject(orgId, teamId, projectId int, userRole string) error {
+	if _, ok := constant.ROLE_CLS_RANK[userRole]; !ok || userRole == constant.ROLE_CLS_OWNER {
+		return errs.NewInvalidValueError("user_role", "unknown role.")
+	}
+
+	p, err := srv.projectRepository.GetOne(&model.Project{ProjectId: projectId})
+	if err != nil || projectId == 0 || p.OrgId != orgId {
+		return errs.NewInvalidValueError("project_id", "must be a project of the organization.")
+	}
+
+	tp := model.TeamProject{TeamId: teamId, ProjectId: projectId, UserRole: userRole}
+	if err = srv.teamProjectRepository.Upsert(&tp, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+
+func (srv *organizationService) RevokeTeamProject(teamId, projectId int) error {
+	if teamId == 0 || projectId == 0 {
+		return errs.NewNotFoundError()
+	}
+
+	if err := srv.teamProjectRepository.Delete(&model.TeamProject{TeamId: teamId, ProjectId: projectId}, nil); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
\ No newline at end of file
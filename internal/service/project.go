@@ -1,8 +1,11 @@
 package service
 
 import (
+	"regexp"
+	"strings"
 	"database/sql"
 
+	"goat-cg/internal/shared/constant"
 	"goat-cg/internal/core/logger"
 	"goat-cg/internal/core/errs"
 	"goat-cg/internal/core/db"
@@ -14,10 +17,14 @@ import (
 
 type ProjectService interface {
 	GetProject(projectId int) (model.Project, error)
+	GetProjectByName(username, projectName string) (model.Project, error)
 	GetProjects(userId int) ([]model.Project, error)
 	GetMemberProjects(userId int) ([]model.Project, error)
 	CreateProject(userId int, username, projectName, projectMemo string) error
+	CreateOrgProject(org model.Organization, projectName, projectMemo string) error
 	UpdateProject(username string, projectId int, projectName, projectMemo string) error
+	TransferProject(projectId, userId int) (model.Project, error)
+	GetRenamedProject(username, projectName string) (model.Project, error)
 	DeleteProject(projectId int) error
 }
 
@@ -27,6 +34,25 @@ type projectService struct {
 	projectRepository repository.ProjectRepository
 	tableRepository repository.TableRepository
 	columnRepository repository.ColumnRepository
+	checkRepository repository.CheckRepository
+	classificationRepository repository.ClassificationRepository
+	classificationValueRepository repository.ClassificationValueRepository
+	viewRepository repository.ViewRepository
+	viewTableRepository repository.ViewTableRepository
+	viewColumnRepository repository.ViewColumnRepository
+	projectRedirectRepository repository.ProjectRedirectRepository
+	memberRepository repository.MemberRepository
+	userRepository repository.UserRepository
+}
+
+
+// PROJECT_NAME_PATTERN project names are the second segment of the URLs. (as project.html)
+var PROJECT_NAME_PATTERN = regexp.MustCompile(`^[0-9A-Za-z]{2,50}$`)
+
+
+// isValidProjectName the name can be the second segment of the URLs.
+func isValidProjectName(name string) bool {
+	return PROJECT_NAME_PATTERN.MatchString(name) && !reservedNames[strings.ToLower(name)]
 }
 
 
@@ -35,11 +61,29 @@ func NewProjectService() ProjectService {
 	projectRepository := repository.NewProjectRepository()
 	tableRepository := repository.NewTableRepository()
 	columnRepository := repository.NewColumnRepository()
+	checkRepository := repository.NewCheckRepository()
+	classificationRepository := repository.NewClassificationRepository()
+	classificationValueRepository := repository.NewClassificationValueRepository()
+	viewRepository := repository.NewViewRepository()
+	viewTableRepository := repository.NewViewTableRepository()
+	viewColumnRepository := repository.NewViewColumnRepository()
+	projectRedirectRepository := repository.NewProjectRedirectRepository()
+	memberRepository := repository.NewMemberRepository()
+	userRepository := repository.NewUserRepository()
 	return &projectService{
 		projectQuery, 
 		projectRepository, 
 		tableRepository, 
 		columnRepository,
+		checkRepository,
+		classificationRepository,
+		classificationValueRepository,
+		viewRepository,
+		viewTableRepository,
+		viewColumnRepository,
+		projectRedirectRepository,
+		memberRepository,
+		userRepository,
 	}
 }
 
@@ -59,6 +103,22 @@ func (srv *projectService) GetProject(projectId int) (model.Project, error) {
 }
 
 
+// GetProjectByName get the project by owner name and project name. ("owner/name" of the URL)
+func (srv *projectService) GetProjectByName(username, projectName string) (model.Project, error) {
+	project, err := srv.projectRepository.GetOne(&model.Project{Username: username, ProjectName: projectName})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logger.Debug(err.Error())
+		} else {
+			logger.Error(err.Error())
+		}
+	}
+
+	return project, err
+}
+
+
 // ログインユーザのプロジェクを取得
 func (srv *projectService) GetProjects(userId int) ([]model.Project, error) {
 	projects, err := srv.projectRepository.Get(&model.Project{UserId: userId})
@@ -88,6 +148,10 @@ func (srv *projectService) GetMemberProjects(userId int) ([]model.Project, error
 
 
 func (srv *projectService) CreateProject(userId int, username, projectName, projectMemo string) error {
+	if !isValidProjectName(projectName) {
+		return errs.NewInvalidValueError("project_name", "2-50 letters or digits, not reserved.")
+	}
+
 	_, err := srv.projectRepository.GetOne(&model.Project{Username: username, ProjectName: projectName})
 	if err == nil {
 		return errs.NewUniqueConstraintError("project_name")
@@ -107,6 +171,31 @@ func (srv *projectService) CreateProject(userId int, username, projectName, proj
 }
 
 
+// CreateOrgProject create the project owned by the organization. ("org_name/project_name")
+func (srv *projectService) CreateOrgProject(org model.Organization, projectName, projectMemo string) error {
+	if !isValidProjectName(projectName) {
+		return errs.NewInvalidValueError("project_name", "2-50 letters or digits, not reserved.")
+	}
+
+	_, err := srv.projectRepository.GetOne(&model.Project{Username: org.OrgName, ProjectName: projectName})
+	if err == nil {
+		return errs.NewUniqueConstraintError("project_name")
+	}
+
+	var p model.Project
+	p.ProjectName = projectName
+	p.ProjectMemo = projectMemo
+	p.Username = org.OrgName
+	p.OrgId = org.OrgId
+
+	if err = srv.projectRepository.Insert(&p, nil); err != nil {
+		logger.Error(err.Error())
+	}
+
+	return err
+}
+
+
 func (srv *projectService) UpdateProject(username string, projectId int, projectName, projectMemo string) error {
 	project, err := srv.projectRepository.GetOne(&model.Project{Username: username, ProjectName: projectName})
 	if err == nil && project.ProjectId != projectId {
@@ -119,9 +208,42 @@ func (srv *projectService) UpdateProject(username string, projectId int, project
 		return err
 	}
 
+	if p.ProjectName == projectName {
+		p.ProjectMemo = projectMemo
+		if err = srv.projectRepository.Update(&p, nil); err != nil {
+			logger.Error(err.Error())
+			return err
+		}
+		return nil
+	}
+
+	if !isValidProjectName(projectName) {
+		return errs.NewInvalidValueError("project_name", "2-50 letters or digits, not reserved.")
+	}
+
+	// renamed: the old URL redirects to the new one.
+	tx, err := db.GetDB().Begin()
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	redirect := model.ProjectRedirect{Username: p.Username, ProjectName: p.ProjectName, ProjectId: p.ProjectId}
+	if err = srv.projectRedirectRepository.Upsert(&redirect, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
 	p.ProjectName = projectName
 	p.ProjectMemo = projectMemo
-	if err = srv.projectRepository.Update(&p, nil); err != nil {
+	if err = srv.projectRepository.Update(&p, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
 		logger.Error(err.Error())
 		return err
 	}
@@ -130,6 +252,101 @@ func (srv *projectService) UpdateProject(username string, projectId int, project
 }
 
 
+// TransferProject make the member (userId) the owner of the project. (the URL changes to "new owner/project_name")
+// the old owner stays as an admin, and the old URL redirects to the new one.
+// projects of organizations are not transferred. (the teams granting roles belong to the organization)
+func (srv *projectService) TransferProject(projectId, userId int) (model.Project, error) {
+	p, err := srv.projectRepository.GetOne(&model.Project{ProjectId: projectId})
+	if err != nil {
+		return model.Project{}, errs.NewNotFoundError()
+	}
+	if p.OrgId != 0 {
+		return model.Project{}, errs.NewInvalidValueError("project_id", "projects of organizations cannot be transferred.")
+	}
+
+	m, err := srv.memberRepository.GetOne(&model.Member{ProjectId: projectId, UserId: userId})
+	if err != nil || m.UserStatus != constant.STATE_CLS_NOMAL {
+		return model.Project{}, errs.NewInvalidValueError("user_id", "must be a member of the project.")
+	}
+
+	user, err := srv.userRepository.GetOne(&model.User{UserId: userId})
+	if err != nil {
+		logger.Error(err.Error())
+		return model.Project{}, err
+	}
+
+	_, err = srv.projectRepository.GetOne(&model.Project{Username: user.Username, ProjectName: p.ProjectName})
+	if err == nil {
+		return model.Project{}, errs.NewUniqueConstraintError("project_name")
+	}
+
+	tx, err := db.GetDB().Begin()
+	if err != nil {
+		logger.Error(err.Error())
+		return model.Project{}, err
+	}
+
+	redirect := model.ProjectRedirect{Username: p.Username, ProjectName: p.ProjectName, ProjectId: p.ProjectId}
+	if err = srv.projectRedirectRepository.Upsert(&redirect, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return model.Project{}, err
+	}
+
+	if err = srv.memberRepository.Delete(&m, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return model.Project{}, err
+	}
+
+	var old model.Member
+	old.ProjectId = projectId
+	old.UserId = p.UserId
+	old.UserStatus = constant.STATE_CLS_NOMAL
+	old.UserRole = constant.ROLE_CLS_ADMIN
+	if err = srv.memberRepository.Upsert(&old, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return model.Project{}, err
+	}
+
+	p.UserId = user.UserId
+	p.Username = user.Username
+	if err = srv.projectRepository.Update(&p, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return model.Project{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(err.Error())
+		return model.Project{}, err
+	}
+
+	return p, nil
+}
+
+
+// GetRenamedProject get the project by its former "username/project_name". (renamed or transferred)
+func (srv *projectService) GetRenamedProject(username, projectName string) (model.Project, error) {
+	if username == "" || projectName == "" {
+		return model.Project{}, errs.NewNotFoundError()
+	}
+
+	r, err := srv.projectRedirectRepository.GetOne(
+		&model.ProjectRedirect{Username: username, ProjectName: projectName},
+	)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			logger.Error(err.Error())
+		}
+		return model.Project{}, errs.NewNotFoundError()
+	}
+
+	return srv.GetProject(r.ProjectId)
+}
+
+
 func (srv *projectService) DeleteProject(projectId int) error {
 	tables, err := srv.tableRepository.Get(&model.Table{ProjectId: projectId})
 	if err != nil {
@@ -137,6 +354,18 @@ func (srv *projectService) DeleteProject(projectId int) error {
 		return err
 	}
 
+	classifications, err := srv.classificationRepository.Get(&model.Classification{ProjectId: projectId})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	views, err := srv.viewRepository.Get(&model.View{ProjectId: projectId})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
 	tx, err := db.GetDB().Begin()
 	if err != nil {
 		logger.Error(err.Error())
@@ -161,6 +390,50 @@ func (srv *projectService) DeleteProject(projectId int) error {
 			logger.Error(err.Error())
 			return err
 		}
+
+		if err = srv.checkRepository.Delete(&model.Check{TableId: table.TableId}, tx); err != nil {
+			tx.Rollback()
+			logger.Error(err.Error())
+			return err
+		}
+	}
+
+	err = srv.classificationRepository.Delete(&model.Classification{ProjectId: projectId}, tx)
+	if err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	for _, c := range classifications {
+		err = srv.classificationValueRepository.Delete(
+			&model.ClassificationValue{ClassificationId: c.ClassificationId}, tx,
+		)
+		if err != nil {
+			tx.Rollback()
+			logger.Error(err.Error())
+			return err
+		}
+	}
+
+	if err = srv.viewRepository.Delete(&model.View{ProjectId: projectId}, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	for _, v := range views {
+		if err = srv.viewTableRepository.Delete(&model.ViewTable{ViewId: v.ViewId}, tx); err != nil {
+			tx.Rollback()
+			logger.Error(err.Error())
+			return err
+		}
+
+		if err = srv.viewColumnRepository.Delete(&model.ViewColumn{ViewId: v.ViewId}, tx); err != nil {
+			tx.Rollback()
+			logger.Error(err.Error())
+			return err
+		}
 	}
 
 	if err = tx.Commit(); err != nil {
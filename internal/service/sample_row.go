@@ -0,0 +1,557 @@
+package service
+
+import (
+	"sort"
+	"time"
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+
+	"goat-cg/internal/dto"
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/core/logger"
+	"goat-cg/internal/model"
+	"goat-cg/internal/repository"
+)
+
+
+type SampleRowService interface {
+	GetSampleRows(tableId int, staleRows bool) (dto.SampleRows, error)
+	GetSampleRowsCsv(tableId int) (string, error)
+	UpdateSampleRows(tableId int, rows []map[string]*string) error
+	UpdateSampleRowsCsv(tableId int, text string) error
+	ValidateSampleRows(tableId int) error
+}
+
+
+type sampleRowService struct {
+	sampleRowRepository repository.SampleRowRepository
+	columnRepository repository.ColumnRepository
+	checkRepository repository.CheckRepository
+	classificationValueRepository repository.ClassificationValueRepository
+}
+
+
+func NewSampleRowService() SampleRowService {
+	sampleRowRepository := repository.NewSampleRowRepository()
+	columnRepository := repository.NewColumnRepository()
+	checkRepository := repository.NewCheckRepository()
+	classificationValueRepository := repository.NewClassificationValueRepository()
+	return &sampleRowService{
+		sampleRowRepository,
+		columnRepository,
+		checkRepository,
+		classificationValueRepository,
+	}
+}
+
+
+// GetSampleRows get sample rows of the table with the values of all columns.
+// staleRows: include rows which do not match the definition.
+func (srv *sampleRowService) GetSampleRows(tableId int, staleRows bool) (dto.SampleRows, error) {
+	ret := dto.SampleRows{}
+
+	columns, err := srv.getColumns(tableId)
+	if err != nil {
+		return ret, err
+	}
+	for _, c := range columns {
+		ret.Columns = append(ret.Columns, strings.ToLower(c.ColumnName))
+	}
+
+	rows, err := srv.sampleRowRepository.Get(&model.SampleRow{TableId: tableId})
+	if err != nil {
+		logger.Error(err.Error())
+		return ret, err
+	}
+	for _, r := range rows {
+		if r.StaleFlg == constant.FLG_ON && !staleRows {
+			continue
+		}
+		data, err := decodeSampleRow(r.RowData)
+		if err != nil {
+			logger.Error(err.Error())
+			return ret, err
+		}
+		values := dto.SampleRowValues{
+			Stale: r.StaleFlg == constant.FLG_ON,
+			StaleReason: r.StaleReason,
+		}
+		for _, c := range ret.Columns {
+			if v := data[c]; v != nil {
+				values.Values = append(values.Values, *v)
+			} else {
+				values.Values = append(values.Values, "")
+			}
+		}
+		ret.Rows = append(ret.Rows, values)
+	}
+
+	return ret, nil
+}
+
+
+// GetSampleRowsCsv get sample rows of the table as CSV.
+// header is the column names set in the rows, NULL is an empty field.
+func (srv *sampleRowService) GetSampleRowsCsv(tableId int) (string, error) {
+	columns, err := srv.getColumns(tableId)
+	if err != nil {
+		return "", err
+	}
+	rows, err := srv.sampleRowRepository.Get(&model.SampleRow{TableId: tableId})
+	if err != nil {
+		logger.Error(err.Error())
+		return "", err
+	}
+	if len(rows) == 0 {
+		return "", nil
+	}
+
+	var data []map[string]*string
+	for _, r := range rows {
+		d, err := decodeSampleRow(r.RowData)
+		if err != nil {
+			logger.Error(err.Error())
+			return "", err
+		}
+		data = append(data, d)
+	}
+
+	var names []string
+	for _, c := range columns {
+		n := strings.ToLower(c.ColumnName)
+		for _, d := range data {
+			if _, ok := d[n]; ok {
+				names = append(names, n)
+				break
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(names)
+	for _, d := range data {
+		var record []string
+		for _, n := range names {
+			if v := d[n]; v != nil {
+				record = append(record, *v)
+			} else {
+				record = append(record, "")
+			}
+		}
+		w.Write(record)
+	}
+	w.Flush()
+
+	return buf.String(), w.Error()
+}
+
+
+// UpdateSampleRows replace sample rows of the table.
+// keys of the rows must be column names of the table. (columns not in a row are generated in seeds)
+// rows which do not match the definition are saved as stale.
+func (srv *sampleRowService) UpdateSampleRows(tableId int, rows []map[string]*string) error {
+	columns, err := srv.getColumns(tableId)
+	if err != nil {
+		return err
+	}
+	names := map[string]bool{}
+	for _, c := range columns {
+		names[strings.ToLower(c.ColumnName)] = true
+	}
+	for _, r := range rows {
+		for k := range r {
+			if !names[k] {
+				return errs.NewInvalidValueError("sample_rows", "unknown column: " + k)
+			}
+		}
+	}
+
+	reasons, err := srv.validateRows(tableId, rows)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.GetDB().Begin()
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	if err = srv.sampleRowRepository.DeleteByTableId(tableId, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	for i, r := range rows {
+		b, _ := json.Marshal(r)
+		sr := model.SampleRow{TableId: tableId, RowNo: i + 1, RowData: string(b), StaleReason: reasons[i]}
+		if reasons[i] != "" {
+			sr.StaleFlg = constant.FLG_ON
+		}
+		if err = srv.sampleRowRepository.Insert(&sr, tx); err != nil {
+			tx.Rollback()
+			logger.Error(err.Error())
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+
+// UpdateSampleRowsCsv replace sample rows of the table with the CSV.
+// the header must be column names of the table. (columns not in the header are generated)
+func (srv *sampleRowService) UpdateSampleRowsCsv(tableId int, text string) error {
+	var records [][]string
+	if strings.TrimSpace(text) != "" {
+		var err error
+		if records, err = csv.NewReader(strings.NewReader(text)).ReadAll(); err != nil {
+			return errs.NewInvalidValueError("sample_rows", err.Error())
+		}
+	}
+
+	var header []string
+	if len(records) > 0 {
+		header = records[0]
+		records = records[1:]
+	}
+	for i, h := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(h))
+	}
+
+	var rows []map[string]*string
+	for _, record := range records {
+		data := map[string]*string{}
+		for j, h := range header {
+			if record[j] != "" {
+				v := record[j]
+				data[h] = &v
+			} else {
+				data[h] = nil
+			}
+		}
+		rows = append(rows, data)
+	}
+
+	return srv.UpdateSampleRows(tableId, rows)
+}
+
+
+// ValidateSampleRows validate sample rows of the table with the current definition
+// and update the stale flags. (called when columns or checks are changed)
+func (srv *sampleRowService) ValidateSampleRows(tableId int) error {
+	rows, err := srv.sampleRowRepository.Get(&model.SampleRow{TableId: tableId})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var data []map[string]*string
+	for _, r := range rows {
+		d, err := decodeSampleRow(r.RowData)
+		if err != nil {
+			logger.Error(err.Error())
+			return err
+		}
+		data = append(data, d)
+	}
+
+	reasons, err := srv.validateRows(tableId, data)
+	if err != nil {
+		return err
+	}
+
+	for i, r := range rows {
+		staleFlg := 0
+		if reasons[i] != "" {
+			staleFlg = constant.FLG_ON
+		}
+		if r.StaleFlg == staleFlg && r.StaleReason == reasons[i] {
+			continue
+		}
+		r.StaleFlg = staleFlg
+		r.StaleReason = reasons[i]
+		if err = srv.sampleRowRepository.UpdateStale(&r, nil); err != nil {
+			logger.Error(err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+
+// validateRows return the reasons why each row does not match the definition. ("" if valid)
+// types, precision, NOT NULL, UNIQUE, classification, ENUM, and RANGE, PATTERN and IN checks are validated.
+func (srv *sampleRowService) validateRows(tableId int, rows []map[string]*string) ([]string, error) {
+	columns, err := srv.getColumns(tableId)
+	if err != nil {
+		return nil, err
+	}
+	checks, err := srv.checkRepository.Get(&model.Check{TableId: tableId})
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	pks := 0
+	for _, c := range columns {
+		names[strings.ToLower(c.ColumnName)] = true
+		if c.PrimaryKeyFlg == constant.FLG_ON {
+			pks++
+		}
+	}
+
+	ret := make([]string, len(rows))
+	used := map[int]map[string]bool{}
+	for i, r := range rows {
+		var reasons []string
+
+		var unknown []string
+		for k := range r {
+			if !names[k] {
+				unknown = append(unknown, k)
+			}
+		}
+		sort.Strings(unknown)
+		for _, k := range unknown {
+			reasons = append(reasons, k + ": unknown column")
+		}
+
+		for _, c := range columns {
+			name := strings.ToLower(c.ColumnName)
+			v, ok := r[name]
+			if !ok {
+				continue
+			}
+			if v == nil {
+				if c.NotNullFlg == constant.FLG_ON || c.PrimaryKeyFlg == constant.FLG_ON {
+					reasons = append(reasons, name + ": NOT NULL")
+				}
+				continue
+			}
+			if msg := srv.validateValue(c, *v, checks); msg != "" {
+				reasons = append(reasons, name + ": " + msg)
+			}
+			if c.UniqueFlg == constant.FLG_ON || (pks == 1 && c.PrimaryKeyFlg == constant.FLG_ON) {
+				if used[c.ColumnId] == nil {
+					used[c.ColumnId] = map[string]bool{}
+				}
+				if used[c.ColumnId][*v] {
+					reasons = append(reasons, name + ": duplicate value")
+				}
+				used[c.ColumnId][*v] = true
+			}
+		}
+
+		ret[i] = strings.Join(reasons, "; ")
+	}
+
+	return ret, nil
+}
+
+
+var (
+	sampleNumericPattern = regexp.MustCompile(`^[+-]?(\d*)(?:\.(\d*))?$`)
+	sampleUuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// sampleTimeLayouts accepted formats of date and time values.
+var sampleTimeLayouts = map[string][]string{
+	constant.DATA_TYPE_CLS_DATE: {"2006-01-02"},
+	constant.DATA_TYPE_CLS_TIME: {"15:04:05", "15:04"},
+	constant.DATA_TYPE_CLS_TIMESTAMP: {"2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02 15:04"},
+	constant.DATA_TYPE_CLS_TIMESTAMPTZ: {
+		"2006-01-02 15:04:05Z07:00", time.RFC3339, "2006-01-02 15:04:05-07", "2006-01-02 15:04:05",
+	},
+}
+
+
+// validateValue return the reason why the value is not valid for the column. ("" if valid)
+func (srv *sampleRowService) validateValue(column model.Column, value string, checks []model.Check) string {
+	typeName := dataTypeName(column.DataTypeCls)
+
+	switch column.DataTypeCls {
+	case constant.DATA_TYPE_CLS_SERIAL, constant.DATA_TYPE_CLS_INTEGER:
+		if _, err := strconv.ParseInt(value, 10, 32); err != nil {
+			return "invalid " + typeName
+		}
+	case constant.DATA_TYPE_CLS_BIGSERIAL, constant.DATA_TYPE_CLS_BIGINT:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return "invalid " + typeName
+		}
+	case constant.DATA_TYPE_CLS_SMALLINT:
+		if _, err := strconv.ParseInt(value, 10, 16); err != nil {
+			return "invalid " + typeName
+		}
+	case constant.DATA_TYPE_CLS_NUMERIC:
+		m := sampleNumericPattern.FindStringSubmatch(value)
+		if m == nil || m[1] + m[2] == "" {
+			return "invalid " + typeName
+		}
+		if column.Precision > 0 &&
+			(len(strings.TrimLeft(m[1], "0")) > column.Precision - column.Scale || len(m[2]) > column.Scale) {
+			return "exceeds NUMERIC(" + strconv.Itoa(column.Precision) + ", " + strconv.Itoa(column.Scale) + ")"
+		}
+	case constant.DATA_TYPE_CLS_REAL, constant.DATA_TYPE_CLS_DOUBLE:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "invalid " + typeName
+		}
+	case constant.DATA_TYPE_CLS_BOOLEAN:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return "invalid " + typeName
+		}
+	case constant.DATA_TYPE_CLS_UUID:
+		if !sampleUuidPattern.MatchString(value) {
+			return "invalid " + typeName
+		}
+	case constant.DATA_TYPE_CLS_DATE, constant.DATA_TYPE_CLS_TIME,
+		constant.DATA_TYPE_CLS_TIMESTAMP, constant.DATA_TYPE_CLS_TIMESTAMPTZ:
+		valid := false
+		for _, layout := range sampleTimeLayouts[column.DataTypeCls] {
+			if _, err := time.Parse(layout, value); err == nil {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return "invalid " + typeName
+		}
+	case constant.DATA_TYPE_CLS_BLOB:
+		if _, err := hex.DecodeString(value); err != nil {
+			return "invalid " + typeName + " (hex)"
+		}
+	case constant.DATA_TYPE_CLS_JSON, constant.DATA_TYPE_CLS_JSONB:
+		if !json.Valid([]byte(value)) {
+			return "invalid " + typeName
+		}
+	case constant.DATA_TYPE_CLS_VARCHAR, constant.DATA_TYPE_CLS_CHAR:
+		if column.Precision > 0 && len([]rune(value)) > column.Precision {
+			return "longer than " + strconv.Itoa(column.Precision)
+		}
+	}
+
+	if values := srv.getEnumValues(column); len(values) > 0 && !containsString(values, value) {
+		return "not in (" + strings.Join(values, ", ") + ")"
+	}
+
+	for _, ck := range checks {
+		if ck.ColumnId != column.ColumnId {
+			continue
+		}
+		switch ck.CheckTypeCls {
+		case constant.CHECK_TYPE_CLS_IN:
+			if values := splitEnumValues(ck.AllowedValues); !containsString(values, value) {
+				return ck.CheckName + ": not in (" + strings.Join(values, ", ") + ")"
+			}
+		case constant.CHECK_TYPE_CLS_PATTERN:
+			if re, err := regexp.Compile(ck.Pattern); err == nil && !re.MatchString(value) {
+				return ck.CheckName + ": does not match " + ck.Pattern
+			}
+		case constant.CHECK_TYPE_CLS_RANGE:
+			if !inSampleRange(column, value, ck.MinValue, ck.MaxValue) {
+				return ck.CheckName + ": out of range"
+			}
+		}
+	}
+
+	return ""
+}
+
+
+// inSampleRange value is between min and max. (numbers are compared as numbers, others as strings)
+func inSampleRange(column model.Column, value, min, max string) bool {
+	if isNumericType(column.DataTypeCls) || isSerialType(column.DataTypeCls) {
+		x, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return true
+		}
+		if y, err := strconv.ParseFloat(min, 64); err == nil && x < y {
+			return false
+		}
+		if y, err := strconv.ParseFloat(max, 64); err == nil && x > y {
+			return false
+		}
+		return true
+	}
+	return (min == "" || value >= min) && (max == "" || value <= max)
+}
+
+
+// getEnumValues codes of the bound classification or EnumValues of ENUM.
+func (srv *sampleRowService) getEnumValues(column model.Column) []string {
+	if column.ClassificationId == 0 {
+		if column.DataTypeCls == constant.DATA_TYPE_CLS_ENUM {
+			return splitEnumValues(column.EnumValues)
+		}
+		return nil
+	}
+
+	values, err := srv.classificationValueRepository.Get(
+		&model.ClassificationValue{ClassificationId: column.ClassificationId},
+	)
+	if err != nil {
+		logger.Error(err.Error())
+	}
+	var ret []string
+	for _, v := range values {
+		ret = append(ret, v.Code)
+	}
+	return ret
+}
+
+
+// getColumns columns not deleted. (in the order of AlignSeq)
+func (srv *sampleRowService) getColumns(tableId int) ([]model.Column, error) {
+	columns, err := srv.columnRepository.Get(&model.Column{TableId: tableId})
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	sort.SliceStable(columns, func(i, j int) bool {
+		return columns[i].AlignSeq < columns[j].AlignSeq
+	})
+
+	var ret []model.Column
+	for _, c := range columns {
+		if c.DelFlg != constant.FLG_ON {
+			ret = append(ret, c)
+		}
+	}
+	return ret, nil
+}
+
+
+// decodeSampleRow RowData -> map of column name and value. (nil is NULL)
+func decodeSampleRow(rowData string) (map[string]*string, error) {
+	ret := map[string]*string{}
+	err := json.Unmarshal([]byte(rowData), &ret)
+	return ret, err
+}
+
+
+func containsString(ls []string, s string) bool {
+	for _, x := range ls {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
\ No newline at end of file
@@ -16,8 +16,8 @@ import (
 type TableService interface {
 	GetTables(projectId int) ([]model.Table, error)
 	GetTable(tableId int) (model.Table, error)
-	CreateTable(projectId, userId int, tableName, tableNameLogical string) error
-	UpdateTable(projectId, tableId, userId int, tableName, tableNameLogical string, delFlg int) error
+	CreateTable(projectId, userId int, tableName, tableNameLogical string, versionFlg int) error
+	UpdateTable(projectId, tableId, userId int, tableName, tableNameLogical string, versionFlg, delFlg int) error
 	DeleteTable(tableId int) error 
 	GetTableLog(tableId int) ([]dto.TableLog, error)
 }
@@ -26,6 +26,8 @@ type TableService interface {
 type tableService struct {
 	tableRepository repository.TableRepository
 	columnRepository repository.ColumnRepository
+	checkRepository repository.CheckRepository
+	viewTableRepository repository.ViewTableRepository
 	tableQuery query.TableQuery
 }
 
@@ -33,9 +35,17 @@ type tableService struct {
 func NewTableService() TableService {
 	tableRepository := repository.NewTableRepository()
 	columnRepository := repository.NewColumnRepository()
+	checkRepository := repository.NewCheckRepository()
+	viewTableRepository := repository.NewViewTableRepository()
 	tableQuery := query.NewTableQuery()
 
-	return &tableService{tableRepository, columnRepository, tableQuery}
+	return &tableService{
+		tableRepository, 
+		columnRepository, 
+		checkRepository, 
+		viewTableRepository, 
+		tableQuery,
+	}
 }
 
 
@@ -67,7 +77,7 @@ func (srv *tableService) GetTable(tableId int) (model.Table, error) {
 }
 
 // CreateTable create new Table.
-func (srv *tableService) CreateTable(projectId, userId int, tableName, tableNameLogical string) error {
+func (srv *tableService) CreateTable(projectId, userId int, tableName, tableNameLogical string, versionFlg int) error {
 	_, err := srv.tableRepository.GetOne(&model.Table{TableName: tableName, ProjectId: projectId})
 	if err == nil {
 		return errs.NewUniqueConstraintError("table_name")
@@ -77,6 +87,7 @@ func (srv *tableService) CreateTable(projectId, userId int, tableName, tableName
 	t.ProjectId = projectId
 	t.TableName = tableName
 	t.TableNameLogical = tableNameLogical
+	t.VersionFlg = versionFlg
 	t.DelFlg = 0
 	t.CreateUserId = userId
 	t.UpdateUserId = userId
@@ -91,7 +102,7 @@ func (srv *tableService) CreateTable(projectId, userId int, tableName, tableName
 
 // UpdateTable update Table by tableId.
 // contains logical delete. 
-func (srv *tableService) UpdateTable(projectId, tableId, userId int, tableName, tableNameLogical string, delFlg int) error {
+func (srv *tableService) UpdateTable(projectId, tableId, userId int, tableName, tableNameLogical string, versionFlg, delFlg int) error {
 	table, err := srv.tableRepository.GetOne(&model.Table{TableName: tableName, ProjectId: projectId})
 	if err == nil && table.TableId != tableId {
 		return errs.NewUniqueConstraintError("table_name")
@@ -106,6 +117,7 @@ func (srv *tableService) UpdateTable(projectId, tableId, userId int, tableName,
 	t.TableId = tableId
 	t.TableName = tableName
 	t.TableNameLogical = tableNameLogical
+	t.VersionFlg = versionFlg
 	t.UpdateUserId = userId
 	t.DelFlg = delFlg
 
@@ -121,6 +133,12 @@ func (srv *tableService) UpdateTable(projectId, tableId, userId int, tableName,
 // DeleteTable delete Table by tableId.
 // (physical delete)
 func (srv *tableService) DeleteTable(tableId int) error {
+	columns, err := srv.columnRepository.Get(&model.Column{TableId: tableId})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
 	tx, err := db.GetDB().Begin()
 	if err != nil {
 		tx.Rollback()
@@ -128,6 +146,14 @@ func (srv *tableService) DeleteTable(tableId int) error {
 		return err
 	}
 
+	for _, c := range columns {
+		if err = clearColumnReferences(srv.columnRepository, c.ColumnId, tx); err != nil {
+			tx.Rollback()
+			logger.Error(err.Error())
+			return err
+		}
+	}
+
 	if err = srv.tableRepository.Delete(&model.Table{TableId: tableId}, tx); err != nil {
 		tx.Rollback()
 		logger.Error(err.Error())
@@ -140,6 +166,18 @@ func (srv *tableService) DeleteTable(tableId int) error {
 		return err
 	}
 
+	if err = srv.checkRepository.Delete(&model.Check{TableId: tableId}, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	if err = srv.viewTableRepository.Delete(&model.ViewTable{TableId: tableId}, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return err
 	}
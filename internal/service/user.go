@@ -1,10 +1,14 @@
 package service
 
 import (
+	"regexp"
+	"strings"
 	"database/sql"
 	"golang.org/x/crypto/bcrypt"
 
+	"goat-cg/internal/shared/constant"
 	"goat-cg/internal/core/jwt"
+	"goat-cg/internal/core/db"
 	"goat-cg/internal/core/logger"
 	"goat-cg/internal/core/errs"
 	"goat-cg/internal/model"
@@ -18,6 +22,8 @@ type UserService interface {
 	Login(name, password string) (dto.User, error)
 	GenerateJWT(id int) (string, error)
 	GetProfile(id int) (dto.User, error)
+	GetUserByName(name string) (dto.User, error)
+	GetUsers() ([]dto.User, error)
 	UpdateName(id int, name string) error
 	UpdateEmail(id int, email string) error
 	UpdatePassword(id int, password string) error
@@ -27,19 +33,64 @@ type UserService interface {
 
 type userService struct {
 	userRepository repository.UserRepository
+	memberRepository repository.MemberRepository
+	invitationRepository repository.InvitationRepository
+	projectRepository repository.ProjectRepository
+	projectRedirectRepository repository.ProjectRedirectRepository
+	organizationRepository repository.OrganizationRepository
 }
 
 func NewUserService() UserService {
 	return &userService{
 		userRepository: repository.NewUserRepository(),
+		memberRepository: repository.NewMemberRepository(),
+		invitationRepository: repository.NewInvitationRepository(),
+		projectRepository: repository.NewProjectRepository(),
+		projectRedirectRepository: repository.NewProjectRedirectRepository(),
+		organizationRepository: repository.NewOrganizationRepository(),
 	}
 }
 
 
+// USERNAME_PATTERN usernames are the first segment of the URLs of their projects.
+var USERNAME_PATTERN = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,49}$`)
+
+// reservedNames first segments of the URLs not of users, and second segments not of projects.
+var reservedNames = map[string]bool{
+	"signup": true, "login": true, "logout": true, "api": true,
+	"css": true, "js": true, "tmp": true, "favicon.ico": true,
+	"-": true, "projects": true, "orgs": true, "account": true, "invitations": true,
+}
+
+
+// isValidName the name can be the first segment of the URLs. (usernames and organization names)
+func isValidName(name string) bool {
+	return USERNAME_PATTERN.MatchString(name) && !reservedNames[strings.ToLower(name)]
+}
+
+
+// isNameTaken the name is the username of another user than userId, or the name of an organization.
+// (they share the first segment of the URLs. the triggers of users and organization enforce it too)
+func isNameTaken(ur repository.UserRepository, or repository.OrganizationRepository, name string, userId int) bool {
+	if u, err := ur.GetOne(&model.User{Username: name}); err == nil && u.UserId != userId {
+		return true
+	}
+	_, err := or.GetOne(&model.Organization{OrgName: name})
+	return err == nil
+}
+
+
+// isUniqueViolation the error of the UNIQUE constraints or the triggers of the names.
+func isUniqueViolation(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+
 func (srv *userService) toUserDTO(user model.User) dto.User {
 	return dto.User{
 		UserId:    user.UserId,
 		Username:  user.Username,
+		Email:     user.Email,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
@@ -47,11 +98,14 @@ func (srv *userService) toUserDTO(user model.User) dto.User {
 
 
 func (srv *userService) Signup(name, password, email string) error {
-	_, err := srv.userRepository.GetOne(&model.User{Username: name})
-	if err == nil {
+	if !isValidName(name) {
+		return errs.NewInvalidValueError("username", "1-50 letters, digits, '_', '.' or '-', not reserved.")
+	}
+
+	if isNameTaken(srv.userRepository, srv.organizationRepository, name, 0) {
 		return errs.NewUniqueConstraintError("username")
 	}
-	_, err = srv.userRepository.GetOne(&model.User{Email: email})
+	_, err := srv.userRepository.GetOne(&model.User{Email: email})
 	if err == nil {
 		return errs.NewUniqueConstraintError("email")
 	}
@@ -71,9 +125,57 @@ func (srv *userService) Signup(name, password, email string) error {
 	err = srv.userRepository.Insert(&user, nil);
 	if err != nil {
 		logger.Error(err.Error())
+		if isUniqueViolation(err) && strings.Contains(err.Error(), "users.email") {
+			return errs.NewUniqueConstraintError("email")
+		}
+		if isUniqueViolation(err) {
+			return errs.NewUniqueConstraintError("username")
+		}
+		return err
 	}
 
-	return err
+	srv.claimInvitations(name, email)
+	return nil
+}
+
+
+// claimInvitations turn the invitations to the email into pending invitations to the new user.
+// (failures do not fail the signup)
+func (srv *userService) claimInvitations(name, email string) {
+	invitations, err := srv.invitationRepository.Get(&model.Invitation{Email: email})
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+	if len(invitations) == 0 {
+		return
+	}
+
+	user, err := srv.userRepository.GetOne(&model.User{Username: name})
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	for _, i := range invitations {
+		if !isExpired(i.ExpiresAt) {
+			var m model.Member
+			m.ProjectId = i.ProjectId
+			m.UserId = user.UserId
+			m.UserStatus = constant.STATE_CLS_REQUEST
+			m.UserRole = i.UserRole
+			m.InvitedBy = i.InvitedBy
+			m.ExpiresAt = i.ExpiresAt
+
+			if err = srv.memberRepository.Upsert(&m, nil); err != nil {
+				logger.Error(err.Error())
+				continue
+			}
+		}
+		if err = srv.invitationRepository.Delete(&model.Invitation{InvitationId: i.InvitationId}, nil); err != nil {
+			logger.Error(err.Error())
+		}
+	}
 }
 
 
@@ -111,6 +213,41 @@ func (srv *userService) GetProfile(id int) (dto.User, error) {
 }
 
 
+// GetUserByName get the user by username. (administration from CLI)
+func (srv *userService) GetUserByName(name string) (dto.User, error) {
+	if name == "" {
+		return dto.User{}, errs.NewNotFoundError()
+	}
+
+	user, err := srv.userRepository.GetOne(&model.User{Username: name})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logger.Debug(err.Error())
+		} else {
+			logger.Error(err.Error())
+		}
+	}
+
+	return srv.toUserDTO(user), err
+}
+
+
+// GetUsers get all users. (administration from CLI)
+func (srv *userService) GetUsers() ([]dto.User, error) {
+	users, err := srv.userRepository.Get(&model.User{})
+	if err != nil {
+		logger.Error(err.Error())
+		return []dto.User{}, err
+	}
+
+	ret := []dto.User{}
+	for _, u := range users {
+		ret = append(ret, srv.toUserDTO(u))
+	}
+	return ret, nil
+}
+
+
 func (srv *userService) GenerateJWT(id int) (string, error) {
 	user, err := srv.userRepository.GetOne(&model.User{UserId: id})
 	if err != nil {
@@ -133,10 +270,15 @@ func (srv *userService) GenerateJWT(id int) (string, error) {
 }
 
 
+// UpdateName rename the user. the projects of the user move to "name/project_name",
+// and their old URLs redirect to the new ones.
 func (srv *userService) UpdateName(id int, name string) error {
-	u, err := srv.userRepository.GetOne(&model.User{Username: name})
-	if err == nil && u.UserId != id{
-		return errs.NewUniqueConstraintError("user_name")
+	if !isValidName(name) {
+		return errs.NewInvalidValueError("username", "1-50 letters, digits, '_', '.' or '-', not reserved.")
+	}
+
+	if isNameTaken(srv.userRepository, srv.organizationRepository, name, id) {
+		return errs.NewUniqueConstraintError("username")
 	}
 
 	user, err := srv.userRepository.GetOne(&model.User{UserId: id})
@@ -144,9 +286,48 @@ func (srv *userService) UpdateName(id int, name string) error {
 		logger.Error(err.Error())
 		return err
 	}
+	if user.Username == name {
+		return nil
+	}
+
+	projects, err := srv.projectRepository.Get(&model.Project{UserId: id})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	tx, err := db.GetDB().Begin()
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	for _, p := range projects {
+		redirect := model.ProjectRedirect{Username: user.Username, ProjectName: p.ProjectName, ProjectId: p.ProjectId}
+		if err = srv.projectRedirectRepository.Upsert(&redirect, tx); err != nil {
+			tx.Rollback()
+			logger.Error(err.Error())
+			return err
+		}
+	}
+
+	if err = srv.projectRepository.UpdateUsername(id, name, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
 
 	user.Username = name
-	if err = srv.userRepository.Update(&user, nil); err != nil {
+	if err = srv.userRepository.Update(&user, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		if isUniqueViolation(err) {
+			return errs.NewUniqueConstraintError("username")
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
 		logger.Error(err.Error())
 		return err
 	}
@@ -0,0 +1,436 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+	"strconv"
+	"database/sql"
+
+	"goat-cg/internal/dto"
+	"goat-cg/internal/core/db"
+	"goat-cg/internal/core/logger"
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/model"
+	"goat-cg/internal/repository"
+	"goat-cg/internal/query"
+)
+
+
+type ViewService interface {
+	GetView(viewId int) (model.View, error)
+	GetViews(projectId int) ([]model.View, error)
+	GetViewTables(viewId int) ([]model.ViewTable, error)
+	GetViewColumns(viewId int) ([]model.ViewColumn, error)
+	CreateView(in dto.CreateView) error
+	UpdateView(in dto.CreateView) error
+	DeleteView(viewId int) error
+	GetViewLog(viewId int) ([]dto.ViewLog, error)
+}
+
+
+type viewService struct {
+	viewRepository repository.ViewRepository
+	viewTableRepository repository.ViewTableRepository
+	viewColumnRepository repository.ViewColumnRepository
+	tableRepository repository.TableRepository
+	columnRepository repository.ColumnRepository
+	viewQuery query.ViewQuery
+}
+
+
+func NewViewService() ViewService {
+	viewRepository := repository.NewViewRepository()
+	viewTableRepository := repository.NewViewTableRepository()
+	viewColumnRepository := repository.NewViewColumnRepository()
+	tableRepository := repository.NewTableRepository()
+	columnRepository := repository.NewColumnRepository()
+	viewQuery := query.NewViewQuery()
+	return &viewService{
+		viewRepository,
+		viewTableRepository,
+		viewColumnRepository,
+		tableRepository,
+		columnRepository,
+		viewQuery,
+	}
+}
+
+
+// GetView get View record by viewId.
+func (srv *viewService) GetView(viewId int) (model.View, error) {
+	view, err := srv.viewRepository.GetOne(&model.View{ViewId: viewId})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logger.Debug(err.Error())
+		} else {
+			logger.Error(err.Error())
+		}
+	}
+
+	return view, err
+}
+
+
+// GetViews get View records by projectId.
+func (srv *viewService) GetViews(projectId int) ([]model.View, error) {
+	views, err := srv.viewRepository.Get(&model.View{ProjectId: projectId})
+
+	if err != nil {
+		logger.Error(err.Error())
+	}
+
+	return views, err
+}
+
+
+// GetViewTables get dependent tables of the view.
+func (srv *viewService) GetViewTables(viewId int) ([]model.ViewTable, error) {
+	viewTables, err := srv.viewTableRepository.Get(&model.ViewTable{ViewId: viewId})
+
+	if err != nil {
+		logger.Error(err.Error())
+	}
+
+	return viewTables, err
+}
+
+
+// GetViewColumns get result columns of the view.
+func (srv *viewService) GetViewColumns(viewId int) ([]model.ViewColumn, error) {
+	viewColumns, err := srv.viewColumnRepository.Get(&model.ViewColumn{ViewId: viewId})
+
+	if err != nil {
+		logger.Error(err.Error())
+	}
+
+	return viewColumns, err
+}
+
+
+// CreateView create new View record with dependent tables and result columns.
+// result columns are inferred from the SELECT list when not declared.
+func (srv *viewService) CreateView(sin dto.CreateView) error {
+	_, err := srv.viewRepository.GetOne(&model.View{ViewName: sin.ViewName, ProjectId: sin.ProjectId})
+	if err == nil {
+		return errs.NewUniqueConstraintError("view_name")
+	}
+
+	columns, err := srv.getViewColumns(sin)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.GetDB().Begin()
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	view := sin.ToView()
+	if err = srv.viewRepository.Insert(&view, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	if err = srv.insertViewDetails(view.ViewId, sin.TableIds, columns, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	return tx.Commit()
+}
+
+
+// UpdateView update View record and replace dependent tables and result columns.
+func (srv *viewService) UpdateView(sin dto.CreateView) error {
+	v, err := srv.viewRepository.GetOne(&model.View{ViewName: sin.ViewName, ProjectId: sin.ProjectId})
+	if err == nil && v.ViewId != sin.ViewId {
+		return errs.NewUniqueConstraintError("view_name")
+	}
+
+	columns, err := srv.getViewColumns(sin)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.GetDB().Begin()
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	view := sin.ToView()
+	if err = srv.viewRepository.Update(&view, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	if err = srv.deleteViewDetails(sin.ViewId, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	if err = srv.insertViewDetails(sin.ViewId, sin.TableIds, columns, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	return tx.Commit()
+}
+
+
+// DeleteView delete View record with dependent tables and result columns.
+// (physical delete)
+func (srv *viewService) DeleteView(viewId int) error {
+	tx, err := db.GetDB().Begin()
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	if err = srv.viewRepository.Delete(&model.View{ViewId: viewId}, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	if err = srv.deleteViewDetails(viewId, tx); err != nil {
+		tx.Rollback()
+		logger.Error(err.Error())
+		return err
+	}
+
+	return tx.Commit()
+}
+
+
+// GetViewLog get View chenge log.
+func (srv *viewService) GetViewLog(viewId int) ([]dto.ViewLog, error) {
+	viewLog, err := srv.viewQuery.GetViewLog(viewId)
+
+	if err != nil {
+		logger.Error(err.Error())
+	}
+
+	return viewLog, err
+}
+
+
+func (srv *viewService) insertViewDetails(
+	viewId int, tableIds []int, columns []model.ViewColumn, tx *sql.Tx,
+) error {
+	for _, tid := range tableIds {
+		if err := srv.viewTableRepository.Insert(&model.ViewTable{ViewId: viewId, TableId: tid}, tx); err != nil {
+			return err
+		}
+	}
+	for _, c := range columns {
+		c.ViewId = viewId
+		if err := srv.viewColumnRepository.Insert(&c, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+
+func (srv *viewService) deleteViewDetails(viewId int, tx *sql.Tx) error {
+	if err := srv.viewTableRepository.Delete(&model.ViewTable{ViewId: viewId}, tx); err != nil {
+		return err
+	}
+	return srv.viewColumnRepository.Delete(&model.ViewColumn{ViewId: viewId}, tx)
+}
+
+
+// getViewColumns validate dependent tables and return declared or inferred columns.
+func (srv *viewService) getViewColumns(sin dto.CreateView) ([]model.ViewColumn, error) {
+	var columns []model.Column
+	for _, tid := range sin.TableIds {
+		table, err := srv.tableRepository.GetOne(&model.Table{TableId: tid})
+		if err != nil || table.ProjectId != sin.ProjectId {
+			return nil, errs.NewInvalidValueError("table_id", "table not found.")
+		}
+
+		cols, err := srv.columnRepository.Get(&model.Column{TableId: tid})
+		if err != nil {
+			logger.Error(err.Error())
+			return nil, err
+		}
+		for _, c := range cols {
+			if c.DelFlg != constant.FLG_ON {
+				columns = append(columns, c)
+			}
+		}
+		if table.VersionFlg == constant.FLG_ON {
+			columns = append(columns, model.Column{ColumnName: "version", DataTypeCls: constant.DATA_TYPE_CLS_INTEGER})
+		}
+	}
+	columns = append(columns, 
+		model.Column{ColumnName: "created_at", DataTypeCls: constant.DATA_TYPE_CLS_TIMESTAMP},
+		model.Column{ColumnName: "updated_at", DataTypeCls: constant.DATA_TYPE_CLS_TIMESTAMP},
+	)
+
+	if strings.TrimSpace(sin.Columns) == "" {
+		ret := inferViewColumns(sin.ViewSql, columns)
+		if len(ret) == 0 {
+			return nil, errs.NewInvalidValueError("columns", "columns can not be inferred.")
+		}
+		return ret, nil
+	}
+
+	return parseViewColumns(sin.Columns)
+}
+
+
+var viewColumnNamePattern = regexp.MustCompile("^[a-z0-9_]+$")
+
+// parseViewColumns "name TYPE" per line -> []model.ViewColumn
+func parseViewColumns(text string) ([]model.ViewColumn, error) {
+	var ret []model.ViewColumn
+	names := map[string]bool{}
+
+	for _, line := range strings.Split(text, "\n") {
+		fs := strings.Fields(line)
+		if len(fs) == 0 {
+			continue
+		}
+		if len(fs) != 2 || !viewColumnNamePattern.MatchString(fs[0]) {
+			return nil, errs.NewInvalidValueError("columns", "invalid line: " + strings.TrimSpace(line))
+		}
+		cls, ok := constant.DATA_TYPE_CLS_BY_NAME[strings.ToUpper(fs[1])]
+		if !ok {
+			return nil, errs.NewInvalidValueError("columns", "unknown type: " + fs[1])
+		}
+		if names[fs[0]] {
+			return nil, errs.NewInvalidValueError("columns", "duplicated: " + fs[0])
+		}
+		names[fs[0]] = true
+		ret = append(ret, model.ViewColumn{ColumnName: fs[0], DataTypeCls: cls, AlignSeq: len(ret) + 1})
+	}
+
+	return ret, nil
+}
+
+
+var (
+	viewAliasPattern = regexp.MustCompile(`(?is)^(.*?[^\s+\-*/%|=<>])\s+(?:as\s+)?"?([a-z_][a-z0-9_]*)"?$`)
+	viewColumnRefPattern = regexp.MustCompile(`(?i)^(?:"?[a-z_][a-z0-9_]*"?\.)?"?([a-z_][a-z0-9_]*)"?$`)
+)
+
+// inferViewColumns infer result columns from the SELECT list of viewSql.
+// types are taken from the dependent table columns with the same name,
+// and "*" / "x.*" expand to all of them. (TEXT if unknown)
+func inferViewColumns(viewSql string, columns []model.Column) []model.ViewColumn {
+	var ret []model.ViewColumn
+	names := map[string]bool{}
+	add := func(name, cls string) {
+		name = strings.ToLower(name)
+		if !names[name] {
+			names[name] = true
+			ret = append(ret, model.ViewColumn{ColumnName: name, DataTypeCls: cls, AlignSeq: len(ret) + 1})
+		}
+	}
+
+	for i, item := range splitSelectList(viewSql) {
+		item = strings.TrimSpace(item)
+		if i == 0 && len(item) > 9 && strings.EqualFold(item[:9], "distinct ") {
+			item = strings.TrimSpace(item[9:])
+		}
+
+		if item == "*" || strings.HasSuffix(item, ".*") {
+			for _, c := range columns {
+				add(c.ColumnName, c.DataTypeCls)
+			}
+			continue
+		}
+
+		expr, name := item, ""
+		if m := viewAliasPattern.FindStringSubmatch(item); m != nil {
+			expr, name = strings.TrimSpace(m[1]), m[2]
+		}
+
+		cls := constant.DATA_TYPE_CLS_TEXT
+		if m := viewColumnRefPattern.FindStringSubmatch(expr); m != nil {
+			if name == "" {
+				name = m[1]
+			}
+			for _, c := range columns {
+				if strings.EqualFold(c.ColumnName, m[1]) {
+					cls = c.DataTypeCls
+					break
+				}
+			}
+		} else {
+			lower := strings.ToLower(expr)
+			if strings.HasPrefix(lower, "count(") {
+				cls = constant.DATA_TYPE_CLS_BIGINT
+			} else if strings.HasPrefix(lower, "sum(") || strings.HasPrefix(lower, "avg(") {
+				cls = constant.DATA_TYPE_CLS_NUMERIC
+			}
+		}
+		if name == "" {
+			name = "column" + strconv.Itoa(i + 1)
+		}
+		add(name, cls)
+	}
+
+	return ret
+}
+
+
+// splitSelectList return items between the first SELECT and its FROM.
+// commas in parentheses and quotes are not separators.
+func splitSelectList(viewSql string) []string {
+	lower := strings.ToLower(viewSql)
+	start := strings.Index(lower, "select")
+	if start < 0 {
+		return nil
+	}
+
+	var ret []string
+	depth, quote, from := 0, byte(0), start + 6
+	for i := start + 6; i < len(viewSql); i++ {
+		ch := viewSql[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+		case depth == 0 && ch == ',':
+			ret = append(ret, viewSql[from:i])
+			from = i + 1
+		case depth == 0 && isSqlKeywordAt(lower, i, "from"):
+			return append(ret, viewSql[from:i])
+		}
+	}
+
+	return append(ret, viewSql[from:])
+}
+
+
+func isSqlKeywordAt(lower string, i int, keyword string) bool {
+	if !strings.HasPrefix(lower[i:], keyword) {
+		return false
+	}
+	isWordChar := func(b byte) bool {
+		return b == '_' || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9')
+	}
+	if i > 0 && isWordChar(lower[i - 1]) {
+		return false
+	}
+	end := i + len(keyword)
+	return end >= len(lower) || !isWordChar(lower[end])
+}
\ No newline at end of file
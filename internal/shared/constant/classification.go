@@ -18,22 +18,115 @@ const (
 )
 
 //USER_PROJECTS.ROLE_CLS
+//ROLE_CLS_NOMAL: editor. ROLE_CLS_OWNER: the user of PROJECT.USER_ID. (not in USER_PROJECTS)
 const (
 	ROLE_CLS_NOMAL = "00"
+	ROLE_CLS_VIEWER = "10"
 	ROLE_CLS_ADMIN = "88"
 	ROLE_CLS_OWNER = "99"
 )
 
+//ROLE_CLS_NAME names of USER_PROJECTS.ROLE_CLS for display.
+var ROLE_CLS_NAME = map[string]string{
+	ROLE_CLS_VIEWER: "Viewer",
+	ROLE_CLS_NOMAL: "Editor",
+	ROLE_CLS_ADMIN: "Admin",
+	ROLE_CLS_OWNER: "Owner",
+}
+
+//ROLE_CLS_RANK ranks of USER_PROJECTS.ROLE_CLS. a role has the permissions of the lower ranks.
+var ROLE_CLS_RANK = map[string]int{
+	ROLE_CLS_VIEWER: 1,
+	ROLE_CLS_NOMAL: 2,
+	ROLE_CLS_ADMIN: 3,
+	ROLE_CLS_OWNER: 4,
+}
+
+
+//ORG_MEMBER.ORG_ROLE_CLS
+//ORG_ROLE_CLS_OWNER: manage the organization, its teams and members. the owner of all the projects of it.
+const (
+	ORG_ROLE_CLS_MEMBER = "00"
+	ORG_ROLE_CLS_OWNER = "99"
+)
+
+//ORG_ROLE_CLS_NAME names of ORG_MEMBER.ORG_ROLE_CLS for display.
+var ORG_ROLE_CLS_NAME = map[string]string{
+	ORG_ROLE_CLS_MEMBER: "Member",
+	ORG_ROLE_CLS_OWNER: "Owner",
+}
+
+
+//ACCESS_TOKEN.SCOPE_CLS
+const (
+	SCOPE_CLS_READ = "01"
+	SCOPE_CLS_WRITE = "02"
+	SCOPE_CLS_CODEGEN = "03"
+)
+
+//SCOPE_CLS_NAME names of ACCESS_TOKEN.SCOPE_CLS for display.
+var SCOPE_CLS_NAME = map[string]string{
+	SCOPE_CLS_READ: "Read only",
+	SCOPE_CLS_WRITE: "Read & write",
+	SCOPE_CLS_CODEGEN: "Codegen only",
+}
+
 
 //COLUMNS.DATE_TYPE_CLS
 const (
 	DATA_TYPE_CLS_SERIAL = "01"
+	DATA_TYPE_CLS_BIGSERIAL = "02"
 	DATA_TYPE_CLS_TEXT = "10"
 	DATA_TYPE_CLS_VARCHAR = "11"
 	DATA_TYPE_CLS_CHAR = "12"
+	DATA_TYPE_CLS_UUID = "13"
+	DATA_TYPE_CLS_ENUM = "14"
 	DATA_TYPE_CLS_INTEGER = "20"
+	DATA_TYPE_CLS_BIGINT = "21"
+	DATA_TYPE_CLS_SMALLINT = "22"
 	DATA_TYPE_CLS_NUMERIC = "30"
+	DATA_TYPE_CLS_REAL = "31"
+	DATA_TYPE_CLS_DOUBLE = "32"
 	DATA_TYPE_CLS_TIMESTAMP = "40"
 	DATA_TYPE_CLS_DATE = "41"
+	DATA_TYPE_CLS_TIME = "42"
+	DATA_TYPE_CLS_TIMESTAMPTZ = "43"
 	DATA_TYPE_CLS_BLOB = "50"
+	DATA_TYPE_CLS_BOOLEAN = "60"
+	DATA_TYPE_CLS_JSON = "70"
+	DATA_TYPE_CLS_JSONB = "71"
+)
+
+//DATA_TYPE_CLS_BY_NAME data type names (view columns, table definitions) and DataTypeCls.
+var DATA_TYPE_CLS_BY_NAME = map[string]string{
+	"SERIAL": DATA_TYPE_CLS_SERIAL,
+	"BIGSERIAL": DATA_TYPE_CLS_BIGSERIAL,
+	"TEXT": DATA_TYPE_CLS_TEXT,
+	"VARCHAR": DATA_TYPE_CLS_VARCHAR,
+	"CHAR": DATA_TYPE_CLS_CHAR,
+	"UUID": DATA_TYPE_CLS_UUID,
+	"ENUM": DATA_TYPE_CLS_ENUM,
+	"INTEGER": DATA_TYPE_CLS_INTEGER,
+	"BIGINT": DATA_TYPE_CLS_BIGINT,
+	"SMALLINT": DATA_TYPE_CLS_SMALLINT,
+	"NUMERIC": DATA_TYPE_CLS_NUMERIC,
+	"REAL": DATA_TYPE_CLS_REAL,
+	"DOUBLE": DATA_TYPE_CLS_DOUBLE,
+	"TIMESTAMP": DATA_TYPE_CLS_TIMESTAMP,
+	"DATE": DATA_TYPE_CLS_DATE,
+	"TIME": DATA_TYPE_CLS_TIME,
+	"TIMESTAMPTZ": DATA_TYPE_CLS_TIMESTAMPTZ,
+	"BLOB": DATA_TYPE_CLS_BLOB,
+	"BOOLEAN": DATA_TYPE_CLS_BOOLEAN,
+	"JSON": DATA_TYPE_CLS_JSON,
+	"JSONB": DATA_TYPE_CLS_JSONB,
+}
+
+
+//CHECK_DEF.CHECK_TYPE_CLS
+const (
+	CHECK_TYPE_CLS_RANGE = "01"
+	CHECK_TYPE_CLS_PATTERN = "02"
+	CHECK_TYPE_CLS_IN = "03"
+	CHECK_TYPE_CLS_EXPRESSION = "09"
 )
\ No newline at end of file
@@ -0,0 +1,316 @@
+package form
+
+import (
+	"fmt"
+	"errors"
+	"strconv"
+	"strings"
+	"io"
+	"path/filepath"
+	"encoding/csv"
+	"mime/multipart"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"goat-cg/internal/dto"
+	"goat-cg/internal/model"
+	"goat-cg/internal/core/xlsx"
+	"goat-cg/internal/shared/constant"
+)
+
+
+// ImportColumns upload of table definition. (CSV or XLSX in the layout of the export)
+type ImportColumns struct {
+	File *multipart.FileHeader `form:"file" binding:"required"`
+}
+
+
+// ApplyImportColumns columns confirmed in the preview. (JSON of []PostColumn)
+type ApplyImportColumns struct {
+	Rows string `form:"rows" binding:"required"`
+}
+
+
+// ImportColumnRow a row of the uploaded file.
+// Line: row number in the file, Error: message of PostColumn validation.
+type ImportColumnRow struct {
+	Line int
+	Column PostColumn
+	Error string
+}
+
+
+// ReadRows read column definitions of the table from the file.
+// columns: current columns of the table. rows with the same column name update them,
+// keeping the values not written in the file. (enum values, classification, references)
+func (f ImportColumns) ReadRows(tableName string, columns []model.Column) ([]ImportColumnRow, error) {
+	file, err := f.File.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	records, err := ReadImportRecords(file, f.File.Filename, f.File.Size, tableName)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportRecords(records, tableName, columns)
+}
+
+
+// ParseImportRecords read column definitions of the table from the cells of the file.
+func ParseImportRecords(records [][]string, tableName string, columns []model.Column) ([]ImportColumnRow, error) {
+	headerLine := -1
+	index := map[string]int{}
+	for i, rec := range records {
+		for j, v := range rec {
+			if strings.TrimSpace(v) == "Column Name" {
+				headerLine = i
+			}
+			index[strings.TrimSpace(v)] = j
+		}
+		if headerLine >= 0 {
+			break
+		}
+		index = map[string]int{}
+	}
+	if headerLine < 0 {
+		return nil, errors.New("header row (Column Name, Type, ...) not found.")
+	}
+
+	existing := map[string]model.Column{}
+	for _, c := range columns {
+		existing[c.ColumnName] = c
+	}
+
+	var ret []ImportColumnRow
+	for i := headerLine + 1; i < len(records); i++ {
+		rec := records[i]
+		get := func(name string) string {
+			if j, ok := index[name]; ok && j < len(rec) {
+				return strings.TrimSpace(rec[j])
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(rec, "")) == "" || get("No") == "-" {
+			continue
+		}
+		if _, ok := index["Table Name"]; ok && get("Table Name") != tableName {
+			continue
+		}
+
+		row := ImportColumnRow{Line: i + 1}
+		c := PostColumn{
+			ColumnName: get("Column Name"),
+			ColumnNameLogical: get("Column Name（JP）"),
+			DefaultValue: get("Default"),
+			Remark: get("Remark"),
+		}
+		c.DataTypeCls = constant.DATA_TYPE_CLS_BY_NAME[strings.ToUpper(get("Type"))]
+		c.PrimaryKeyFlg = importFlag(get("PK"))
+		c.NotNullFlg = importFlag(get("NN"))
+		c.UniqueFlg = importFlag(get("UQ"))
+
+		var rowErrors []string
+		numbers := []struct{ name string; p *int }{
+			{"No", &c.AlignSeq}, {"Precision", &c.Precision}, {"Scale", &c.Scale},
+		}
+		for _, num := range numbers {
+			if v := get(num.name); v != "" {
+				n, err := strconv.Atoi(v)
+				if err != nil {
+					rowErrors = append(rowErrors, num.name + ": must be a number")
+				}
+				*num.p = n
+			}
+		}
+
+		if e, ok := existing[c.ColumnName]; ok {
+			c.ColumnId = e.ColumnId
+			c.EnumValues = e.EnumValues
+			c.ClassificationId = e.ClassificationId
+			c.RefColumnId = e.RefColumnId
+			c.DelFlg = e.DelFlg
+			if c.AlignSeq == 0 {
+				c.AlignSeq = e.AlignSeq
+			}
+		}
+		if _, ok := index["Enum Values"]; ok {
+			c.EnumValues = get("Enum Values")
+		}
+
+		row.Column = c
+		if get("Type") != "" && c.DataTypeCls == "" {
+			rowErrors = append([]string{"Type: unknown type " + get("Type")}, rowErrors...)
+		}
+		row.Error = strings.Join(rowErrors, ", ")
+		ret = append(ret, row)
+	}
+
+	for i := range ret {
+		if ret[i].Error == "" {
+			ret[i].Error = validateImportColumn(ret[i].Column)
+		}
+	}
+
+	return ret, nil
+}
+
+
+// ValidateRows validate columns confirmed in the preview again.
+func (f ApplyImportColumns) ValidateRows(rows []PostColumn) []ImportColumnRow {
+	var ret []ImportColumnRow
+	for i, c := range rows {
+		ret = append(ret, ImportColumnRow{Line: i + 1, Column: c, Error: validateImportColumn(c)})
+	}
+	return ret
+}
+
+
+// ToCreateColumns columns of the rows.
+func ToCreateColumns(rows []ImportColumnRow, tableId int, userId int) []dto.CreateColumn {
+	var ret []dto.CreateColumn
+	for _, r := range rows {
+		ret = append(ret, r.Column.ToCreateColumn(tableId, userId))
+	}
+	return ret
+}
+
+
+// validateImportColumn validate with the binding rules of PostColumn.
+func validateImportColumn(c PostColumn) string {
+	err := binding.Validator.ValidateStruct(&c)
+	if err == nil {
+		return ""
+	}
+
+	var ve validator.ValidationErrors
+	if !errors.As(err, &ve) {
+		return err.Error()
+	}
+	var ls []string
+	for _, fe := range ve {
+		if fe.Param() != "" {
+			ls = append(ls, fmt.Sprintf("%s: %s=%s", fe.Field(), fe.Tag(), fe.Param()))
+		} else {
+			ls = append(ls, fmt.Sprintf("%s: %s", fe.Field(), fe.Tag()))
+		}
+	}
+	return strings.Join(ls, ", ")
+}
+
+
+// importFlag "○", "1", "Y", "true" ... -> 1
+func importFlag(v string) int {
+	switch strings.ToLower(v) {
+	case "○", "◯", "o", "1", "y", "yes", "true", "x", "✓":
+		return constant.FLG_ON
+	}
+	return constant.FLG_OFF
+}
+
+
+// ImportFile content of an uploaded or local file.
+type ImportFile interface {
+	io.Reader
+	io.ReaderAt
+}
+
+
+// ReadImportRecords read cells of the file.
+// CSV: all rows, XLSX: the sheet named after the table, or the first sheet with the header.
+func ReadImportRecords(file ImportFile, filename string, size int64, tableName string) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		r := csv.NewReader(file)
+		r.FieldsPerRecord = -1
+		r.LazyQuotes = true
+		records, err := r.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		if len(records) > 0 && len(records[0]) > 0 {
+			records[0][0] = strings.TrimPrefix(records[0][0], "\uFEFF")
+		}
+		return records, nil
+
+	case ".xlsx":
+		sheets, err := xlsx.Read(file, size)
+		if err != nil {
+			return nil, err
+		}
+		for _, sh := range sheets {
+			if sh.Name == tableName {
+				return sh.Rows, nil
+			}
+		}
+		for _, sh := range sheets {
+			for _, row := range sh.Rows {
+				for _, v := range row {
+					if strings.TrimSpace(v) == "Column Name" {
+						return sh.Rows, nil
+					}
+				}
+			}
+		}
+		return nil, errors.New("sheet with header row not found.")
+	}
+
+	return nil, errors.New("file must be .csv or .xlsx.")
+}
+
+
+// ImportTableNames names of the tables written in the file.
+// CSV: values of "Table Name", XLSX: sheets with the header row. (the layout of the dictionary export)
+func ImportTableNames(file ImportFile, filename string, size int64) ([]string, error) {
+	var names []string
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		records, err := ReadImportRecords(file, filename, size, "")
+		if err != nil {
+			return nil, err
+		}
+		index := -1
+		seen := map[string]bool{}
+		for _, rec := range records {
+			if index < 0 {
+				for j, v := range rec {
+					if strings.TrimSpace(v) == "Table Name" {
+						index = j
+					}
+				}
+				continue
+			}
+			if index < len(rec) && strings.TrimSpace(rec[index]) != "" && !seen[strings.TrimSpace(rec[index])] {
+				seen[strings.TrimSpace(rec[index])] = true
+				names = append(names, strings.TrimSpace(rec[index]))
+			}
+		}
+		if index < 0 {
+			return nil, errors.New("column \"Table Name\" not found.")
+		}
+
+	case ".xlsx":
+		sheets, err := xlsx.Read(file, size)
+		if err != nil {
+			return nil, err
+		}
+		for _, sh := range sheets {
+			if len(sh.Rows) == 0 {
+				continue
+			}
+			for _, v := range sh.Rows[0] {
+				if strings.TrimSpace(v) == "Column Name" {
+					names = append(names, sh.Name)
+					break
+				}
+			}
+		}
+
+	default:
+		return nil, errors.New("file must be .csv or .xlsx.")
+	}
+
+	return names, nil
+}
\ No newline at end of file
@@ -0,0 +1,39 @@
+package form
+
+import (
+	"goat-cg/internal/dto"
+)
+
+
+type PostCheck struct {
+	CheckId int `form:"check_id"`
+	ColumnId int `form:"column_id" binding:"min=0"`
+	CheckName string `form:"check_name" binding:"required,max=50,min=1"`
+	CheckTypeCls string `form:"check_type_cls" binding:"required,oneof=01 02 03 09"`
+	MinValue string `form:"min_value"`
+	MaxValue string `form:"max_value"`
+	Pattern string `form:"pattern" binding:"required_if=CheckTypeCls 02"`
+	AllowedValues string `form:"allowed_values" binding:"required_if=CheckTypeCls 03"`
+	Expression string `form:"expression" binding:"required_if=CheckTypeCls 09"`
+}
+
+
+func (f PostCheck) ToCreateCheck(tableId int, userId int) dto.CreateCheck {
+	var ret dto.CreateCheck
+
+	ret.CheckId = f.CheckId
+	ret.TableId = tableId
+	ret.ColumnId = f.ColumnId
+	ret.CheckName = f.CheckName
+	ret.CheckTypeCls = f.CheckTypeCls
+	ret.MinValue = f.MinValue
+	ret.MaxValue = f.MaxValue
+	ret.Pattern = f.Pattern
+	ret.AllowedValues = f.AllowedValues
+	ret.Expression = f.Expression
+	ret.CreateUserId = userId
+	ret.UpdateUserId = userId
+
+	return ret
+}
+
@@ -0,0 +1,49 @@
+package form
+
+import (
+	"strings"
+
+	"goat-cg/internal/dto"
+	"goat-cg/internal/model"
+)
+
+
+type PostClassification struct {
+	ClassificationId int `form:"classification_id"`
+	ClassificationName string `form:"classification_name" binding:"required,max=50,min=1"`
+	ClassificationNameLogical string `form:"classification_name_logical"`
+	Remark string `form:"remark"`
+	Values string `form:"values" binding:"required"`
+}
+
+
+// ToCreateClassification Values are written one per line as "code,value_name,label".
+func (f PostClassification) ToCreateClassification(projectId int, userId int) dto.CreateClassification {
+	var ret dto.CreateClassification
+
+	ret.ClassificationId = f.ClassificationId
+	ret.ProjectId = projectId
+	ret.ClassificationName = f.ClassificationName
+	ret.ClassificationNameLogical = f.ClassificationNameLogical
+	ret.Remark = f.Remark
+	ret.CreateUserId = userId
+	ret.UpdateUserId = userId
+
+	for _, line := range strings.Split(f.Values, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		ls := strings.SplitN(line, ",", 3)
+		for len(ls) < 3 {
+			ls = append(ls, "")
+		}
+		ret.Values = append(ret.Values, model.ClassificationValue{
+			Code: strings.TrimSpace(ls[0]),
+			ValueName: strings.TrimSpace(ls[1]),
+			Label: strings.TrimSpace(ls[2]),
+			AlignSeq: len(ret.Values) + 1,
+		})
+	}
+
+	return ret
+}
\ No newline at end of file
@@ -0,0 +1,35 @@
+package form
+
+import (
+	"goat-cg/internal/dto"
+)
+
+
+// PostCodegen options of a generator. (API)
+type PostCodegen struct {
+	TableIds []int `json:"table_ids"`
+	ViewIds []int `json:"view_ids"`
+	Rdbms string `json:"rdbms"`
+	Zod bool `json:"zod"`
+	Grpc bool `json:"grpc"`
+	Orm string `json:"orm"`
+	SeedFormat string `json:"seed_format"`
+	SeedRows int `json:"seed_rows"`
+}
+
+
+func (f PostCodegen) ToCodegen(projectName string) dto.Codegen {
+	var ret dto.Codegen
+
+	ret.ProjectName = projectName
+	ret.Rdbms = f.Rdbms
+	ret.TableIds = f.TableIds
+	ret.ViewIds = f.ViewIds
+	ret.Zod = f.Zod
+	ret.Grpc = f.Grpc
+	ret.Orm = f.Orm
+	ret.SeedFormat = f.SeedFormat
+	ret.SeedRows = f.SeedRows
+
+	return ret
+}
\ No newline at end of file
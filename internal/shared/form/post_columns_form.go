@@ -6,19 +6,22 @@ import (
 
 
 type PostColumn struct {
-	ColumnId int `form:"column_id"`
-	ColumnName string `form:"column_name" binding:"required,max=50,min=1"`
-	ColumnNameLogical string `form:"column_name_logical"`
-	DataTypeCls string `form:"data_type_cls" binding:"required"`
-	Precision int `form:"precision"`
-	Scale int `form:"scale"`
-	PrimaryKeyFlg int `form:"primary_key_flg"`
-	NotNullFlg int `form:"not_null_flg"`
-	UniqueFlg int `form:"unique_flg"`
-	DefaultValue string `form:"default_value"`
-	Remark string `form:"remark"`
-	AlignSeq int `form:"align_seq"`
-	DelFlg int `form:"del_flg"`
+	ColumnId int `form:"column_id" json:"column_id"`
+	ColumnName string `form:"column_name" json:"column_name" binding:"required,max=50,min=1"`
+	ColumnNameLogical string `form:"column_name_logical" json:"column_name_logical"`
+	DataTypeCls string `form:"data_type_cls" json:"data_type_cls" binding:"required,oneof=01 02 10 11 12 13 14 20 21 22 30 31 32 40 41 42 43 50 60 70 71"`
+	Precision int `form:"precision" json:"precision" binding:"min=0"`
+	Scale int `form:"scale" json:"scale" binding:"min=0,ltefield=Precision"`
+	PrimaryKeyFlg int `form:"primary_key_flg" json:"primary_key_flg"`
+	NotNullFlg int `form:"not_null_flg" json:"not_null_flg"`
+	UniqueFlg int `form:"unique_flg" json:"unique_flg"`
+	DefaultValue string `form:"default_value" json:"default_value"`
+	EnumValues string `form:"enum_values" json:"enum_values" binding:"required_if=DataTypeCls 14 ClassificationId 0"`
+	ClassificationId int `form:"classification_id" json:"classification_id" binding:"min=0"`
+	RefColumnId int `form:"ref_column_id" json:"ref_column_id" binding:"min=0"`
+	Remark string `form:"remark" json:"remark"`
+	AlignSeq int `form:"align_seq" json:"align_seq"`
+	DelFlg int `form:"del_flg" json:"del_flg"`
 }
 
 
@@ -36,6 +39,9 @@ func (f PostColumn) ToCreateColumn(tableId int, userId int) dto.CreateColumn {
 	ret.NotNullFlg = f.NotNullFlg
 	ret.UniqueFlg = f.UniqueFlg
 	ret.DefaultValue = f.DefaultValue
+	ret.EnumValues = f.EnumValues
+	ret.ClassificationId = f.ClassificationId
+	ret.RefColumnId = f.RefColumnId
 	ret.Remark = f.Remark
 	ret.AlignSeq = f.AlignSeq
 	ret.DelFlg = f.DelFlg
@@ -0,0 +1,19 @@
+package form
+
+
+// PostMember UserRole: ROLE_CLS (viewer "10", editor "00", admin "88"). editor if empty.
+type PostMember struct {
+	Email string `form:"email" json:"email" binding:"required,email"`
+	UserRole string `form:"user_role" json:"user_role"`
+}
+
+
+type PutMemberRole struct {
+	UserRole string `form:"user_role" json:"user_role" binding:"required"`
+}
+
+
+// PostMemberRole UserRole of approving the join request. viewer if empty.
+type PostMemberRole struct {
+	UserRole string `form:"user_role" json:"user_role"`
+}
\ No newline at end of file
@@ -0,0 +1,15 @@
+package form
+
+
+type PostProject struct {
+	ProjectName string `form:"project_name" json:"project_name" binding:"required,max=50,min=1"`
+	ProjectMemo string `form:"project_memo" json:"project_memo"`
+	// OrgName the organization to create the project in. (creation only, the user's own if empty)
+	OrgName string `form:"org_name" json:"org_name"`
+}
+
+
+// PostProjectTransfer the member to be the new owner.
+type PostProjectTransfer struct {
+	UserId int `form:"user_id" json:"user_id" binding:"required"`
+}
\ No newline at end of file
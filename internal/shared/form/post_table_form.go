@@ -0,0 +1,9 @@
+package form
+
+
+type PostTable struct {
+	TableName string `form:"table_name" json:"table_name" binding:"required,max=50,min=1"`
+	TableNameLogical string `form:"table_name_logical" json:"table_name_logical"`
+	VersionFlg int `form:"version_flg" json:"version_flg" binding:"oneof=0 1"`
+	DelFlg int `form:"del_flg" json:"del_flg" binding:"oneof=0 1"`
+}
\ No newline at end of file
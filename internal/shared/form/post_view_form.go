@@ -0,0 +1,32 @@
+package form
+
+import (
+	"goat-cg/internal/dto"
+)
+
+
+type PostView struct {
+	ViewId int `form:"view_id"`
+	ViewName string `form:"view_name" binding:"required,max=50,min=1"`
+	ViewNameLogical string `form:"view_name_logical"`
+	ViewSql string `form:"view_sql" binding:"required"`
+	TableIds []int `form:"table_id"`
+	Columns string `form:"columns"`
+}
+
+
+func (f PostView) ToCreateView(projectId int, userId int) dto.CreateView {
+	var ret dto.CreateView
+
+	ret.ViewId = f.ViewId
+	ret.ProjectId = projectId
+	ret.ViewName = f.ViewName
+	ret.ViewNameLogical = f.ViewNameLogical
+	ret.ViewSql = f.ViewSql
+	ret.TableIds = f.TableIds
+	ret.Columns = f.Columns
+	ret.CreateUserId = userId
+	ret.UpdateUserId = userId
+
+	return ret
+}
\ No newline at end of file
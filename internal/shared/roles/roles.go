@@ -0,0 +1,68 @@
+package roles
+
+import (
+	"goat-cg/internal/shared/constant"
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/model"
+)
+
+
+// Has the role has the permissions of the required role.
+func Has(role, required string) bool {
+	return constant.ROLE_CLS_RANK[role] >= constant.ROLE_CLS_RANK[required]
+}
+
+
+// Normalize roles of members invited before roles (user_role "0") are editors.
+func Normalize(role string) string {
+	if _, ok := constant.ROLE_CLS_RANK[role]; !ok {
+		return constant.ROLE_CLS_NOMAL
+	}
+	return role
+}
+
+
+// ValidateChange the actor can give the role to a member of the current role.
+// (current "": a new member) actors can only manage the roles lower than their own.
+func ValidateChange(actorRole, current, userRole string) error {
+	if userRole == constant.ROLE_CLS_OWNER {
+		return errs.NewInvalidValueError("user_role", "unknown role.")
+	}
+	if _, ok := constant.ROLE_CLS_RANK[userRole]; !ok {
+		return errs.NewInvalidValueError("user_role", "unknown role.")
+	}
+	if !Has(actorRole, constant.ROLE_CLS_ADMIN) {
+		return errs.NewForbiddenError()
+	}
+	if constant.ROLE_CLS_RANK[userRole] >= constant.ROLE_CLS_RANK[actorRole] {
+		return errs.NewForbiddenError()
+	}
+	if current != "" && constant.ROLE_CLS_RANK[current] >= constant.ROLE_CLS_RANK[actorRole] {
+		return errs.NewForbiddenError()
+	}
+	return nil
+}
+
+
+// Highest the higher of the role of the member row and the roles granted to the teams of the user.
+// blocked users have none, and pending invitations or join requests grant nothing.
+func Highest(m model.Member, teamRoles []string) (string, error) {
+	if m.UserStatus == constant.STATE_CLS_BLOCK {
+		return "", errs.NewNotFoundError()
+	}
+
+	role := ""
+	for _, r := range teamRoles {
+		if Has(r, role) {
+			role = r
+		}
+	}
+	if m.UserStatus == constant.STATE_CLS_NOMAL && Has(Normalize(m.UserRole), role) {
+		role = Normalize(m.UserRole)
+	}
+
+	if role == "" {
+		return "", errs.NewNotFoundError()
+	}
+	return role, nil
+}
\ No newline at end of file
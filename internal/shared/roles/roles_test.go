@@ -0,0 +1,152 @@
+package roles
+
+import (
+	"testing"
+
+	"goat-cg/internal/core/errs"
+	"goat-cg/internal/model"
+	"goat-cg/internal/shared/constant"
+)
+
+
+const (
+	viewer = constant.ROLE_CLS_VIEWER
+	editor = constant.ROLE_CLS_NOMAL
+	admin = constant.ROLE_CLS_ADMIN
+	owner = constant.ROLE_CLS_OWNER
+)
+
+
+// errKind "forbidden", "invalid", "notfound", "" (nil) or "other".
+func errKind(err error) string {
+	switch err.(type) {
+	case nil:
+		return ""
+	case errs.ForbiddenError:
+		return "forbidden"
+	case errs.InvalidValueError:
+		return "invalid"
+	case errs.NotFoundError:
+		return "notfound"
+	}
+	return "other"
+}
+
+
+func TestHas(t *testing.T) {
+	tests := []struct {
+		role string
+		required string
+		want bool
+	}{
+		{viewer, viewer, true},
+		{viewer, editor, false},
+		{viewer, admin, false},
+		{viewer, owner, false},
+		{editor, viewer, true},
+		{editor, editor, true},
+		{editor, admin, false},
+		{editor, owner, false},
+		{admin, viewer, true},
+		{admin, editor, true},
+		{admin, admin, true},
+		{admin, owner, false},
+		{owner, viewer, true},
+		{owner, editor, true},
+		{owner, admin, true},
+		{owner, owner, true},
+		{"", viewer, false},
+		{"", owner, false},
+	}
+
+	for _, tt := range tests {
+		if got := Has(tt.role, tt.required); got != tt.want {
+			t.Errorf("Has(%q, %q) = %v, want %v", tt.role, tt.required, got, tt.want)
+		}
+	}
+}
+
+
+func TestValidateChange(t *testing.T) {
+	tests := []struct {
+		name string
+		actor string
+		current string
+		userRole string
+		want string
+	}{
+		{"owner invites a viewer", owner, "", viewer, ""},
+		{"owner invites an editor", owner, "", editor, ""},
+		{"owner invites an admin", owner, "", admin, ""},
+		{"owner promotes an editor to admin", owner, editor, admin, ""},
+		{"owner demotes an admin to viewer", owner, admin, viewer, ""},
+		{"admin invites a viewer", admin, "", viewer, ""},
+		{"admin invites an editor", admin, "", editor, ""},
+		{"admin promotes a viewer to editor", admin, viewer, editor, ""},
+		{"admin demotes an editor to viewer", admin, editor, viewer, ""},
+
+		{"no one gives the owner role", owner, editor, owner, "invalid"},
+		{"admin cannot promote to owner", admin, editor, owner, "invalid"},
+		{"admin cannot invite an owner", admin, "", owner, "invalid"},
+		{"unknown role", owner, editor, "55", "invalid"},
+
+		{"admin cannot give admin", admin, editor, admin, "forbidden"},
+		{"admin cannot demote another admin", admin, admin, editor, "forbidden"},
+		{"admin cannot demote the owner", admin, owner, admin, "forbidden"},
+		{"owner cannot demote the owner", owner, owner, admin, "forbidden"},
+		{"editor cannot change roles", editor, viewer, viewer, "forbidden"},
+		{"editor cannot invite", editor, "", viewer, "forbidden"},
+		{"viewer cannot change roles", viewer, viewer, viewer, "forbidden"},
+
+		{"viewer cannot change their own role", viewer, viewer, editor, "forbidden"},
+		{"editor cannot change their own role", editor, editor, viewer, "forbidden"},
+		{"admin cannot change their own role", admin, admin, viewer, "forbidden"},
+		{"owner cannot change their own role", owner, owner, viewer, "forbidden"},
+	}
+
+	for _, tt := range tests {
+		err := ValidateChange(tt.actor, tt.current, tt.userRole)
+		if got := errKind(err); got != tt.want {
+			t.Errorf("%s: ValidateChange(%q, %q, %q) = %v, want %q", tt.name, tt.actor, tt.current, tt.userRole, err, tt.want)
+		}
+	}
+}
+
+
+func TestHighest(t *testing.T) {
+	member := func(status, role string) model.Member {
+		return model.Member{ProjectId: 1, UserId: 2, UserStatus: status, UserRole: role}
+	}
+	none := model.Member{}
+
+	tests := []struct {
+		name string
+		m model.Member
+		teamRoles []string
+		want string
+		wantErr string
+	}{
+		{"viewer member", member(constant.STATE_CLS_NOMAL, viewer), nil, viewer, ""},
+		{"editor member", member(constant.STATE_CLS_NOMAL, editor), nil, editor, ""},
+		{"admin member", member(constant.STATE_CLS_NOMAL, admin), nil, admin, ""},
+		{"member before roles is an editor", member(constant.STATE_CLS_NOMAL, "0"), nil, editor, ""},
+		{"not a member", none, nil, "", "notfound"},
+		{"pending invitation", member(constant.STATE_CLS_REQUEST, admin), nil, "", "notfound"},
+		{"join request", member(constant.STATE_CLS_JOIN, editor), nil, "", "notfound"},
+		{"blocked", member(constant.STATE_CLS_BLOCK, admin), nil, "", "notfound"},
+
+		{"team grant only", none, []string{editor}, editor, ""},
+		{"highest team grant", none, []string{viewer, admin, editor}, admin, ""},
+		{"team grant above the membership", member(constant.STATE_CLS_NOMAL, viewer), []string{editor}, editor, ""},
+		{"membership above the team grant", member(constant.STATE_CLS_NOMAL, admin), []string{viewer}, admin, ""},
+		{"team grant with a pending invitation", member(constant.STATE_CLS_REQUEST, admin), []string{viewer}, viewer, ""},
+		{"blocked despite a team grant", member(constant.STATE_CLS_BLOCK, viewer), []string{admin}, "", "notfound"},
+	}
+
+	for _, tt := range tests {
+		got, err := Highest(tt.m, tt.teamRoles)
+		if got != tt.want || errKind(err) != tt.wantErr {
+			t.Errorf("%s: Highest() = %q, %v, want %q, %q", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,25 @@
+-- Upgrade a database created by an earlier create-table.sql. Run it once, then create-table.sql:
+--   sqlite3 goat-cg.db < scripts/alter-table.sql
+--   sqlite3 goat-cg.db < scripts/create-table.sql
+-- (create-table.sql creates the new tables and triggers; it never changes existing tables)
+-- The columns are appended in the same order to the tables and their logs,
+-- so the triggers copying them with "INSERT INTO x_log SELECT *" keep working.
+
+-- optimistic locking
+ALTER TABLE table_def ADD COLUMN version_flg INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE table_def_log ADD COLUMN version_flg INTEGER;
+
+-- enum values, classifications and column references
+ALTER TABLE column_def ADD COLUMN enum_values TEXT;
+ALTER TABLE column_def ADD COLUMN classification_id INTEGER DEFAULT 0;
+ALTER TABLE column_def ADD COLUMN ref_column_id INTEGER DEFAULT 0;
+ALTER TABLE column_def_log ADD COLUMN enum_values TEXT;
+ALTER TABLE column_def_log ADD COLUMN classification_id INTEGER;
+ALTER TABLE column_def_log ADD COLUMN ref_column_id INTEGER;
+
+-- pending invitations
+ALTER TABLE project_member ADD COLUMN invited_by INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE project_member ADD COLUMN expires_at TEXT NOT NULL DEFAULT '';
+
+-- organizations
+ALTER TABLE project ADD COLUMN org_id INTEGER NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -21,6 +21,7 @@ CREATE TABLE IF NOT EXISTS project (
 	project_memo TEXT,
 	user_id INTEGER NOT NULL,
 	username TEXT NOT NULL,
+	org_id INTEGER NOT NULL DEFAULT 0,
 	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
 	updated_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
 	UNIQUE(project_name, username)
@@ -33,11 +34,27 @@ BEGIN
     	WHERE rowid == NEW.rowid;
 END;
 
+CREATE TABLE IF NOT EXISTS project_redirect (
+	username TEXT NOT NULL,
+	project_name TEXT NOT NULL,
+	project_id INTEGER NOT NULL,
+	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	PRIMARY KEY(username, project_name)
+);
+
+CREATE TRIGGER IF NOT EXISTS trg_project_del_project_redirect AFTER DELETE ON project
+BEGIN
+	DELETE FROM project_redirect
+	WHERE project_id == OLD.project_id;
+END;
+
 CREATE TABLE IF NOT EXISTS project_member (
 	project_id INTEGER NOT NULL,
 	user_id INTEGER NOT NULL,
 	user_status TEXT NOT NULL,
 	user_role TEXT NOT NULL,
+	invited_by INTEGER NOT NULL DEFAULT 0,
+	expires_at TEXT NOT NULL DEFAULT '',
 	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
 	updated_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
 	PRIMARY KEY(project_id, user_id)
@@ -50,12 +67,161 @@ BEGIN
     	WHERE rowid == NEW.rowid;
 END;
 
+CREATE TABLE IF NOT EXISTS project_invitation (
+	invitation_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	project_id INTEGER NOT NULL,
+	email TEXT NOT NULL,
+	user_role TEXT NOT NULL,
+	invited_by INTEGER NOT NULL,
+	expires_at TEXT NOT NULL,
+	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	updated_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	UNIQUE(project_id, email)
+);
+
+CREATE TRIGGER IF NOT EXISTS trg_project_invitation_upd AFTER UPDATE ON project_invitation
+BEGIN
+    UPDATE project_invitation
+    	SET updated_at = DATETIME('now', 'localtime') 
+    	WHERE rowid == NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_project_del_project_invitation AFTER DELETE ON project
+BEGIN
+	DELETE FROM project_invitation
+	WHERE project_id == OLD.project_id;
+END;
+
+CREATE TABLE IF NOT EXISTS organization (
+	org_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	org_name TEXT NOT NULL UNIQUE,
+	org_memo TEXT NOT NULL DEFAULT '',
+	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	updated_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime'))
+);
+
+CREATE TRIGGER IF NOT EXISTS trg_organization_upd AFTER UPDATE ON organization
+BEGIN
+    UPDATE organization
+    	SET updated_at = DATETIME('now', 'localtime') 
+    	WHERE rowid == NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_organization_ins_name BEFORE INSERT ON organization
+WHEN EXISTS (SELECT 1 FROM users WHERE username == NEW.org_name)
+BEGIN
+	SELECT RAISE(ABORT, 'UNIQUE constraint failed: users.username');
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_organization_upd_name BEFORE UPDATE OF org_name ON organization
+WHEN EXISTS (SELECT 1 FROM users WHERE username == NEW.org_name)
+BEGIN
+	SELECT RAISE(ABORT, 'UNIQUE constraint failed: users.username');
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_users_ins_name BEFORE INSERT ON users
+WHEN EXISTS (SELECT 1 FROM organization WHERE org_name == NEW.username)
+BEGIN
+	SELECT RAISE(ABORT, 'UNIQUE constraint failed: organization.org_name');
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_users_upd_name BEFORE UPDATE OF username ON users
+WHEN EXISTS (SELECT 1 FROM organization WHERE org_name == NEW.username)
+BEGIN
+	SELECT RAISE(ABORT, 'UNIQUE constraint failed: organization.org_name');
+END;
+
+CREATE TABLE IF NOT EXISTS org_member (
+	org_id INTEGER NOT NULL,
+	user_id INTEGER NOT NULL,
+	org_role TEXT NOT NULL,
+	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	updated_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	PRIMARY KEY(org_id, user_id)
+);
+
+CREATE TRIGGER IF NOT EXISTS trg_org_member_upd AFTER UPDATE ON org_member
+BEGIN
+    UPDATE org_member
+    	SET updated_at = DATETIME('now', 'localtime') 
+    	WHERE rowid == NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_org_member_del_team_member AFTER DELETE ON org_member
+BEGIN
+	DELETE FROM team_member
+	WHERE user_id == OLD.user_id
+	AND team_id IN (SELECT team_id FROM team WHERE org_id == OLD.org_id);
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_users_del_org_member AFTER DELETE ON users
+BEGIN
+	DELETE FROM org_member
+	WHERE user_id == OLD.user_id;
+END;
+
+CREATE TABLE IF NOT EXISTS team (
+	team_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	org_id INTEGER NOT NULL,
+	team_name TEXT NOT NULL,
+	team_memo TEXT NOT NULL DEFAULT '',
+	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	updated_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	UNIQUE(org_id, team_name)
+);
+
+CREATE TRIGGER IF NOT EXISTS trg_team_upd AFTER UPDATE ON team
+BEGIN
+    UPDATE team
+    	SET updated_at = DATETIME('now', 'localtime') 
+    	WHERE rowid == NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_team_del AFTER DELETE ON team
+BEGIN
+	DELETE FROM team_member
+	WHERE team_id == OLD.team_id;
+
+	DELETE FROM team_project
+	WHERE team_id == OLD.team_id;
+END;
+
+CREATE TABLE IF NOT EXISTS team_member (
+	team_id INTEGER NOT NULL,
+	user_id INTEGER NOT NULL,
+	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	PRIMARY KEY(team_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS team_project (
+	team_id INTEGER NOT NULL,
+	project_id INTEGER NOT NULL,
+	user_role TEXT NOT NULL,
+	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	updated_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	PRIMARY KEY(team_id, project_id)
+);
+
+CREATE TRIGGER IF NOT EXISTS trg_team_project_upd AFTER UPDATE ON team_project
+BEGIN
+    UPDATE team_project
+    	SET updated_at = DATETIME('now', 'localtime') 
+    	WHERE rowid == NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_project_del_team_project AFTER DELETE ON project
+BEGIN
+	DELETE FROM team_project
+	WHERE project_id == OLD.project_id;
+END;
+
 
 CREATE TABLE IF NOT EXISTS table_def (
 	table_id INTEGER PRIMARY KEY AUTOINCREMENT,
 	project_id INTEGER NOT NULL,
 	table_name TEXT NOT NULL,
 	table_name_logical TEXT,
+	version_flg INTEGER NOT NULL DEFAULT 0,
 	create_user_id INTEGER,
 	update_user_id INTEGER,
 	del_flg INTEGER NOT NULL DEFAULT 0,
@@ -92,6 +258,7 @@ CREATE TABLE IF NOT EXISTS table_def_log (
 	project_id INTEGER,
 	table_name TEXT,
 	table_name_logical TEXT,
+	version_flg INTEGER,
 	create_user_id INTEGER,
 	update_user_id INTEGER,
 	del_flg INTEGER,
@@ -112,6 +279,9 @@ CREATE TABLE IF NOT EXISTS column_def (
 	not_null_flg INTEGER DEFAULT 0,
 	unique_flg INTEGER DEFAULT 0,
 	default_value TEXT,
+	enum_values TEXT,
+	classification_id INTEGER DEFAULT 0,
+	ref_column_id INTEGER DEFAULT 0,
 	remark TEXT,
 	align_seq INTEGER,
 	del_flg INTEGER NOT NULL DEFAULT 0,
@@ -156,6 +326,9 @@ CREATE TABLE IF NOT EXISTS column_def_log (
 	not_null_flg INTEGER,
 	unique_flg INTEGER,
 	default_value TEXT,
+	enum_values TEXT,
+	classification_id INTEGER,
+	ref_column_id INTEGER,
 	remark TEXT,
 	align_seq INTEGER,
 	del_flg INTEGER,
@@ -166,6 +339,212 @@ CREATE TABLE IF NOT EXISTS column_def_log (
 );
 
 
+CREATE TABLE IF NOT EXISTS view_def (
+	view_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	project_id INTEGER NOT NULL,
+	view_name TEXT NOT NULL,
+	view_name_logical TEXT,
+	view_sql TEXT NOT NULL,
+	create_user_id INTEGER,
+	update_user_id INTEGER,
+	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	updated_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	UNIQUE(project_id, view_name)
+);
+
+CREATE TRIGGER IF NOT EXISTS trg_view_def_upd AFTER UPDATE ON view_def
+BEGIN
+    UPDATE view_def
+    SET updated_at = DATETIME('now', 'localtime') 
+    WHERE rowid == NEW.rowid;
+
+    INSERT INTO view_def_log
+	SELECT * FROM view_def WHERE view_id == NEW.view_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_view_def_ins AFTER INSERT ON view_def
+BEGIN
+	INSERT INTO view_def_log
+	SELECT * FROM view_def WHERE view_id == NEW.view_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_view_def_del AFTER DELETE ON view_def
+BEGIN
+	DELETE FROM view_def_log
+	WHERE view_id == OLD.view_id;
+END;
+
+CREATE TABLE IF NOT EXISTS view_def_log (
+	view_id INTEGER,
+	project_id INTEGER,
+	view_name TEXT,
+	view_name_logical TEXT,
+	view_sql TEXT,
+	create_user_id INTEGER,
+	update_user_id INTEGER,
+	created_at TEXT,
+	updated_at TEXT
+);
+
+CREATE TABLE IF NOT EXISTS view_table (
+	view_id INTEGER NOT NULL,
+	table_id INTEGER NOT NULL,
+	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	PRIMARY KEY(view_id, table_id)
+);
+
+CREATE TABLE IF NOT EXISTS view_column (
+	view_id INTEGER NOT NULL,
+	column_name TEXT NOT NULL,
+	data_type_cls TEXT NOT NULL,
+	align_seq INTEGER,
+	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	PRIMARY KEY(view_id, column_name)
+);
+
+
+CREATE TABLE IF NOT EXISTS check_def (
+	check_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	table_id INTEGER NOT NULL,
+	column_id INTEGER NOT NULL DEFAULT 0,
+	check_name TEXT NOT NULL,
+	check_type_cls TEXT NOT NULL,
+	min_value TEXT,
+	max_value TEXT,
+	pattern TEXT,
+	allowed_values TEXT,
+	expression TEXT,
+	create_user_id INTEGER,
+	update_user_id INTEGER,
+	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	updated_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	UNIQUE(table_id, check_name)
+);
+
+CREATE TRIGGER IF NOT EXISTS trg_check_def_upd AFTER UPDATE ON check_def
+BEGIN
+    UPDATE check_def
+    SET updated_at = DATETIME('now', 'localtime') 
+    WHERE rowid == NEW.rowid;
+END;
+
+
+CREATE TABLE IF NOT EXISTS classification (
+	classification_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	project_id INTEGER NOT NULL,
+	classification_name TEXT NOT NULL,
+	classification_name_logical TEXT,
+	remark TEXT,
+	create_user_id INTEGER,
+	update_user_id INTEGER,
+	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	updated_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	UNIQUE(project_id, classification_name)
+);
+
+CREATE TRIGGER IF NOT EXISTS trg_classification_upd AFTER UPDATE ON classification
+BEGIN
+    UPDATE classification
+    SET updated_at = DATETIME('now', 'localtime') 
+    WHERE rowid == NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS classification_value (
+	classification_id INTEGER NOT NULL,
+	code TEXT NOT NULL,
+	value_name TEXT NOT NULL,
+	label TEXT,
+	align_seq INTEGER,
+	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	updated_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	PRIMARY KEY(classification_id, code)
+);
+
+
+CREATE TABLE IF NOT EXISTS proto_field (
+	table_id INTEGER NOT NULL,
+	field_no INTEGER NOT NULL,
+	column_id INTEGER NOT NULL DEFAULT 0,
+	field_name TEXT NOT NULL,
+	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	updated_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	PRIMARY KEY(table_id, field_no)
+);
+
+CREATE TRIGGER IF NOT EXISTS trg_proto_field_upd AFTER UPDATE ON proto_field
+BEGIN
+    UPDATE proto_field
+    SET updated_at = DATETIME('now', 'localtime') 
+    WHERE rowid == NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_table_def_del_proto_field AFTER DELETE ON table_def
+BEGIN
+	DELETE FROM proto_field
+	WHERE table_id == OLD.table_id;
+END;
+
+
+CREATE TABLE IF NOT EXISTS sample_row (
+	table_id INTEGER NOT NULL,
+	row_no INTEGER NOT NULL,
+	row_data TEXT NOT NULL,
+	stale_flg INTEGER NOT NULL DEFAULT 0,
+	stale_reason TEXT NOT NULL DEFAULT '',
+	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	updated_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	PRIMARY KEY(table_id, row_no)
+);
+
+CREATE TRIGGER IF NOT EXISTS trg_sample_row_upd AFTER UPDATE ON sample_row
+BEGIN
+    UPDATE sample_row
+    SET updated_at = DATETIME('now', 'localtime') 
+    WHERE rowid == NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_table_def_del_sample_row AFTER DELETE ON table_def
+BEGIN
+	DELETE FROM sample_row
+	WHERE table_id == OLD.table_id;
+END;
+
+
+CREATE TABLE IF NOT EXISTS access_token (
+	token_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL,
+	token_name TEXT NOT NULL,
+	token_hash TEXT NOT NULL UNIQUE,
+	token_prefix TEXT NOT NULL,
+	scope_cls TEXT NOT NULL,
+	project_id INTEGER NOT NULL DEFAULT 0,
+	expires_at TEXT NOT NULL DEFAULT '',
+	last_used_at TEXT NOT NULL DEFAULT '',
+	created_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	updated_at TEXT NOT NULL DEFAULT (DATETIME('now', 'localtime')),
+	UNIQUE(user_id, token_name)
+);
+
+CREATE TRIGGER IF NOT EXISTS trg_access_token_upd AFTER UPDATE ON access_token
+BEGIN
+    UPDATE access_token
+    SET updated_at = DATETIME('now', 'localtime') 
+    WHERE rowid == NEW.rowid;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_users_del_access_token AFTER DELETE ON users
+BEGIN
+	DELETE FROM access_token
+	WHERE user_id == OLD.user_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_project_del_access_token AFTER DELETE ON project
+BEGIN
+	DELETE FROM access_token
+	WHERE project_id == OLD.project_id;
+END;
+
+
 CREATE TABLE IF NOT EXISTS general (
 	class TEXT,
 	key1 TEXT,
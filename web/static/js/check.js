@@ -0,0 +1,24 @@
+const checkInputs = {
+	"01": ["min_value", "max_value"],
+	"02": ["pattern"],
+	"03": ["allowed_values"],
+	"09": ["expression"],
+}
+
+const setInputControl = (checkTypeCls) => {
+	Object.keys(checkInputs).forEach((cls) => {
+		checkInputs[cls].forEach((id) => {
+			document.getElementById(id).disabled = (cls !== checkTypeCls)
+			document.getElementById(id).required = (cls === checkTypeCls && cls !== "01")
+		})
+	})
+}
+
+
+document.addEventListener("DOMContentLoaded", () => {
+	setInputControl(document.getElementById("check_type_cls").value)
+})
+
+document.getElementById("check_type_cls").addEventListener("change", (e) => {
+	setInputControl(e.target.value)
+})
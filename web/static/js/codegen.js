@@ -1,23 +1,5 @@
-document.getElementById("all").addEventListener("click", (e) => {
-	let ls = document.getElementsByName("table_id");
-
-	for (let e of ls) {
-		e.checked = true
-	}
-})
-
-
-document.getElementById("clear").addEventListener("click", (e) => {
-	let ls = document.getElementsByName("table_id");
-
-	for (let e of ls) {
-		e.checked = false
-	}
-})
-
-
-const getChechedValues = () => {
-	let ls = document.getElementsByName("table_id");
+const getChechedValues = (name = "table_id") => {
+	let ls = document.getElementsByName(name);
 	let ret = [];
 
 	for (let x of ls) {
@@ -28,14 +10,20 @@ const getChechedValues = () => {
 	return ret
 }
 
-document.getElementById("cg-goat").addEventListener("click", (e) => {
+const codegen = (generator) => {
 	let tableids = getChechedValues()
+	let viewids = getChechedValues("view_id")
 	let dbtype = document.getElementById("db_type").value
+	let zod = document.getElementById("zod").checked
+	let grpc = document.getElementById("grpc").checked
+	let orm = document.getElementById("orm").value
+	let seedrows = Number(document.getElementById("seed_rows").value)
+	let seedformat = document.getElementById("seed_format").value
 
-	fetch(`./codegen/goat`, {
+	fetch(`./codegen/${generator}`, {
 		method: "POST",
 		headers: {"Content-Type": "application/json"},
-		body: JSON.stringify({dbtype,tableids})
+		body: JSON.stringify({dbtype,tableids,viewids,zod,grpc,orm,seedrows,seedformat})
 	})
 	.then(response => {
 		return response.text()
@@ -48,7 +36,16 @@ document.getElementById("cg-goat").addEventListener("click", (e) => {
 		return false;
 	})
 	.catch(console.error);
-})
+}
+
+document.getElementById("cg-goat").addEventListener("click", (e) => codegen("goat"))
+document.getElementById("cg-openapi").addEventListener("click", (e) => codegen("openapi"))
+document.getElementById("cg-typescript").addEventListener("click", (e) => codegen("typescript"))
+document.getElementById("cg-proto").addEventListener("click", (e) => codegen("proto"))
+document.getElementById("cg-graphql").addEventListener("click", (e) => codegen("graphql"))
+document.getElementById("cg-jsonschema").addEventListener("click", (e) => codegen("jsonschema"))
+document.getElementById("cg-orm").addEventListener("click", (e) => codegen("orm"))
+document.getElementById("cg-seed").addEventListener("click", (e) => codegen("seed"))
 /*
 document.getElementById("cg-ddl").addEventListener("click", (e) => {
 	let tableids = getChechedValues()
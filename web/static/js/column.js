@@ -17,6 +17,19 @@ const setInputControl = (dataTypeCls) => {
 			document.getElementById("scale").value = 0
 			break;
 	}
+
+	if (dataTypeCls === "14") {
+		const bound = document.getElementById("classification_id").value !== "0"
+		document.getElementById("enum_values").disabled = bound
+		document.getElementById("enum_values").required = !bound
+		if (bound) {
+			document.getElementById("enum_values").value = ""
+		}
+	} else {
+		document.getElementById("enum_values").disabled = true
+		document.getElementById("enum_values").required = false
+		document.getElementById("enum_values").value = ""
+	}
 }
 
 
@@ -26,4 +39,8 @@ document.addEventListener("DOMContentLoaded", () => {
 
 document.getElementById("data_type_cls").addEventListener("change", (e) => {
 	setInputControl(e.target.value)
-})
\ No newline at end of file
+})
+
+document.getElementById("classification_id").addEventListener("change", (e) => {
+	setInputControl(document.getElementById("data_type_cls").value)
+})
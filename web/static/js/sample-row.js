@@ -0,0 +1,60 @@
+const sampleGrid = document.getElementById("sample-grid")
+
+const getSampleColumns = () => {
+	let ret = []
+	for (let th of sampleGrid.querySelectorAll("thead th[data-column]")) {
+		ret.push(th.dataset.column)
+	}
+	return ret
+}
+
+document.getElementById("sample-add").addEventListener("click", (e) => {
+	let tr = document.createElement("tr")
+	for (let i = 0; i < getSampleColumns().length; i++) {
+		let td = document.createElement("td")
+		td.className = "p-0"
+		td.innerHTML = `<input type="text" class="input is-small">`
+		tr.appendChild(td)
+	}
+	let td = document.createElement("td")
+	td.className = "py-1"
+	td.innerHTML = `<button type="button" class="delete sample-del"></button>`
+	tr.appendChild(td)
+	tr.appendChild(document.createElement("td"))
+	sampleGrid.querySelector("tbody").appendChild(tr)
+})
+
+sampleGrid.addEventListener("click", (e) => {
+	if (e.target.classList.contains("sample-del")) {
+		e.target.closest("tr").remove()
+	}
+})
+
+document.getElementById("sample-save").addEventListener("click", (e) => {
+	let columns = getSampleColumns()
+	let rows = []
+	for (let tr of sampleGrid.querySelectorAll("tbody tr")) {
+		let row = {}
+		tr.querySelectorAll("input").forEach((input, i) => {
+			row[columns[i]] = input.value === "" ? null : input.value
+		})
+		rows.push(row)
+	}
+
+	fetch(`${location.pathname}/samples`, {
+		method: "PUT",
+		headers: {"Content-Type": "application/json"},
+		body: JSON.stringify(rows)
+	})
+	.then(response => {
+		return response.json()
+	})
+	.then(data => {
+		if (data.error) {
+			document.getElementById("sample-error").textContent = data.error
+			return
+		}
+		window.location.reload()
+	})
+	.catch(console.error);
+})
\ No newline at end of file
@@ -0,0 +1,22 @@
+document.getElementById("all").addEventListener("click", (e) => {
+	let ls = [
+		...document.getElementsByName("table_id"),
+		...document.getElementsByName("view_id"),
+	];
+
+	for (let e of ls) {
+		e.checked = true
+	}
+})
+
+
+document.getElementById("clear").addEventListener("click", (e) => {
+	let ls = [
+		...document.getElementsByName("table_id"),
+		...document.getElementsByName("view_id"),
+	];
+
+	for (let e of ls) {
+		e.checked = false
+	}
+})
\ No newline at end of file